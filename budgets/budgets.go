@@ -0,0 +1,91 @@
+// Package budgets records the events raised when tasks exceed their estimates and projects their budgets.
+package budgets
+
+import (
+	"errors"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordOverruns records an event if the ended task has exceeded its estimate, and another one if its project
+// has exceeded its budget. An overrun is recorded only once for the same estimate or budget, so raising it
+// lets a new overrun be recorded. It must run inside the transaction that ended the task.
+func RecordOverruns(tx *gorm.DB, task models.Task, now time.Time) ([]models.BudgetEvent, error) {
+	var events []models.BudgetEvent
+
+	if task.EstimatedDuration != nil && task.Duration > *task.EstimatedDuration {
+		event := models.BudgetEvent{
+			Kind:      models.BudgetEventTaskEstimate,
+			TaskID:    task.ID,
+			UserID:    task.UserID,
+			ProjectID: task.ProjectID,
+			Estimated: *task.EstimatedDuration,
+			Actual:    task.Duration,
+		}
+		recorded, err := record(tx, &event, "task_id = ?", task.ID)
+		if err != nil {
+			return events, err
+		}
+		if recorded {
+			events = append(events, event)
+		}
+	}
+
+	if task.ProjectID == nil {
+		return events, nil
+	}
+
+	// Locking the project keeps tasks ended concurrently from recording the same overrun twice.
+	var project models.Project
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, *task.ProjectID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return events, nil
+	}
+	if err != nil || project.BudgetHours == nil {
+		return events, err
+	}
+
+	spent, err := reports.ProjectDuration(tx, project.ID, now)
+	if err != nil {
+		return events, err
+	}
+	budget := reports.BudgetMinutes(*project.BudgetHours)
+	if spent <= budget {
+		return events, nil
+	}
+
+	event := models.BudgetEvent{
+		Kind:      models.BudgetEventProjectBudget,
+		TaskID:    task.ID,
+		UserID:    task.UserID,
+		ProjectID: &project.ID,
+		Estimated: budget,
+		Actual:    spent,
+	}
+	recorded, err := record(tx, &event, "project_id = ?", project.ID)
+	if err != nil {
+		return events, err
+	}
+	if recorded {
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// record creates the event unless an event of the same kind and estimate was already recorded
+// for the subject selected by the condition.
+func record(tx *gorm.DB, event *models.BudgetEvent, condition string, subjectID uint) (bool, error) {
+	var count int64
+	err := tx.Model(&models.BudgetEvent{}).
+		Where("kind = ? AND estimated = ?", event.Kind, event.Estimated).
+		Where(condition, subjectID).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return false, err
+	}
+	return true, tx.Create(event).Error
+}
@@ -0,0 +1,105 @@
+// Command import-timesheets loads a CSV timesheet into the database in one transaction.
+//
+// Usage:
+//
+//	import-timesheets [flags] timesheet.csv
+//
+// The file "-" or no file reads the timesheet from standard input. Columns are mapped to fields
+// with repeated -map flags, e.g. -map start=Начало -map end=Окончание -map user=Сотрудник.
+// If any row is invalid, the errors are printed and nothing is imported.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"time-tracker-go/config"
+	"time-tracker-go/imports"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// mappingFlag collects repeated -map flags.
+type mappingFlag []string
+
+func (m *mappingFlag) String() string {
+	return strings.Join(*m, ",")
+}
+
+func (m *mappingFlag) Set(value string) error {
+	*m = append(*m, value)
+	return nil
+}
+
+func main() {
+	var mappings mappingFlag
+	flag.Var(&mappings, "map", "column mapping field=Column (repeatable); fields: "+strings.Join(imports.TimesheetFields, ", "))
+	delimiter := flag.String("delimiter", ",", "field delimiter: a single character, tab or semicolon")
+	timeFormat := flag.String("time-format", "", "Go layout of start and end times (default: common ISO and Russian formats)")
+	durationUnit := flag.String("duration-unit", imports.DurationMinutes, "unit of durations without a colon: minutes or hours")
+	timeZone := flag.String("tz", "UTC", "time zone of times without an offset")
+	dryRun := flag.Bool("dry-run", false, "validate the timesheet without importing it")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [timesheet.csv]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	options := imports.TimesheetOptions{TimeLayout: *timeFormat, DurationUnit: *durationUnit, DryRun: *dryRun}
+	var err error
+	if options.Comma, err = imports.ParseDelimiter(*delimiter); err != nil {
+		log.Fatal(err)
+	}
+	if options.Columns, err = imports.ParseColumnMapping(mappings); err != nil {
+		log.Fatal(err)
+	}
+	if options.Location, err = time.LoadLocation(*timeZone); err != nil {
+		log.Fatalf("Invalid time zone: %v", err)
+	}
+
+	var input io.Reader = os.Stdin
+	if path := flag.Arg(0); path != "" && path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	// Подключение к базе данных
+	cfg := config.LoadConfig()
+	db, err := gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{Logger: logger.Default.LogMode(logger.Warn)})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	result, err := imports.ImportTimesheet(db, input, options, time.Now())
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	for _, rowError := range result.Errors {
+		if rowError.Column != "" {
+			fmt.Fprintf(os.Stderr, "row %d, column %q: %s\n", rowError.Row, rowError.Column, rowError.Error)
+		} else {
+			fmt.Fprintf(os.Stderr, "row %d: %s\n", rowError.Row, rowError.Error)
+		}
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		fmt.Printf("%d rows read, %d errors: nothing imported\n", result.Rows, len(result.Errors))
+		os.Exit(1)
+	case result.DryRun:
+		fmt.Printf("%d rows read: %d tasks for %d users would be imported\n", result.Rows, result.Created, result.Users)
+	default:
+		fmt.Printf("%d rows read: %d tasks for %d users imported\n", result.Rows, result.Created, result.Users)
+	}
+}
@@ -1,42 +1,102 @@
+// Command main runs the time tracker server and manages its database.
+//
+// Usage:
+//
+//	go run ./cmd [serve] [--migrate=false]
+//	go run ./cmd migrate up|down|redo|status [--steps N] [--force]
+//	go run ./cmd seed --env=dev [--force]
+//
+// Without a command the server is started. Seeding deletes all data, so it, "migrate down" and
+// "migrate redo" are refused unless --force is given or both APP_ENV and the environment recorded
+// in the database by its first migration are "dev".
 package main
 
 import (
+	"fmt"
 	"log"
-	"net/http"
+	"os"
 	"time-tracker-go/config"
 	"time-tracker-go/migrations"
-	"time-tracker-go/routes"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// commands maps command names to their implementations.
+var commands = map[string]func(args []string){
+	"serve":   runServe,
+	"migrate": runMigrate,
+	"seed":    runSeed,
+}
+
+const usage = `Usage: main <command> [flags]
+
+Commands:
+  serve                         Apply migrations and start the HTTP and gRPC servers (default)
+  migrate up|down|redo|status   Apply, revert or show the versioned migrations of the database
+  seed --env=dev                Replace all data with development data
+
+Run "main <command> --help" for the flags of a command.
+`
+
 func main() {
-	// Загрузка конфигурации
-	cfg := config.LoadConfig()
+	if len(os.Args) < 2 {
+		runServe(nil)
+		return
+	}
 
-	// Подключение к базе данных
+	switch os.Args[1] {
+	case "help", "--help", "-h":
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	run(os.Args[2:])
+}
+
+// connect opens the database of the configuration.
+func connect(cfg config.Config) *gorm.DB {
 	log.Println("Connecting to database...")
 	db, err := gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	log.Println("Database connection established successfully.")
+	return db
+}
 
-	// Выполнение миграций
-	log.Println("Applying migrations...")
-	migrations.Migrate(db)
-
-	// Загрузка начальных данных
-	log.Println("Seeding initial data...")
-	migrations.Seed(db)
-
-	// Настройка маршрутов
-	log.Println("Setting up routes...")
-	router := routes.SetupRoutes(db, cfg)
+// recordEnvironment records the environment of the configuration in a migrated database that has none yet,
+// and warns if the database belongs to another environment.
+func recordEnvironment(cfg config.Config, db *gorm.DB) {
+	env, err := migrations.RecordEnvironment(db, cfg.Environment)
+	if err != nil {
+		log.Fatalf("Failed to record database environment: %v", err)
+	}
+	if env != cfg.Environment {
+		log.Printf("Warning: APP_ENV is %q, but the database belongs to the %q environment", cfg.Environment, env)
+	}
+}
 
-	// Запуск сервера
-	serverAddr := ":8080"
-	log.Printf("Server is running at http://localhost%s", serverAddr)
-	log.Fatal(http.ListenAndServe(serverAddr, router))
+// requireDev exits unless both APP_ENV and the environment recorded in the database are "dev",
+// or the action is forced.
+func requireDev(cfg config.Config, db *gorm.DB, action string, force bool) {
+	env, err := migrations.Environment(db)
+	if err != nil {
+		log.Fatalf("Failed to read database environment: %v", err)
+	}
+	if cfg.Environment == config.EnvDev && env == config.EnvDev {
+		return
+	}
+	if env == "" {
+		env = "unknown"
+	}
+	if !force {
+		log.Fatalf("Refusing to %s: APP_ENV is %q and the database environment is %q, both must be %q. Use --force to %s anyway.", action, cfg.Environment, env, config.EnvDev, action)
+	}
+	log.Printf("Warning: forced to %s (APP_ENV %q, database environment %q)", action, cfg.Environment, env)
 }
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time-tracker-go/config"
+	"time-tracker-go/migrations"
+)
+
+const migrateUsage = "Usage: main migrate up|down|redo|status [--steps N] [--force]"
+
+// runMigrate applies, reverts or shows the versioned migrations of the database.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	steps := fs.Int("steps", 1, "Number of migrations to revert (down)")
+	force := fs.Bool("force", false, "Revert migrations even if APP_ENV or the database environment is not dev")
+	fs.Parse(args[1:])
+
+	switch args[0] {
+	case "up":
+		cfg := config.LoadConfig()
+		db := connect(cfg)
+		log.Println("Applying migrations...")
+		migrations.Migrate(db)
+		recordEnvironment(cfg, db)
+	case "down":
+		if *steps < 1 {
+			log.Fatal("--steps must be at least 1")
+		}
+		cfg := config.LoadConfig()
+		db := connect(cfg)
+		requireDev(cfg, db, "revert migrations", *force)
+		reverted, err := migrations.Down(db, *steps)
+		if err != nil {
+			log.Fatalf("Failed to revert migrations: %v", err)
+		}
+		for _, migration := range reverted {
+			log.Printf("Reverted migration %s", migration)
+		}
+		if len(reverted) == 0 {
+			log.Println("No migration to revert")
+		}
+	case "redo":
+		cfg := config.LoadConfig()
+		db := connect(cfg)
+		requireDev(cfg, db, "redo the last migration", *force)
+		migration, err := migrations.Redo(db)
+		if err != nil {
+			log.Fatalf("Failed to redo migration: %v", err)
+		}
+		log.Printf("Reverted and applied migration %s", migration)
+	case "status":
+		db := connect(config.LoadConfig())
+		statuses, err := migrations.Status(db)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "-"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(table, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, status.State, appliedAt)
+		}
+		table.Flush()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q\n%s\n", args[0], migrateUsage)
+		os.Exit(2)
+	}
+}
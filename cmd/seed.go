@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time-tracker-go/config"
+	"time-tracker-go/migrations"
+)
+
+// runSeed replaces all data of the database with the seed data of an environment.
+func runSeed(args []string) {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	env := fs.String("env", "", "Environment of the seed data (only dev exists)")
+	force := fs.Bool("force", false, "Seed even if APP_ENV or the database environment is not dev")
+	fs.Parse(args)
+
+	if *env != config.EnvDev {
+		log.Fatalf("No seed data for environment %q; use --env=%s", *env, config.EnvDev)
+	}
+
+	cfg := config.LoadConfig()
+	db := connect(cfg)
+	requireDev(cfg, db, "delete all data and seed", *force)
+
+	log.Println("Seeding initial data...")
+	migrations.Seed(db)
+}
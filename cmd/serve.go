@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"time-tracker-go/config"
+	"time-tracker-go/grpcapi"
+	"time-tracker-go/live"
+	"time-tracker-go/migrations"
+	"time-tracker-go/routes"
+	"time-tracker-go/services"
+	"time-tracker-go/webhooks"
+)
+
+// runServe starts the HTTP and gRPC servers. It never seeds data.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	migrate := fs.Bool("migrate", true, "Apply migrations before starting")
+	fs.Parse(args)
+
+	// Загрузка конфигурации
+	cfg := config.LoadConfig()
+
+	// Подключение к базе данных
+	db := connect(cfg)
+
+	// Выполнение миграций
+	if *migrate {
+		log.Println("Applying migrations...")
+		migrations.Migrate(db)
+		recordEnvironment(cfg, db)
+	}
+
+	// Запуск доставки вебхуков
+	log.Println("Starting webhook dispatcher...")
+	dispatcher := webhooks.NewDispatcher(db)
+	go dispatcher.Run(context.Background())
+
+	// Трансляция изменений таймеров (последние 1000 событий хранятся для возобновления потоков)
+	broker := live.NewBroker(1000)
+
+	// Бизнес-логика пользователей и задач, общая для REST и gRPC
+	users := services.NewUsers(db, cfg, dispatcher)
+	tasks := services.NewTasks(db, dispatcher, broker)
+
+	// Запуск gRPC-сервера
+	grpcAddr := ":" + cfg.GRPCPort
+	listener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", grpcAddr, err)
+	}
+	go func() {
+		log.Printf("gRPC server is running at localhost%s", grpcAddr)
+		log.Fatal(grpcapi.NewServer(users, tasks, broker).Serve(listener))
+	}()
+
+	// Настройка маршрутов
+	log.Println("Setting up routes...")
+	router := routes.SetupRoutes(db, cfg, users, tasks, dispatcher, broker)
+
+	// Запуск сервера
+	serverAddr := ":8080"
+	log.Printf("Server is running at http://localhost%s", serverAddr)
+	log.Fatal(http.ListenAndServe(serverAddr, router))
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// timeLayout is the format of the start_date and end_date query parameters of the API, in UTC.
+const timeLayout = "2006-01-02T15:04:05"
+
+// Client calls the REST API of the time tracker on behalf of a user.
+type Client struct {
+	Server string // Base URL of the server
+	UserID uint   // ID of the user
+	HTTP   *http.Client
+}
+
+// NewClient creates a client for the server and user of the configuration.
+func NewClient(config Config) *Client {
+	return &Client{
+		Server: strings.TrimRight(config.Server, "/"),
+		UserID: config.UserID,
+		HTTP:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// APIError is an error response of the API.
+type APIError struct {
+	StatusCode int    // HTTP status code
+	Message    string // Error message of the response
+	Reason     string // Machine-readable reason of conflicts, if any
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// userPath returns the path of a resource of the user.
+func (c *Client) userPath(format string, args ...interface{}) string {
+	return fmt.Sprintf("/users/%d", c.UserID) + fmt.Sprintf(format, args...)
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into out, if not nil.
+// Error responses are returned as *APIError.
+func (c *Client) do(method, path string, query url.Values, body, out interface{}) error {
+	target := c.Server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot reach %s: %w", c.Server, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+		// Conflicts and locked periods are described by a JSON body, other errors by plain text.
+		var response struct {
+			Error  string `json:"error"`
+			Reason string `json:"reason"`
+		}
+		if json.Unmarshal(data, &response) == nil && response.Error != "" {
+			apiErr.Message, apiErr.Reason = response.Error, response.Reason
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// period returns the query parameters selecting the period [from, to).
+func period(from, to time.Time) url.Values {
+	return url.Values{
+		"start_date": {from.UTC().Format(timeLayout)},
+		"end_date":   {to.UTC().Format(timeLayout)},
+	}
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+)
+
+// errNoRunningTask is returned by commands that need a running task when there is none.
+var errNoRunningTask = errors.New("no running task")
+
+// idList collects IDs from a repeatable flag.
+type idList []uint
+
+func (l *idList) String() string {
+	return fmt.Sprint(*l)
+}
+
+func (l *idList) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err != nil || id == 0 {
+			return fmt.Errorf("invalid ID %q", part)
+		}
+		*l = append(*l, uint(id))
+	}
+	return nil
+}
+
+// runStart adds a task and starts it, or starts or resumes an existing task.
+func runStart(app *App, args []string) error {
+	fs := app.flags("start", `tt start "description" [--project ID] [--tag ID]... [--billable] [--switch]
+       tt start --task ID [--switch]`)
+	projectID := fs.Uint("project", 0, "Project of the new task")
+	var tagIDs idList
+	fs.Var(&tagIDs, "tag", "Tag of the new task (repeatable or comma-separated)")
+	billable := fs.Bool("billable", false, "Bill the time of the new task")
+	taskID := fs.Uint("task", 0, "Start or resume an existing task instead of adding one")
+	switchRunning := fs.Bool("switch", false, "End the running task first")
+	positional, err := app.parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	client, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	var task models.Task
+	action := models.TaskActionStart
+	created := false // Whether the task was added by this command
+	switch {
+	case *taskID != 0 && len(positional) > 0:
+		return usageError(fs, "give either a description or --task, not both")
+	case *taskID != 0:
+		if err := client.do("GET", client.userPath("/tasks/%d", *taskID), nil, nil, &task); err != nil {
+			return err
+		}
+		if task.Status == models.TaskStatusPaused {
+			action = models.TaskActionResume
+		}
+	case len(positional) > 0:
+		// Fail before adding the task, so that it is not left behind unstarted.
+		if !*switchRunning {
+			running, err := runningTask(client)
+			if err != nil {
+				return err
+			}
+			if running != nil {
+				return fmt.Errorf("task #%d %q is running; stop it first or use --switch", running.ID, running.Description)
+			}
+		}
+		task = models.Task{Description: strings.Join(positional, " "), Billable: *billable}
+		if *projectID != 0 {
+			id := uint(*projectID)
+			task.ProjectID = &id
+		}
+		for _, tagID := range tagIDs {
+			var tag models.Tag
+			tag.ID = tagID
+			task.Tags = append(task.Tags, tag)
+		}
+		if err := client.do("POST", client.userPath("/tasks"), nil, task, &task); err != nil {
+			return err
+		}
+		created = true
+	default:
+		return usageError(fs, "missing task description")
+	}
+
+	query := url.Values{}
+	if *switchRunning {
+		query.Set("switch", "true")
+	}
+	if err := client.do("PUT", client.userPath("/tasks/%d/%s", task.ID, action), query, nil, &task); err != nil {
+		if created {
+			// Another task may have been started in the meantime; remove the task added above.
+			if deleteErr := client.do("DELETE", client.userPath("/tasks/%d", task.ID), nil, nil, nil); deleteErr != nil {
+				fmt.Fprintf(app.Err, "tt: could not remove unstarted task #%d: %v\n", task.ID, deleteErr)
+			}
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Reason == models.ReasonAnotherTaskRunning {
+			return fmt.Errorf("%w; stop it first or use --switch", err)
+		}
+		return err
+	}
+
+	if app.JSON {
+		return app.printJSON(task)
+	}
+	verb := "Started"
+	if action == models.TaskActionResume {
+		verb = "Resumed"
+	}
+	app.printf("%s task #%d %q at %s\n", verb, task.ID, task.Description, time.Now().Format("15:04"))
+	return nil
+}
+
+// runStop ends the running task.
+func runStop(app *App, args []string) error {
+	fs := app.flags("stop", "tt stop")
+	if _, err := app.parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	task, err := runningTask(client)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return errNoRunningTask
+	}
+
+	if err := client.do("PUT", client.userPath("/tasks/%d/end", task.ID), nil, nil, task); err != nil {
+		return err
+	}
+
+	if app.JSON {
+		return app.printJSON(task)
+	}
+	app.printf("Stopped task #%d %q after %s\n", task.ID, task.Description, formatMinutes(task.Duration))
+	return nil
+}
+
+// runStatus shows the running task and the time spent on it.
+func runStatus(app *App, args []string) error {
+	fs := app.flags("status", "tt status")
+	if _, err := app.parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	task, err := runningTask(client)
+	if err != nil {
+		return err
+	}
+
+	if app.JSON {
+		return app.printJSON(task)
+	}
+	if task == nil {
+		app.printf("No running task\n")
+		return nil
+	}
+
+	since := task.StartTime
+	if interval := task.OpenInterval(); interval != nil {
+		since = interval.StartTime
+	}
+	app.printf("Running task #%d %q for %s (since %s)\n", task.ID, task.Description, formatDuration(elapsed(*task, time.Now())), since.Local().Format("15:04"))
+	return nil
+}
+
+// runLog lists the tasks worked on today or this week.
+func runLog(app *App, args []string) error {
+	fs := app.flags("log", "tt log [--week]")
+	week := fs.Bool("week", false, "Show this week instead of today")
+	if _, err := app.parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	from := startOfDay(now)
+	to := from.AddDate(0, 0, 1)
+	title := from.Format("Monday, 2 January 2006")
+	if *week {
+		from = startOfWeek(now)
+		to = from.AddDate(0, 0, 7)
+		title = fmt.Sprintf("Week of %s – %s", from.Format("2 Jan"), to.AddDate(0, 0, -1).Format("2 Jan 2006"))
+	}
+
+	var entries reports.TimeEntries
+	if err := client.do("GET", client.userPath("/time-entries"), period(from, to), nil, &entries); err != nil {
+		return err
+	}
+
+	if app.JSON {
+		return app.printJSON(entries)
+	}
+
+	app.printf("%s\n\n", title)
+	if len(entries.Entries) == 0 {
+		app.printf("No time tracked\n")
+		return nil
+	}
+
+	table := app.table()
+	fmt.Fprintln(table, "ID\tTIME\tSTATUS\tDESCRIPTION")
+	for _, entry := range entries.Entries {
+		fmt.Fprintf(table, "#%d\t%s\t%s\t%s\n", entry.ID, formatMinutes(entry.PeriodDuration), entry.Status, entry.Description)
+	}
+	table.Flush()
+
+	app.printf("\nTotal %s, billable %s, amount %.2f\n", formatMinutes(entries.TotalDuration), formatMinutes(entries.TotalBillableDuration), entries.TotalAmount)
+	return nil
+}
+
+// runReport shows the time spent this month or this week, day by day.
+func runReport(app *App, args []string) error {
+	fs := app.flags("report", "tt report [--month | --week] [--by day|week]")
+	fs.Bool("month", true, "Report this month")
+	week := fs.Bool("week", false, "Report this week instead of this month")
+	groupBy := fs.String("by", reports.GroupByDay, "Grouping: day or week")
+	if _, err := app.parse(fs, args); err != nil {
+		return err
+	}
+	if *groupBy != reports.GroupByDay && *groupBy != reports.GroupByWeek {
+		return usageError(fs, "--by must be day or week")
+	}
+
+	client, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 1, 0)
+	title := from.Format("January 2006")
+	if *week {
+		from = startOfWeek(now)
+		to = from.AddDate(0, 0, 7)
+		title = fmt.Sprintf("Week of %s – %s", from.Format("2 Jan"), to.AddDate(0, 0, -1).Format("2 Jan 2006"))
+	}
+
+	query := period(from, to)
+	query.Set("group_by", *groupBy)
+	var timesheet reports.Timesheet
+	if err := client.do("GET", client.userPath("/reports/timesheet"), query, nil, &timesheet); err != nil {
+		return err
+	}
+
+	if app.JSON {
+		return app.printJSON(timesheet)
+	}
+
+	app.printf("%s\n\n", title)
+	table := app.table()
+	fmt.Fprintln(table, "PERIOD\tTIME\tBILLABLE\tAMOUNT")
+	for _, bucket := range timesheet.Buckets {
+		if bucket.Duration == 0 {
+			continue
+		}
+		label := bucket.Start.Local().Format("Mon 02 Jan")
+		if *groupBy == reports.GroupByWeek {
+			label = "Week of " + bucket.Start.Local().Format("02 Jan")
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%.2f\n", label, formatMinutes(bucket.Duration), formatMinutes(bucket.BillableDuration), bucket.Amount)
+	}
+	fmt.Fprintf(table, "Total\t%s\t%s\t%.2f\n", formatMinutes(timesheet.TotalDuration), formatMinutes(timesheet.TotalBillableDuration), timesheet.TotalAmount)
+	table.Flush()
+
+	if len(timesheet.Tasks) > 0 {
+		app.printf("\n")
+		table = app.table()
+		fmt.Fprintln(table, "ID\tTIME\tDESCRIPTION")
+		for _, task := range timesheet.Tasks {
+			fmt.Fprintf(table, "#%d\t%s\t%s\n", task.TaskID, formatMinutes(task.Duration), task.Description)
+		}
+		table.Flush()
+	}
+	return nil
+}
+
+// runConfig shows the configuration, or changes it when flags are given.
+func runConfig(app *App, args []string) error {
+	fs := app.flags("config", "tt config [--server URL] [--user ID]")
+	server := fs.String("server", "", "Base URL of the time tracker server")
+	userID := fs.Uint("user", 0, "ID of your user")
+	if _, err := app.parse(fs, args); err != nil {
+		return err
+	}
+
+	// Only the file is changed, so that settings from environment variables are not persisted.
+	config, err := readConfigFile(app.ConfigPath)
+	if err != nil {
+		return err
+	}
+	changed := false
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "server":
+			config.Server, app.Config.Server = *server, *server
+			changed = true
+		case "user":
+			config.UserID, app.Config.UserID = *userID, *userID
+			changed = true
+		}
+	})
+	if changed {
+		if err := saveConfig(app.ConfigPath, config); err != nil {
+			return err
+		}
+	}
+
+	if app.JSON {
+		return app.printJSON(app.Config)
+	}
+	app.printf("Config file: %s\nServer: %s\nUser ID: %d\n", app.ConfigPath, app.Config.Server, app.Config.UserID)
+	return nil
+}
+
+// runningTask returns the running task of the user, or nil if there is none.
+func runningTask(client *Client) (*models.Task, error) {
+	var tasks []models.Task
+	query := url.Values{"status": {string(models.TaskStatusRunning)}, "pageSize": {"1"}}
+	if err := client.do("GET", client.userPath("/tasks"), query, nil, &tasks); err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	return &tasks[0], nil
+}
+
+// elapsed returns the time spent on the task up to now, including its running interval.
+func elapsed(task models.Task, now time.Time) time.Duration {
+	var total time.Duration
+	for _, interval := range task.Intervals {
+		total += interval.Length(now)
+	}
+	return total
+}
+
+// startOfDay returns midnight of the day of t.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns midnight of the Monday of the week of t.
+func startOfWeek(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
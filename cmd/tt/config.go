@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// defaultServer is the address of a time tracker server running locally.
+const defaultServer = "http://localhost:8080"
+
+// Config is the configuration of the client, stored as JSON in the config file.
+type Config struct {
+	Server string `json:"server"` // Base URL of the time tracker server
+	UserID uint   `json:"userID"` // ID of the user whose timers are managed
+}
+
+// configPath returns the path of the config file: the TT_CONFIG environment variable,
+// or tt/config.json in the user configuration directory.
+func configPath() (string, error) {
+	if path := os.Getenv("TT_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tt", "config.json"), nil
+}
+
+// loadConfig reads the config file, if any, and applies the TT_SERVER and TT_USER_ID environment variables.
+func loadConfig(path string) (Config, error) {
+	config, err := readConfigFile(path)
+	if err != nil {
+		return config, err
+	}
+
+	if server := os.Getenv("TT_SERVER"); server != "" {
+		config.Server = server
+	}
+	if userID := os.Getenv("TT_USER_ID"); userID != "" {
+		id, err := strconv.ParseUint(userID, 10, 64)
+		if err != nil {
+			return config, fmt.Errorf("invalid TT_USER_ID: %w", err)
+		}
+		config.UserID = uint(id)
+	}
+
+	return config, nil
+}
+
+// readConfigFile reads the config file. A missing file yields the default configuration.
+func readConfigFile(path string) (Config, error) {
+	config := Config{Server: defaultServer}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// saveConfig writes the config file, creating its directory if needed.
+func saveConfig(path string, config Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
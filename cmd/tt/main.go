@@ -0,0 +1,151 @@
+// Command tt starts and stops timers of the time tracker from the terminal.
+//
+// Usage:
+//
+//	tt config --server http://localhost:8080 --user 1
+//	tt start "Fix the login form" [--project ID] [--tag ID]... [--billable] [--switch]
+//	tt stop
+//	tt status
+//	tt log [--week]
+//	tt report [--month | --week]
+//
+// Every command accepts --json to print the API response instead of text. The server URL and user ID
+// are read from the config file (see tt config), or from the TT_SERVER and TT_USER_ID environment variables.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// commands maps command names to their implementations.
+var commands = map[string]func(app *App, args []string) error{
+	"start":  runStart,
+	"stop":   runStop,
+	"status": runStatus,
+	"log":    runLog,
+	"report": runReport,
+	"config": runConfig,
+}
+
+const usage = `Usage: tt <command> [flags]
+
+Commands:
+  start "description"  Add a task and start its timer (--task ID starts or resumes an existing task)
+  stop                 End the running task
+  status               Show the running task
+  log [--week]         List the tasks worked on today or this week
+  report [--month]     Show the time spent this month (or --week) day by day
+  config               Show or change the server URL (--server) and user ID (--user)
+
+Every command accepts --json to print JSON instead of text.
+Run "tt <command> --help" for the flags of a command.
+`
+
+// errUsage is returned when a command is used incorrectly, after its usage has been printed.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "help" || os.Args[1] == "--help" || os.Args[1] == "-h" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "tt: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	app, err := NewApp(os.Stdout, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tt: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := run(app, os.Args[2:]); err != nil {
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			os.Exit(0)
+		case errors.Is(err, errUsage):
+			os.Exit(2)
+		default:
+			fmt.Fprintf(os.Stderr, "tt: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// App holds the configuration and output of a command.
+type App struct {
+	Config     Config
+	ConfigPath string
+	JSON       bool // Print JSON instead of text
+	Out        io.Writer
+	Err        io.Writer
+}
+
+// NewApp loads the configuration for a command writing to the given outputs.
+func NewApp(out, errOut io.Writer) (*App, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := loadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &App{Config: config, ConfigPath: path, Out: out, Err: errOut}, nil
+}
+
+// flags creates the flag set of a command with the flags shared by all commands.
+func (app *App) flags(name, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(app.Err)
+	fs.BoolVar(&app.JSON, "json", false, "Print JSON instead of text")
+	fs.Usage = func() {
+		fmt.Fprintf(app.Err, "Usage: %s\n\nFlags:\n", synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses the arguments of a command and returns its positional arguments.
+// Unlike flag.Parse, it accepts flags after positional arguments.
+func (app *App) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// client returns an API client, or an error if no user is configured.
+func (app *App) client() (*Client, error) {
+	if app.Config.UserID == 0 {
+		return nil, errors.New(`no user configured; run "tt config --user ID" or set TT_USER_ID`)
+	}
+	return NewClient(app.Config), nil
+}
+
+// usageError prints the message and the usage of the command.
+func usageError(fs *flag.FlagSet, message string) error {
+	fmt.Fprintf(fs.Output(), "%s\n\n", message)
+	fs.Usage()
+	return errUsage
+}
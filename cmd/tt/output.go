@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+)
+
+// printf prints human-readable output.
+func (app *App) printf(format string, args ...interface{}) {
+	fmt.Fprintf(app.Out, format, args...)
+}
+
+// printJSON prints a value as indented JSON.
+func (app *App) printJSON(value interface{}) error {
+	encoder := json.NewEncoder(app.Out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// table returns a writer aligning tab-separated columns. It must be flushed.
+func (app *App) table() *tabwriter.Writer {
+	return tabwriter.NewWriter(app.Out, 0, 0, 2, ' ', 0)
+}
+
+// formatMinutes formats minutes as hours and minutes, e.g. "1h 05m".
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// formatDuration formats a duration as hours and minutes, e.g. "1h 05m".
+func formatDuration(d time.Duration) string {
+	return formatMinutes(int(d.Minutes()))
+}
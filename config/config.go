@@ -4,14 +4,23 @@ import (
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
 )
 
 // Config represents the application configuration.
 type Config struct {
-	DatabaseURL    string
-	ExternalAPIURL string
+	DatabaseURL      string
+	ExternalAPIURL   string
+	Currency         string  // Currency of rates and invoices (ISO 4217 code)
+	VATRate          float64 // Default VAT rate of invoices in percent
+	OverrunThreshold float64 // Share of an estimate or budget in percent from which overruns are reported as at risk
+	GRPCPort         string  // Port of the gRPC server
+	Environment      string  // Environment of the database, e.g. "dev" or "production"
 }
 
+// EnvDev is the environment of development databases, the only ones that may be seeded or rolled back without --force.
+const EnvDev = "dev"
+
 // @Summary Load application configuration
 // @Description Loads application configuration from environment variables
 // @Tags config
@@ -24,8 +33,39 @@ func LoadConfig() Config {
 	}
 
 	config := Config{
-		DatabaseURL:    os.Getenv("DATABASE_URL"),
-		ExternalAPIURL: os.Getenv("EXTERNAL_API_URL"),
+		DatabaseURL:      os.Getenv("DATABASE_URL"),
+		ExternalAPIURL:   os.Getenv("EXTERNAL_API_URL"),
+		Currency:         os.Getenv("CURRENCY"),
+		VATRate:          20,
+		OverrunThreshold: 80,
+		GRPCPort:         os.Getenv("GRPC_PORT"),
+		Environment:      os.Getenv("APP_ENV"),
+	}
+
+	if config.Currency == "" {
+		config.Currency = "RUB"
+	}
+
+	if config.GRPCPort == "" {
+		config.GRPCPort = "9090"
+	}
+
+	if config.Environment == "" {
+		config.Environment = "production"
+	}
+
+	if vatRate := os.Getenv("VAT_RATE"); vatRate != "" {
+		config.VATRate, err = strconv.ParseFloat(vatRate, 64)
+		if err != nil {
+			log.Fatalf("Invalid VAT_RATE: %v", err)
+		}
+	}
+
+	if threshold := os.Getenv("OVERRUN_THRESHOLD"); threshold != "" {
+		config.OverrunThreshold, err = strconv.ParseFloat(threshold, 64)
+		if err != nil || config.OverrunThreshold <= 0 || config.OverrunThreshold > 100 {
+			log.Fatalf("Invalid OVERRUN_THRESHOLD: must be a percentage between 0 and 100")
+		}
 	}
 
 	return config
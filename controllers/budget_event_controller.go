@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"time-tracker-go/models"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// BudgetEventController handles HTTP requests related to budget events.
+type BudgetEventController struct {
+	DB *gorm.DB
+}
+
+// NewBudgetEventController creates a new instance of BudgetEventController with the given DB connection.
+func NewBudgetEventController(db *gorm.DB) *BudgetEventController {
+	return &BudgetEventController{DB: db}
+}
+
+// @Summary Get budget events
+// @Description Retrieves the events recorded when ending a task pushed it over its estimate or its project over budget, newest first.
+// @Description Supports kind, acknowledgement, project and user filters and pagination.
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param kind query string false "Event kind: task_estimate_exceeded or project_budget_exceeded"
+// @Param acknowledged query bool false "Only acknowledged (true) or new (false) events"
+// @Param project_id query int false "Project ID"
+// @Param user_id query int false "User ID"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} models.BudgetEvent
+// @Router /budget-events [get]
+func (bc *BudgetEventController) GetBudgetEvents(w http.ResponseWriter, r *http.Request) {
+	var events []models.BudgetEvent
+	query := preloadBudgetEvent(bc.DB)
+
+	// Filtration
+	if kind := r.URL.Query().Get("kind"); kind != "" {
+		if kind != models.BudgetEventTaskEstimate && kind != models.BudgetEventProjectBudget {
+			http.Error(w, "Invalid kind", http.StatusBadRequest)
+			log.Printf("Invalid kind: %s", kind)
+			return
+		}
+		query = query.Where("kind = ?", kind)
+	}
+
+	if acknowledgedStr := r.URL.Query().Get("acknowledged"); acknowledgedStr != "" {
+		acknowledged, err := strconv.ParseBool(acknowledgedStr)
+		if err != nil {
+			http.Error(w, "Invalid acknowledged flag", http.StatusBadRequest)
+			log.Printf("Invalid acknowledged flag: %v", err)
+			return
+		}
+		if acknowledged {
+			query = query.Where("acknowledged_at IS NOT NULL")
+		} else {
+			query = query.Where("acknowledged_at IS NULL")
+		}
+	}
+
+	for _, param := range []string{"project_id", "user_id"} {
+		if idStr := r.URL.Query().Get(param); idStr != "" {
+			id, err := strconv.Atoi(idStr)
+			if err != nil {
+				http.Error(w, "Invalid "+param, http.StatusBadRequest)
+				log.Printf("Invalid %s: %v", param, err)
+				return
+			}
+			query = query.Where(param+" = ?", id)
+		}
+	}
+
+	// Pagination
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := query.Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&events).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching budget events: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(events)
+
+	log.Printf("Fetched %d budget events", len(events))
+}
+
+// @Summary Acknowledge a budget event
+// @Description Marks a budget event as seen by a manager. Acknowledging an event again keeps the original time.
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param eventID path int true "Budget event ID"
+// @Success 200 {object} models.BudgetEvent
+// @Router /budget-events/{eventID}/acknowledge [put]
+func (bc *BudgetEventController) AcknowledgeBudgetEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["eventID"])
+	if err != nil {
+		http.Error(w, "Invalid budget event ID", http.StatusBadRequest)
+		log.Printf("Invalid budget event ID: %v", err)
+		return
+	}
+
+	var event models.BudgetEvent
+	if err := bc.DB.First(&event, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "Budget event not found", http.StatusNotFound)
+			log.Printf("Budget event not found with ID %d", id)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching budget event: %v", err)
+		return
+	}
+
+	if event.AcknowledgedAt == nil {
+		if err := bc.DB.Model(&event).Update("acknowledged_at", time.Now()).Error; err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			log.Printf("Error acknowledging budget event: %v", err)
+			return
+		}
+	}
+
+	if err := preloadBudgetEvent(bc.DB).First(&event, id).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching budget event: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(event)
+
+	log.Printf("Acknowledged budget event with ID %d", id)
+}
+
+// preloadBudgetEvent returns a query that loads budget events with their task, user and project.
+func preloadBudgetEvent(db *gorm.DB) *gorm.DB {
+	return db.Preload("Task").Preload("User").Preload("Project")
+}
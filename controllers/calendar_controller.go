@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"time-tracker-go/exports"
+	"time-tracker-go/imports"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// calendarFeedDays is the length of the default period of calendar feeds, ending now.
+const calendarFeedDays = 90
+
+// maxCalendarSize is the maximum size of an uploaded calendar file in bytes.
+const maxCalendarSize = 10 << 20
+
+// CalendarController handles HTTP requests related to iCalendar feeds and imports.
+type CalendarController struct {
+	DB *gorm.DB
+}
+
+// NewCalendarController creates a new instance of CalendarController with the given DB connection.
+func NewCalendarController(db *gorm.DB) *CalendarController {
+	return &CalendarController{DB: db}
+}
+
+// @Summary Get the calendar feed of a user
+// @Description Returns the work intervals of a user as an iCalendar feed with one event per interval.
+// @Description Events carry the task description, ID, project, client and tags; running intervals end now.
+// @Description Without start_date and end_date the feed covers the last 90 days.
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path int true "User ID"
+// @Param start_date query string false "Start date, inclusive (format: 2006-01-02T15:04:05)"
+// @Param end_date query string false "End date, exclusive (format: 2006-01-02T15:04:05)"
+// @Param project_id query string false "Comma-separated list of project IDs"
+// @Param client_id query string false "Comma-separated list of client IDs"
+// @Param tags_any query string false "Comma-separated tag names, any of which a task must have"
+// @Param tags_all query string false "Comma-separated tag names, all of which a task must have"
+// @Param tags_none query string false "Comma-separated tag names, none of which a task may have"
+// @Param billable query bool false "Only billable (true) or non-billable (false) tasks"
+// @Success 200 {string} string "iCalendar feed"
+// @Router /users/{id}/calendar.ics [get]
+func (cc *CalendarController) GetUserCalendar(w http.ResponseWriter, r *http.Request) {
+	user, ok := cc.findUser(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	period := reports.Period{From: now.AddDate(0, 0, -calendarFeedDays), To: now}
+	if r.URL.Query().Has("start_date") || r.URL.Query().Has("end_date") {
+		if period, ok = parsePeriod(w, r); !ok {
+			return
+		}
+	}
+
+	filter, ok := parseTaskFilter(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := reports.UserTimeEntries(cc.DB, user.ID, period, filter, now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching time entries: %v", err)
+		return
+	}
+
+	tasks := make([]models.Task, len(entries.Entries))
+	for i, entry := range entries.Entries {
+		tasks[i] = entry.Task
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"user-%d.ics\"", user.ID))
+	name := fmt.Sprintf("Time tracker: %s %s", user.Surname, user.Name)
+	if err := exports.WriteCalendar(w, name, tasks, period, now); err != nil {
+		// The response may have already started, so the feed can only be cut short.
+		log.Printf("Error writing calendar of user %d: %v", user.ID, err)
+		return
+	}
+
+	log.Printf("Exported calendar of user %d with %d tasks", user.ID, len(tasks))
+}
+
+// @Summary Import calendar events as tasks
+// @Description Creates a done task with a single work interval for every finished event of an uploaded iCalendar file.
+// @Description The file is sent as the "file" field of a multipart form or as the raw request body.
+// @Description Events already imported for the user, all-day, cancelled and unfinished events, and events exported by this service are skipped.
+// @Description Recurring events are imported as their occurrences within the period, or up to now; events with unsupported recurrence rules are skipped. Event categories are matched to existing tags by name.
+// @Description With dry_run=true nothing is created and the response previews the import.
+// @Tags calendar
+// @Accept multipart/form-data
+// @Accept text/calendar
+// @Produce json
+// @Param id path int true "User ID"
+// @Param file formData file false "iCalendar file"
+// @Param dry_run query bool false "Preview the import without creating tasks"
+// @Param project_id query int false "Project to assign the imported tasks to"
+// @Param start_date query string false "Only import events starting at or after (format: 2006-01-02T15:04:05)"
+// @Param end_date query string false "Only import events starting before (format: 2006-01-02T15:04:05)"
+// @Param time_zone query string false "Time zone of event times without one, e.g. Europe/Moscow (default: UTC)"
+// @Success 200 {object} imports.CalendarResult "Dry run"
+// @Success 201 {object} imports.CalendarResult
+// @Router /users/{id}/calendar/import [post]
+func (cc *CalendarController) ImportUserCalendar(w http.ResponseWriter, r *http.Request) {
+	user, ok := cc.findUser(w, r)
+	if !ok {
+		return
+	}
+
+	dryRun, ok := parseDryRun(w, r)
+	if !ok {
+		return
+	}
+	options := imports.CalendarOptions{UserID: user.ID, OwnDomain: exports.CalendarUIDDomain, DryRun: dryRun}
+
+	if projectIDStr := r.URL.Query().Get("project_id"); projectIDStr != "" {
+		projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid project_id", http.StatusBadRequest)
+			log.Printf("Invalid project_id: %v", err)
+			return
+		}
+		if !recordExists(w, cc.DB, &models.Project{}, "Project", uint(projectID)) {
+			return
+		}
+		id := uint(projectID)
+		options.ProjectID = &id
+	}
+
+	if r.URL.Query().Has("start_date") || r.URL.Query().Has("end_date") {
+		period, ok := parsePeriod(w, r)
+		if !ok {
+			return
+		}
+		options.Period = &period
+	}
+
+	location := time.UTC
+	if timeZone := r.URL.Query().Get("time_zone"); timeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(timeZone); err != nil {
+			http.Error(w, "Invalid time_zone", http.StatusBadRequest)
+			log.Printf("Invalid time zone: %v", err)
+			return
+		}
+	}
+
+	file, ok := uploadedFile(w, r, maxCalendarSize)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	events, err := imports.ParseCalendar(file, location)
+	if err != nil {
+		if isTooLarge(err) {
+			http.Error(w, "Calendar file is too large", http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, "Invalid calendar file: "+err.Error(), http.StatusBadRequest)
+		}
+		log.Printf("Invalid calendar file: %v", err)
+		return
+	}
+
+	result, err := imports.ImportCalendar(cc.DB, events, options, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error importing calendar: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if options.DryRun {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+
+	log.Printf("Imported calendar for user %d: %d of %d events (dry run: %t)", user.ID, result.Created, len(events), options.DryRun)
+}
+
+// findUser loads the user identified by the "id" route parameter.
+// It writes an error response and returns false if the user cannot be loaded.
+func (cc *CalendarController) findUser(w http.ResponseWriter, r *http.Request) (models.User, bool) {
+	var user models.User
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		log.Printf("Invalid user ID: %v", err)
+		return user, false
+	}
+
+	if err := cc.DB.First(&user, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "User not found", http.StatusNotFound)
+			log.Printf("User not found with ID %d", id)
+			return user, false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching user: %v", err)
+		return user, false
+	}
+	return user, true
+}
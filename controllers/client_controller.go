@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time-tracker-go/models"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// ClientController handles HTTP requests related to clients.
+type ClientController struct {
+	DB *gorm.DB
+}
+
+// NewClientController creates a new instance of ClientController with the given DB connection.
+func NewClientController(db *gorm.DB) *ClientController {
+	return &ClientController{DB: db}
+}
+
+// ClientRequest describes the editable fields of a client.
+type ClientRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// @Summary Get clients
+// @Description Retrieves clients with optional name filter and supports pagination
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param name query string false "Name"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} models.Client
+// @Router /clients [get]
+func (cc *ClientController) GetClients(w http.ResponseWriter, r *http.Request) {
+	var clients []models.Client
+	query := cc.DB
+
+	// Filtration
+	if name := r.URL.Query().Get("name"); name != "" {
+		query = query.Where("name = ?", name)
+	}
+
+	// Pagination
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := query.Order("name").Limit(pageSize).Offset(offset).Find(&clients).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching clients: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clients)
+
+	log.Printf("Fetched %d clients", len(clients))
+}
+
+// @Summary Get a client by ID
+// @Description Retrieves a client together with its projects
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param clientID path int true "Client ID"
+// @Success 200 {object} models.Client
+// @Router /clients/{clientID} [get]
+func (cc *ClientController) GetClient(w http.ResponseWriter, r *http.Request) {
+	client, ok := cc.findClient(w, r, cc.DB.Preload("Projects"))
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(client)
+
+	log.Printf("Fetched client with ID %d", client.ID)
+}
+
+// @Summary Add a new client
+// @Description Adds a new client
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param client body ClientRequest true "Client to be added"
+// @Success 201 {object} models.Client
+// @Router /clients [post]
+func (cc *ClientController) AddClient(w http.ResponseWriter, r *http.Request) {
+	var request ClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	if request.Name == "" {
+		http.Error(w, "Client name is required", http.StatusBadRequest)
+		return
+	}
+
+	client := models.Client{Name: request.Name, Email: request.Email}
+	if err := cc.DB.Create(&client).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error creating client: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(client)
+
+	log.Printf("Created client with ID %d", client.ID)
+}
+
+// @Summary Update a client by ID
+// @Description Updates a client's details by its ID
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param clientID path int true "Client ID"
+// @Param client body ClientRequest true "Updated client"
+// @Success 200 {object} models.Client
+// @Router /clients/{clientID} [put]
+func (cc *ClientController) UpdateClient(w http.ResponseWriter, r *http.Request) {
+	client, ok := cc.findClient(w, r, cc.DB)
+	if !ok {
+		return
+	}
+
+	var request ClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	if request.Name == "" {
+		http.Error(w, "Client name is required", http.StatusBadRequest)
+		return
+	}
+
+	client.Name = request.Name
+	client.Email = request.Email
+
+	if err := cc.DB.Save(&client).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error updating client: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(client)
+
+	log.Printf("Updated client with ID %d", client.ID)
+}
+
+// @Summary Delete a client by ID
+// @Description Deletes a client by its ID. Clients that still have projects cannot be deleted.
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param clientID path int true "Client ID"
+// @Success 200 {object} map[string]string
+// @Failure 409 {object} ConflictResponse
+// @Router /clients/{clientID} [delete]
+func (cc *ClientController) DeleteClient(w http.ResponseWriter, r *http.Request) {
+	client, ok := cc.findClient(w, r, cc.DB)
+	if !ok {
+		return
+	}
+
+	var projectCount int64
+	if err := cc.DB.Model(&models.Project{}).Where("client_id = ?", client.ID).Count(&projectCount).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error counting projects: %v", err)
+		return
+	}
+	if projectCount > 0 {
+		writeConflict(w, ConflictResponse{
+			Error:  "the client still has projects",
+			Reason: ReasonClientHasProjects,
+		})
+		log.Printf("Rejected deletion of client %d with %d projects", client.ID, projectCount)
+		return
+	}
+
+	if err := cc.DB.Delete(&client).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error deleting client: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Client deleted successfully"})
+
+	log.Printf("Deleted client with ID %d", client.ID)
+}
+
+// findClient loads the client identified by the "clientID" route parameter.
+// It writes an error response and returns false if the client cannot be loaded.
+func (cc *ClientController) findClient(w http.ResponseWriter, r *http.Request, db *gorm.DB) (models.Client, bool) {
+	var client models.Client
+
+	id, err := strconv.Atoi(mux.Vars(r)["clientID"])
+	if err != nil {
+		http.Error(w, "Invalid client ID", http.StatusBadRequest)
+		log.Printf("Invalid client ID: %v", err)
+		return client, false
+	}
+
+	if err := db.First(&client, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "Client not found", http.StatusNotFound)
+			log.Printf("Client not found with ID %d", id)
+			return client, false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching client: %v", err)
+		return client, false
+	}
+
+	return client, true
+}
@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"time-tracker-go/imports"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+)
+
+// csvFlushRows is the number of rows after which a CSV export is flushed to the client.
+const csvFlushRows = 100
+
+// csvTimeLayout is the layout of times in CSV exports, understood by spreadsheet applications.
+const csvTimeLayout = "2006-01-02 15:04:05"
+
+// csvExport streams CSV rows to the response without buffering the whole export.
+type csvExport struct {
+	writer  *csv.Writer
+	flusher http.Flusher
+	decimal string // Decimal separator of numbers
+	rows    int    // Rows written since the last flush
+}
+
+// newCSVExport writes the CSV response headers and returns an export writing to the response.
+// The export is configured with the optional query parameters:
+//   - delimiter: field delimiter, a single character, "tab" or "semicolon" (default ",");
+//   - decimal: decimal separator of numbers, "." or "," (default ".");
+//   - bom: prefix the output with a UTF-8 byte order mark (default false).
+//
+// It writes an error response and returns false if the parameters are invalid.
+func newCSVExport(w http.ResponseWriter, r *http.Request, filename string) (*csvExport, bool) {
+	export := &csvExport{decimal: "."}
+
+	comma, err := imports.ParseDelimiter(r.URL.Query().Get("delimiter"))
+	if err != nil {
+		http.Error(w, "Invalid delimiter", http.StatusBadRequest)
+		log.Printf("Invalid CSV delimiter: %v", err)
+		return nil, false
+	}
+
+	if decimal := r.URL.Query().Get("decimal"); decimal != "" {
+		if decimal != "." && decimal != "," {
+			http.Error(w, "Invalid decimal separator, expected . or ,", http.StatusBadRequest)
+			log.Printf("Invalid CSV decimal separator: %q", decimal)
+			return nil, false
+		}
+		if decimal == string(comma) {
+			http.Error(w, "Decimal separator must differ from the delimiter", http.StatusBadRequest)
+			return nil, false
+		}
+		export.decimal = decimal
+	}
+
+	bom := false
+	if bomStr := r.URL.Query().Get("bom"); bomStr != "" {
+		if bom, err = strconv.ParseBool(bomStr); err != nil {
+			http.Error(w, "Invalid bom flag", http.StatusBadRequest)
+			log.Printf("Invalid bom flag: %v", err)
+			return nil, false
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	if bom {
+		w.Write([]byte("\xEF\xBB\xBF"))
+	}
+
+	export.writer = csv.NewWriter(w)
+	export.writer.Comma = comma
+	export.flusher, _ = w.(http.Flusher)
+	return export, true
+}
+
+// exportCSV streams a CSV export written by the given function to the response.
+func exportCSV(w http.ResponseWriter, r *http.Request, filename string, write func(*csvExport) error) {
+	export, ok := newCSVExport(w, r, filename)
+	if !ok {
+		return
+	}
+	if err := write(export); err != nil {
+		// The response has already started, so the export can only be cut short.
+		log.Printf("Error exporting %s: %v", filename, err)
+		return
+	}
+	log.Printf("Exported %s", filename)
+}
+
+// write writes a row and periodically flushes the export to the client.
+func (e *csvExport) write(record []string) error {
+	if err := e.writer.Write(record); err != nil {
+		return err
+	}
+	e.rows++
+	if e.rows >= csvFlushRows {
+		return e.flush()
+	}
+	return nil
+}
+
+// close flushes the remaining rows.
+func (e *csvExport) close() error {
+	return e.flush()
+}
+
+// flush sends the buffered rows to the client.
+func (e *csvExport) flush() error {
+	e.rows = 0
+	e.writer.Flush()
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+	return e.writer.Error()
+}
+
+// number formats a number using the decimal separator of the export.
+func (e *csvExport) number(value float64) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', -1, 64), ".", e.decimal, 1)
+}
+
+// csvTime formats a time for a CSV export. Zero times are exported as empty strings.
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(csvTimeLayout)
+}
+
+// csvID formats an optional ID for a CSV export.
+func csvID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+// userCSVHeader is the header of user list exports.
+var userCSVHeader = []string{"id", "passport_number", "surname", "name", "patronymic", "address"}
+
+// userCSVRecord returns the row of a user in user list exports.
+func userCSVRecord(user models.User) []string {
+	return []string{strconv.FormatUint(uint64(user.ID), 10), user.PassportNumber, user.Surname, user.Name, user.Patronymic, user.Address}
+}
+
+// writeTimeEntriesCSV writes time entries with one row per task.
+func writeTimeEntriesCSV(export *csvExport, entries reports.TimeEntries) error {
+	err := export.write([]string{
+		"task_id", "description", "project", "client", "tags", "billable", "status",
+		"start_time", "end_time", "period_duration", "period_amount",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries.Entries {
+		var project, client string
+		if entry.Project != nil {
+			project = entry.Project.Name
+			if entry.Project.Client != nil {
+				client = entry.Project.Client.Name
+			}
+		}
+		tags := make([]string, len(entry.Tags))
+		for i, tag := range entry.Tags {
+			tags[i] = tag.Name
+		}
+
+		err := export.write([]string{
+			strconv.FormatUint(uint64(entry.ID), 10), entry.Description, project, client, strings.Join(tags, ","),
+			strconv.FormatBool(entry.Billable), string(entry.Status), csvTime(entry.StartTime), csvTime(entry.EndTime),
+			strconv.Itoa(entry.PeriodDuration), export.number(entry.PeriodAmount),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return export.close()
+}
+
+// writeTimesheetCSV writes a timesheet with one row per bucket and task. Buckets without time get a single row.
+func writeTimesheetCSV(export *csvExport, timesheet reports.Timesheet) error {
+	err := export.write([]string{
+		"bucket_start", "bucket_end", "task_id", "project_id", "description", "billable", "duration", "hours", "amount",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range timesheet.Buckets {
+		if len(bucket.Tasks) == 0 {
+			if err := export.write([]string{csvTime(bucket.Start), csvTime(bucket.End), "", "", "", "", "0", "0", "0"}); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, task := range bucket.Tasks {
+			taskID := task.TaskID
+			err := export.write([]string{
+				csvTime(bucket.Start), csvTime(bucket.End), csvID(&taskID), csvID(task.ProjectID), task.Description,
+				strconv.FormatBool(task.Billable), strconv.Itoa(task.Duration), export.number(task.Hours), export.number(task.Amount),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return export.close()
+}
+
+// writeTeamReportCSV writes a team report with one row per user.
+func writeTeamReportCSV(export *csvExport, report reports.TeamReport) error {
+	err := export.write([]string{
+		"rank", "user_id", "surname", "name", "patronymic", "duration", "hours",
+		"billable_duration", "billable_hours", "amount", "task_count", "average_task_duration",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, user := range report.Users {
+		userID := user.UserID
+		err := export.write([]string{
+			strconv.Itoa(user.Rank), csvID(&userID), user.Surname, user.Name, user.Patronymic,
+			strconv.Itoa(user.Duration), export.number(user.Hours),
+			strconv.Itoa(user.BillableDuration), export.number(user.BillableHours), export.number(user.Amount),
+			strconv.Itoa(user.TaskCount), strconv.Itoa(user.AverageTaskDuration),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return export.close()
+}
+
+// writeProjectReportCSV writes a project report with one row per project or client.
+func writeProjectReportCSV(export *csvExport, report reports.ProjectReport) error {
+	header := []string{"client_id", "client_name"}
+	if report.GroupBy == reports.GroupByProject {
+		header = append(header, "project_id", "project_name")
+	}
+	header = append(header, "duration", "hours", "billable_duration", "billable_hours", "amount", "task_count", "user_count")
+	if err := export.write(header); err != nil {
+		return err
+	}
+
+	for _, row := range report.Rows {
+		record := []string{csvID(row.ClientID), row.ClientName}
+		if report.GroupBy == reports.GroupByProject {
+			record = append(record, csvID(row.ProjectID), row.ProjectName)
+		}
+		record = append(record,
+			strconv.Itoa(row.Duration), export.number(row.Hours),
+			strconv.Itoa(row.BillableDuration), export.number(row.BillableHours), export.number(row.Amount),
+			strconv.Itoa(row.TaskCount), strconv.Itoa(row.UserCount),
+		)
+		if err := export.write(record); err != nil {
+			return err
+		}
+	}
+	return export.close()
+}
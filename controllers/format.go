@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Response formats that handlers may offer besides JSON.
+const (
+	formatJSON = "json"
+	formatHTML = "html"
+	formatPDF  = "pdf"
+	formatCSV  = "csv"
+	formatXLSX = "xlsx"
+)
+
+// xlsxMediaType is the media type of Excel workbooks.
+const xlsxMediaType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// formatMediaTypes maps media types of the Accept header to response formats.
+var formatMediaTypes = map[string]string{
+	"application/json": formatJSON,
+	"text/html":        formatHTML,
+	"application/pdf":  formatPDF,
+	"text/csv":         formatCSV,
+	xlsxMediaType:      formatXLSX,
+}
+
+// responseFormat returns the response format requested with the "format" query parameter or, failing that,
+// the first known media type of the Accept header. It defaults to JSON.
+func responseFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return strings.ToLower(format)
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		if format, ok := formatMediaTypes[mediaType]; ok {
+			return format
+		}
+	}
+
+	return formatJSON
+}
+
+// parseResponseFormat returns the requested response format like responseFormat.
+// It writes an error response listing the supported formats and returns false if the format is not one of them.
+func parseResponseFormat(w http.ResponseWriter, r *http.Request, supported ...string) (string, bool) {
+	format := responseFormat(r)
+	if !slices.Contains(supported, format) {
+		expected := strings.Join(supported[:len(supported)-1], ", ") + " or " + supported[len(supported)-1]
+		http.Error(w, "Invalid format, expected "+expected, http.StatusBadRequest)
+		log.Printf("Invalid format: %s", format)
+		return "", false
+	}
+	return format, true
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// GraphQLController handles requests to the GraphQL API.
+type GraphQLController struct {
+	Schema *graphql.Schema
+}
+
+// NewGraphQLController creates a new instance of GraphQLController with the given schema.
+func NewGraphQLController(schema *graphql.Schema) *GraphQLController {
+	return &GraphQLController{Schema: schema}
+}
+
+// GraphQLRequest is a GraphQL query or mutation with its variables.
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`         // Query or mutation document
+	OperationName string                 `json:"operationName"` // Operation to execute if the document has several
+	Variables     map[string]interface{} `json:"variables"`     // Values of the variables of the operation
+}
+
+// GraphQLResponse is the result of a GraphQL request.
+type GraphQLResponse struct {
+	Data   interface{}   `json:"data,omitempty"`   // Result of the operation
+	Errors []interface{} `json:"errors,omitempty"` // Errors with their path and extensions.code: BAD_USER_INPUT, NOT_FOUND, CONFLICT, UNAVAILABLE or INTERNAL
+}
+
+// @Summary Execute a GraphQL query or mutation
+// @Description Executes a query or mutation against the GraphQL schema of users, tasks and reports.
+// @Description A user can be fetched with their tasks and period totals in one request; tasks and totals of a list of users
+// @Description are loaded with one database query per field. Errors are returned in the "errors" array with status 200,
+// @Description with a machine-readable extensions.code and, for conflicts, the reason of the REST API.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body GraphQLRequest true "GraphQL request"
+// @Success 200 {object} GraphQLResponse
+// @Failure 400 {string} string "Invalid request payload"
+// @Router /graphql [post]
+func (gc *GraphQLController) Query(w http.ResponseWriter, r *http.Request) {
+	var request GraphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	if request.Query == "" {
+		http.Error(w, "Missing query", http.StatusBadRequest)
+		log.Println("Missing GraphQL query")
+		return
+	}
+
+	response := gc.Schema.Exec(r.Context(), request.Query, request.OperationName, request.Variables)
+	for _, err := range response.Errors {
+		log.Printf("GraphQL error: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
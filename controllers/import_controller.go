@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"time-tracker-go/imports"
+	"time-tracker-go/models"
+
+	"gorm.io/gorm"
+)
+
+// maxImportSize is the maximum size of an uploaded import file in bytes.
+const maxImportSize = 64 << 20
+
+// ImportController handles HTTP requests that import time tracked elsewhere.
+type ImportController struct {
+	DB *gorm.DB
+}
+
+// NewImportController creates a new instance of ImportController with the given DB connection.
+func NewImportController(db *gorm.DB) *ImportController {
+	return &ImportController{DB: db}
+}
+
+// @Summary Import a CSV timesheet
+// @Description Imports historical time from a CSV file with a header row; every row becomes a done task with a single work interval.
+// @Description Columns are matched to the fields passport_number, user, surname, name, patronymic, description, project, tags, billable, date, start, end and duration by name;
+// @Description columns with other names are mapped with map=field=Column. Users are matched by passport number or by name.
+// @Description Rows are validated (user, project and tags exist, valid times in the past, non-negative durations, no overlaps with other rows or tracked time)
+// @Description and the import is all-or-nothing: if any row is invalid, nothing is imported and 422 lists the errors by row.
+// @Description The file is sent as the "file" field of a multipart form or as the raw request body.
+// @Tags imports
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Produce json
+// @Param file formData file false "CSV timesheet"
+// @Param map query []string false "Column mapping field=Column, repeated for each field" collectionFormat(multi)
+// @Param delimiter query string false "Field delimiter: a single character, tab or semicolon" default(,)
+// @Param time_format query string false "Go layout of start and end times (default: common ISO and Russian formats)"
+// @Param time_zone query string false "Time zone of times without an offset, e.g. Europe/Moscow (default: UTC)"
+// @Param duration_unit query string false "Unit of durations without a colon: minutes or hours" default(minutes)
+// @Param dry_run query bool false "Validate the timesheet without importing it"
+// @Success 200 {object} imports.TimesheetResult "Dry run"
+// @Success 201 {object} imports.TimesheetResult
+// @Failure 422 {object} imports.TimesheetResult
+// @Router /imports/timesheets [post]
+func (ic *ImportController) ImportTimesheet(w http.ResponseWriter, r *http.Request) {
+	options := imports.TimesheetOptions{
+		TimeLayout:   r.URL.Query().Get("time_format"),
+		DurationUnit: r.URL.Query().Get("duration_unit"),
+	}
+
+	var err error
+	if options.Comma, err = imports.ParseDelimiter(r.URL.Query().Get("delimiter")); err != nil {
+		http.Error(w, "Invalid delimiter", http.StatusBadRequest)
+		log.Printf("Invalid CSV delimiter: %v", err)
+		return
+	}
+
+	if options.Columns, err = imports.ParseColumnMapping(r.URL.Query()["map"]); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("Invalid column mapping: %v", err)
+		return
+	}
+
+	if timeZone := r.URL.Query().Get("time_zone"); timeZone != "" {
+		if options.Location, err = time.LoadLocation(timeZone); err != nil {
+			http.Error(w, "Invalid time_zone", http.StatusBadRequest)
+			log.Printf("Invalid time zone: %v", err)
+			return
+		}
+	}
+
+	dryRun, ok := parseDryRun(w, r)
+	if !ok {
+		return
+	}
+	options.DryRun = dryRun
+
+	file, ok := uploadedFile(w, r, maxImportSize)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	result, err := imports.ImportTimesheet(ic.DB, file, options, time.Now())
+	if err != nil {
+		switch {
+		case isTooLarge(err):
+			http.Error(w, "Timesheet is too large", http.StatusRequestEntityTooLarge)
+		case errors.Is(err, imports.ErrInvalidTimesheet):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		log.Printf("Error importing timesheet: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case len(result.Errors) > 0:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	case options.DryRun:
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+
+	log.Printf("Imported timesheet: %d rows, %d tasks created, %d errors (dry run: %t)", result.Rows, result.Created, len(result.Errors), options.DryRun)
+}
+
+// @Summary Import a Toggl Track export
+// @Description Imports the detailed report of Toggl Track exported as CSV or JSON (Reports API v2), or time entries of the API v9 requested with meta=true.
+// @Description Entries become done tasks with a single work interval; descriptions, billable flags, projects, clients and tags are carried over.
+// @Description Users are matched by name unless user_id is given; projects by name and client; tags by name.
+// @Description Entries of unknown users are skipped, unknown projects and tags are left out unless create_missing=true; the response lists them under "unmatched".
+// @Description Entries already imported, running entries, duplicates, entries overlapping tracked time and entries in approved weeks are skipped. The import runs in one transaction.
+// @Tags imports
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Accept json
+// @Produce json
+// @Param file formData file false "Toggl Track export"
+// @Param user_id query int false "User to import all entries for"
+// @Param create_missing query bool false "Create missing clients, projects and tags"
+// @Param time_zone query string false "Time zone of CSV exports, e.g. Europe/Moscow (default: UTC)"
+// @Param date_format query string false "Go layout of dates in CSV exports (default: 2006-01-02, 01/02/2006 or 02.01.2006)"
+// @Param delimiter query string false "CSV field delimiter: a single character, tab or semicolon" default(,)
+// @Param dry_run query bool false "Preview the import without changing anything"
+// @Success 200 {object} imports.TrackerResult "Dry run"
+// @Success 201 {object} imports.TrackerResult
+// @Router /imports/toggl [post]
+func (ic *ImportController) ImportToggl(w http.ResponseWriter, r *http.Request) {
+	ic.importTracker(w, r, imports.SourceToggl, imports.ParseToggl)
+}
+
+// @Summary Import a Clockify export
+// @Description Imports the detailed report of Clockify exported as CSV or JSON.
+// @Description Entries become done tasks with a single work interval; descriptions, billable flags, projects, clients and tags are carried over.
+// @Description Users are matched by name unless user_id is given; projects by name and client; tags by name.
+// @Description Entries of unknown users are skipped, unknown projects and tags are left out unless create_missing=true; the response lists them under "unmatched".
+// @Description Entries already imported, running entries, duplicates, entries overlapping tracked time and entries in approved weeks are skipped. The import runs in one transaction.
+// @Tags imports
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Accept json
+// @Produce json
+// @Param file formData file false "Clockify export"
+// @Param user_id query int false "User to import all entries for"
+// @Param create_missing query bool false "Create missing clients, projects and tags"
+// @Param time_zone query string false "Time zone of CSV exports, e.g. Europe/Moscow (default: UTC)"
+// @Param date_format query string false "Go layout of dates in CSV exports (default: 2006-01-02, 01/02/2006 or 02.01.2006)"
+// @Param delimiter query string false "CSV field delimiter: a single character, tab or semicolon" default(,)
+// @Param dry_run query bool false "Preview the import without changing anything"
+// @Success 200 {object} imports.TrackerResult "Dry run"
+// @Success 201 {object} imports.TrackerResult
+// @Router /imports/clockify [post]
+func (ic *ImportController) ImportClockify(w http.ResponseWriter, r *http.Request) {
+	ic.importTracker(w, r, imports.SourceClockify, imports.ParseClockify)
+}
+
+// importTracker imports the uploaded export of another time tracker read with the given parser.
+func (ic *ImportController) importTracker(w http.ResponseWriter, r *http.Request, source string, parse func(io.Reader, imports.TrackerParseOptions) ([]imports.TrackerEntry, error)) {
+	parseOptions := imports.TrackerParseOptions{DateLayout: r.URL.Query().Get("date_format")}
+	options := imports.TrackerOptions{Source: source}
+
+	var err error
+	if parseOptions.Comma, err = imports.ParseDelimiter(r.URL.Query().Get("delimiter")); err != nil {
+		http.Error(w, "Invalid delimiter", http.StatusBadRequest)
+		log.Printf("Invalid CSV delimiter: %v", err)
+		return
+	}
+
+	if timeZone := r.URL.Query().Get("time_zone"); timeZone != "" {
+		if parseOptions.Location, err = time.LoadLocation(timeZone); err != nil {
+			http.Error(w, "Invalid time_zone", http.StatusBadRequest)
+			log.Printf("Invalid time zone: %v", err)
+			return
+		}
+	}
+
+	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
+		userID, err := strconv.ParseUint(userIDStr, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid user_id", http.StatusBadRequest)
+			log.Printf("Invalid user_id: %v", err)
+			return
+		}
+		if !recordExists(w, ic.DB, &models.User{}, "User", uint(userID)) {
+			return
+		}
+		id := uint(userID)
+		options.UserID = &id
+	}
+
+	if createMissing := r.URL.Query().Get("create_missing"); createMissing != "" {
+		if options.CreateMissing, err = strconv.ParseBool(createMissing); err != nil {
+			http.Error(w, "Invalid create_missing flag", http.StatusBadRequest)
+			log.Printf("Invalid create_missing flag: %v", err)
+			return
+		}
+	}
+
+	dryRun, ok := parseDryRun(w, r)
+	if !ok {
+		return
+	}
+	options.DryRun = dryRun
+
+	file, ok := uploadedFile(w, r, maxImportSize)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	entries, err := parse(file, parseOptions)
+	if err != nil {
+		switch {
+		case isTooLarge(err):
+			http.Error(w, "Export is too large", http.StatusRequestEntityTooLarge)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		log.Printf("Invalid %s export: %v", source, err)
+		return
+	}
+
+	result, err := imports.ImportTrackerEntries(ic.DB, entries, options, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error importing %s export: %v", source, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if options.DryRun {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+
+	log.Printf("Imported %s export: %d of %d entries (dry run: %t)", source, result.Created, result.Entries, options.DryRun)
+}
+
+// parseDryRun reads the optional "dry_run" query parameter.
+// It writes an error response and returns false if it is invalid.
+func parseDryRun(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	dryRunStr := r.URL.Query().Get("dry_run")
+	if dryRunStr == "" {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(dryRunStr)
+	if err != nil {
+		http.Error(w, "Invalid dry_run flag", http.StatusBadRequest)
+		log.Printf("Invalid dry_run flag: %v", err)
+		return false, false
+	}
+	return dryRun, true
+}
@@ -0,0 +1,398 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"time-tracker-go/config"
+	"time-tracker-go/invoices"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InvoiceController handles HTTP requests related to invoices.
+type InvoiceController struct {
+	DB     *gorm.DB
+	Config config.Config
+}
+
+// NewInvoiceController creates a new instance of InvoiceController with the given DB connection and configuration.
+func NewInvoiceController(db *gorm.DB, config config.Config) *InvoiceController {
+	return &InvoiceController{DB: db, Config: config}
+}
+
+// InvoiceRequest selects the time billed on a new invoice. At least one of ClientID and UserID is required.
+type InvoiceRequest struct {
+	ClientID  *uint     `json:"clientID"`  // Bill time on projects of this client
+	UserID    *uint     `json:"userID"`    // Bill time of this user
+	StartDate time.Time `json:"startDate"` // Start of the billed period (inclusive)
+	EndDate   time.Time `json:"endDate"`   // End of the billed period (exclusive)
+	VATRate   *float64  `json:"vatRate"`   // VAT rate in percent (the configured rate if omitted)
+}
+
+// @Summary Get invoices
+// @Description Retrieves invoices with optional status, client and user filters and supports pagination
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param status query string false "Invoice status: draft, sent or paid"
+// @Param client_id query int false "Client ID"
+// @Param user_id query int false "User ID"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} models.Invoice
+// @Router /invoices [get]
+func (ic *InvoiceController) GetInvoices(w http.ResponseWriter, r *http.Request) {
+	var invoiceList []models.Invoice
+	query := preloadInvoice(ic.DB)
+
+	// Filtration
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		status := models.InvoiceStatus(statusStr)
+		if !status.IsValid() {
+			http.Error(w, "Invalid status", http.StatusBadRequest)
+			log.Printf("Invalid status: %s", statusStr)
+			return
+		}
+		query = query.Where("status = ?", status)
+	}
+
+	for _, param := range []string{"client_id", "user_id"} {
+		if idStr := r.URL.Query().Get(param); idStr != "" {
+			id, err := strconv.Atoi(idStr)
+			if err != nil {
+				http.Error(w, "Invalid "+param, http.StatusBadRequest)
+				log.Printf("Invalid %s: %v", param, err)
+				return
+			}
+			query = query.Where(param+" = ?", id)
+		}
+	}
+
+	// Pagination
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := query.Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&invoiceList).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching invoices: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invoiceList)
+
+	log.Printf("Fetched %d invoices", len(invoiceList))
+}
+
+// @Summary Get an invoice by ID
+// @Description Retrieves an invoice as JSON, or as a document rendered to HTML or PDF. The format is selected with the format parameter or the Accept header.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Produce html
+// @Produce application/pdf
+// @Param invoiceID path int true "Invoice ID"
+// @Param format query string false "Response format: json, html or pdf"
+// @Success 200 {object} models.Invoice
+// @Router /invoices/{invoiceID} [get]
+func (ic *InvoiceController) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := ic.findInvoice(w, r)
+	if !ok {
+		return
+	}
+
+	format := responseFormat(r)
+	var document bytes.Buffer
+	var err error
+	switch format {
+	case formatJSON:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(invoice)
+		log.Printf("Fetched invoice with ID %d", invoice.ID)
+		return
+	case formatHTML:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err = invoices.RenderHTML(&document, invoice)
+	case formatPDF:
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"invoice-%s.pdf\"", invoiceName(invoice)))
+		err = invoices.RenderPDF(&document, invoice)
+	default:
+		http.Error(w, "Unsupported format", http.StatusNotAcceptable)
+		log.Printf("Unsupported invoice format: %s", format)
+		return
+	}
+
+	if err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error rendering invoice: %v", err)
+		return
+	}
+	document.WriteTo(w)
+
+	log.Printf("Rendered invoice with ID %d as %s", invoice.ID, format)
+}
+
+// @Summary Generate an invoice
+// @Description Creates a draft invoice from the uninvoiced billable time of a client and/or user. Closed intervals starting within [startDate, endDate) are billed at the rate in effect at their start, aggregated into one line per task and rate, and marked as invoiced.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param invoice body InvoiceRequest true "Client, user and period to bill"
+// @Success 201 {object} models.Invoice
+// @Failure 409 {object} ConflictResponse
+// @Router /invoices [post]
+func (ic *InvoiceController) AddInvoice(w http.ResponseWriter, r *http.Request) {
+	var request InvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	if request.ClientID == nil && request.UserID == nil {
+		http.Error(w, "Client ID or user ID is required", http.StatusBadRequest)
+		return
+	}
+	if !request.StartDate.Before(request.EndDate) {
+		http.Error(w, "Start date must be before end date", http.StatusBadRequest)
+		return
+	}
+	if request.ClientID != nil && !recordExists(w, ic.DB, &models.Client{}, "Client", *request.ClientID) {
+		return
+	}
+	if request.UserID != nil && !recordExists(w, ic.DB, &models.User{}, "User", *request.UserID) {
+		return
+	}
+
+	params := invoices.Params{
+		ClientID: request.ClientID,
+		UserID:   request.UserID,
+		Period:   reports.Period{From: request.StartDate, To: request.EndDate},
+		Currency: ic.Config.Currency,
+		VATRate:  ic.Config.VATRate,
+	}
+	if request.VATRate != nil {
+		if *request.VATRate < 0 {
+			http.Error(w, "VAT rate must not be negative", http.StatusBadRequest)
+			return
+		}
+		params.VATRate = *request.VATRate
+	}
+
+	var invoice models.Invoice
+	err := ic.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		invoice, err = invoices.Generate(tx, params)
+		return err
+	})
+	switch {
+	case errors.Is(err, invoices.ErrNothingToInvoice):
+		http.Error(w, "No uninvoiced billable time in the period", http.StatusBadRequest)
+		log.Printf("Nothing to invoice")
+		return
+	case errors.Is(err, invoices.ErrAlreadyInvoiced):
+		writeConflict(w, ConflictResponse{Error: err.Error(), Reason: ReasonAlreadyInvoiced})
+		log.Printf("Error creating invoice: %v", err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error creating invoice: %v", err)
+		return
+	}
+
+	if err := preloadInvoice(ic.DB).First(&invoice, invoice.ID).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching invoice: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(invoice)
+
+	log.Printf("Created invoice with ID %d and %d lines", invoice.ID, len(invoice.Lines))
+}
+
+// @Summary Send an invoice
+// @Description Marks a draft invoice as sent and assigns it the next number of the year
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param invoiceID path int true "Invoice ID"
+// @Success 200 {object} models.Invoice
+// @Failure 409 {object} ConflictResponse
+// @Router /invoices/{invoiceID}/send [put]
+func (ic *InvoiceController) SendInvoice(w http.ResponseWriter, r *http.Request) {
+	ic.applyInvoiceAction(w, r, models.InvoiceActionSend, func(tx *gorm.DB, invoice *models.Invoice) error {
+		return invoices.Send(tx, invoice, time.Now())
+	})
+}
+
+// @Summary Mark an invoice as paid
+// @Description Marks a sent invoice as paid
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param invoiceID path int true "Invoice ID"
+// @Success 200 {object} models.Invoice
+// @Failure 409 {object} ConflictResponse
+// @Router /invoices/{invoiceID}/pay [put]
+func (ic *InvoiceController) PayInvoice(w http.ResponseWriter, r *http.Request) {
+	ic.applyInvoiceAction(w, r, models.InvoiceActionPay, func(tx *gorm.DB, invoice *models.Invoice) error {
+		return invoices.Pay(tx, invoice, time.Now())
+	})
+}
+
+// @Summary Delete a draft invoice
+// @Description Deletes a draft invoice and releases its time so it can be invoiced again. Sent and paid invoices cannot be deleted.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param invoiceID path int true "Invoice ID"
+// @Success 200 {object} map[string]string
+// @Failure 409 {object} ConflictResponse
+// @Router /invoices/{invoiceID} [delete]
+func (ic *InvoiceController) DeleteInvoice(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["invoiceID"])
+	if err != nil {
+		http.Error(w, "Invalid invoice ID", http.StatusBadRequest)
+		log.Printf("Invalid invoice ID: %v", err)
+		return
+	}
+
+	err = ic.DB.Transaction(func(tx *gorm.DB) error {
+		invoice, err := lockInvoice(tx, id)
+		if err != nil {
+			return err
+		}
+		return invoices.Discard(tx, &invoice)
+	})
+	if err != nil {
+		writeInvoiceActionError(w, err)
+		log.Printf("Error deleting invoice: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Invoice deleted successfully"})
+
+	log.Printf("Deleted invoice with ID %d", id)
+}
+
+// findInvoice loads the invoice identified by the "invoiceID" route parameter with its client, user and lines.
+// It writes an error response and returns false if the invoice cannot be loaded.
+func (ic *InvoiceController) findInvoice(w http.ResponseWriter, r *http.Request) (models.Invoice, bool) {
+	var invoice models.Invoice
+
+	id, err := strconv.Atoi(mux.Vars(r)["invoiceID"])
+	if err != nil {
+		http.Error(w, "Invalid invoice ID", http.StatusBadRequest)
+		log.Printf("Invalid invoice ID: %v", err)
+		return invoice, false
+	}
+
+	if err := preloadInvoice(ic.DB).First(&invoice, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "Invoice not found", http.StatusNotFound)
+			log.Printf("Invoice not found with ID %d", id)
+			return invoice, false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching invoice: %v", err)
+		return invoice, false
+	}
+
+	return invoice, true
+}
+
+// applyInvoiceAction locks the invoice identified by the route parameters, applies the action to it
+// and responds with the updated invoice. Actions not allowed in the current state are answered with 409 Conflict.
+func (ic *InvoiceController) applyInvoiceAction(w http.ResponseWriter, r *http.Request, action models.InvoiceAction, apply func(*gorm.DB, *models.Invoice) error) {
+	id, err := strconv.Atoi(mux.Vars(r)["invoiceID"])
+	if err != nil {
+		http.Error(w, "Invalid invoice ID", http.StatusBadRequest)
+		log.Printf("Invalid invoice ID: %v", err)
+		return
+	}
+
+	err = ic.DB.Transaction(func(tx *gorm.DB) error {
+		invoice, err := lockInvoice(tx, id)
+		if err != nil {
+			return err
+		}
+		return apply(tx, &invoice)
+	})
+	if err != nil {
+		writeInvoiceActionError(w, err)
+		log.Printf("Error applying %s to invoice %d: %v", action, id, err)
+		return
+	}
+
+	var invoice models.Invoice
+	if err := preloadInvoice(ic.DB).First(&invoice, id).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching invoice: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(invoice)
+
+	log.Printf("Applied %s to invoice with ID %d", action, id)
+}
+
+// lockInvoice loads the invoice and locks its row until the end of the transaction.
+func lockInvoice(tx *gorm.DB, id int) (models.Invoice, error) {
+	var invoice models.Invoice
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return invoice, errInvoiceNotFound
+	}
+	return invoice, err
+}
+
+// preloadInvoice returns a query that loads invoices with their client, user and lines.
+func preloadInvoice(db *gorm.DB) *gorm.DB {
+	return db.Preload("Client").Preload("User").Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+}
+
+// invoiceName identifies an invoice in file names: its number, or its ID while it is a draft.
+func invoiceName(invoice models.Invoice) string {
+	if invoice.Number != nil {
+		return *invoice.Number
+	}
+	return fmt.Sprintf("draft-%d", invoice.ID)
+}
+
+// writeInvoiceActionError maps an error returned while changing an invoice state to an HTTP response.
+func writeInvoiceActionError(w http.ResponseWriter, err error) {
+	var transitionErr *models.InvoiceTransitionError
+	switch {
+	case errors.Is(err, errInvoiceNotFound):
+		http.Error(w, "Invoice not found", http.StatusNotFound)
+	case errors.As(err, &transitionErr):
+		writeConflict(w, ConflictResponse{Error: err.Error(), Reason: transitionErr.Reason})
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
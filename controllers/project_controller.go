@@ -0,0 +1,263 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time-tracker-go/models"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProjectController handles HTTP requests related to projects.
+type ProjectController struct {
+	DB *gorm.DB
+}
+
+// NewProjectController creates a new instance of ProjectController with the given DB connection.
+func NewProjectController(db *gorm.DB) *ProjectController {
+	return &ProjectController{DB: db}
+}
+
+// ProjectRequest describes the editable fields of a project.
+type ProjectRequest struct {
+	ClientID    *uint    `json:"clientID"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	BudgetHours *float64 `json:"budgetHours"` // Budget in hours (omit for no budget)
+}
+
+// @Summary Get projects
+// @Description Retrieves projects with optional client and name filters and supports pagination
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param client_id query int false "Client ID"
+// @Param name query string false "Name"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} models.Project
+// @Router /projects [get]
+func (pc *ProjectController) GetProjects(w http.ResponseWriter, r *http.Request) {
+	var projects []models.Project
+	query := pc.DB.Preload("Client")
+
+	// Filtration
+	if clientIDStr := r.URL.Query().Get("client_id"); clientIDStr != "" {
+		clientID, err := strconv.Atoi(clientIDStr)
+		if err != nil {
+			http.Error(w, "Invalid client ID", http.StatusBadRequest)
+			log.Printf("Invalid client ID: %v", err)
+			return
+		}
+		query = query.Where("client_id = ?", clientID)
+	}
+
+	if name := r.URL.Query().Get("name"); name != "" {
+		query = query.Where("name = ?", name)
+	}
+
+	// Pagination
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := query.Order("name").Limit(pageSize).Offset(offset).Find(&projects).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching projects: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(projects)
+
+	log.Printf("Fetched %d projects", len(projects))
+}
+
+// @Summary Get a project by ID
+// @Description Retrieves a project together with its client
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param projectID path int true "Project ID"
+// @Success 200 {object} models.Project
+// @Router /projects/{projectID} [get]
+func (pc *ProjectController) GetProject(w http.ResponseWriter, r *http.Request) {
+	project, ok := pc.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(project)
+
+	log.Printf("Fetched project with ID %d", project.ID)
+}
+
+// @Summary Add a new project
+// @Description Adds a new project, optionally for a client
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param project body ProjectRequest true "Project to be added"
+// @Success 201 {object} models.Project
+// @Router /projects [post]
+func (pc *ProjectController) AddProject(w http.ResponseWriter, r *http.Request) {
+	var request ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	project := models.Project{}
+	if !pc.applyRequest(w, &project, request) {
+		return
+	}
+
+	if err := pc.DB.Omit(clause.Associations).Create(&project).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error creating project: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(project)
+
+	log.Printf("Created project with ID %d", project.ID)
+}
+
+// @Summary Update a project by ID
+// @Description Updates a project's details by its ID
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param projectID path int true "Project ID"
+// @Param project body ProjectRequest true "Updated project"
+// @Success 200 {object} models.Project
+// @Router /projects/{projectID} [put]
+func (pc *ProjectController) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	project, ok := pc.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	var request ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	if !pc.applyRequest(w, &project, request) {
+		return
+	}
+
+	if err := pc.DB.Omit(clause.Associations).Save(&project).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error updating project: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(project)
+
+	log.Printf("Updated project with ID %d", project.ID)
+}
+
+// @Summary Delete a project by ID
+// @Description Deletes a project by its ID. Tasks of the project keep their reference for reporting.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param projectID path int true "Project ID"
+// @Success 200 {object} map[string]string
+// @Router /projects/{projectID} [delete]
+func (pc *ProjectController) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	project, ok := pc.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	if err := pc.DB.Delete(&project).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error deleting project: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project deleted successfully"})
+
+	log.Printf("Deleted project with ID %d", project.ID)
+}
+
+// applyRequest validates the request and copies it into the project.
+// It writes an error response and returns false if the request is invalid.
+func (pc *ProjectController) applyRequest(w http.ResponseWriter, project *models.Project, request ProjectRequest) bool {
+	if request.Name == "" {
+		http.Error(w, "Project name is required", http.StatusBadRequest)
+		return false
+	}
+
+	if request.BudgetHours != nil && *request.BudgetHours <= 0 {
+		http.Error(w, "Budget must be positive", http.StatusBadRequest)
+		return false
+	}
+
+	project.Client = nil
+	if request.ClientID != nil {
+		var client models.Client
+		if err := pc.DB.First(&client, *request.ClientID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				http.Error(w, "Client not found", http.StatusBadRequest)
+				log.Printf("Client not found with ID %d", *request.ClientID)
+				return false
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			log.Printf("Error fetching client: %v", err)
+			return false
+		}
+		project.Client = &client
+	}
+
+	project.ClientID = request.ClientID
+	project.Name = request.Name
+	project.Description = request.Description
+	project.BudgetHours = request.BudgetHours
+	return true
+}
+
+// findProject loads the project identified by the "projectID" route parameter together with its client.
+// It writes an error response and returns false if the project cannot be loaded.
+func (pc *ProjectController) findProject(w http.ResponseWriter, r *http.Request) (models.Project, bool) {
+	var project models.Project
+
+	id, err := strconv.Atoi(mux.Vars(r)["projectID"])
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		log.Printf("Invalid project ID: %v", err)
+		return project, false
+	}
+
+	if err := pc.DB.Preload("Client").First(&project, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "Project not found", http.StatusNotFound)
+			log.Printf("Project not found with ID %d", id)
+			return project, false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching project: %v", err)
+		return project, false
+	}
+
+	return project, true
+}
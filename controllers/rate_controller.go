@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"time-tracker-go/models"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// RateController handles HTTP requests related to hourly rates.
+type RateController struct {
+	DB *gorm.DB
+}
+
+// NewRateController creates a new instance of RateController with the given DB connection.
+func NewRateController(db *gorm.DB) *RateController {
+	return &RateController{DB: db}
+}
+
+// RateRequest describes a new hourly rate.
+type RateRequest struct {
+	UserID        *uint     `json:"userID"`        // nil for all users
+	ProjectID     *uint     `json:"projectID"`     // nil for all projects
+	HourlyRate    float64   `json:"hourlyRate"`    // Amount charged per hour
+	EffectiveFrom time.Time `json:"effectiveFrom"` // Time from which the rate applies
+}
+
+// @Summary Get hourly rates
+// @Description Retrieves the rate history ordered by effective date, optionally for a user or a project
+// @Tags rates
+// @Accept json
+// @Produce json
+// @Param user_id query int false "User ID"
+// @Param project_id query int false "Project ID"
+// @Success 200 {array} models.Rate
+// @Router /rates [get]
+func (rc *RateController) GetRates(w http.ResponseWriter, r *http.Request) {
+	var rates []models.Rate
+	query := rc.DB
+
+	// Filtration
+	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
+		userID, err := strconv.Atoi(userIDStr)
+		if err != nil {
+			http.Error(w, "Invalid user ID", http.StatusBadRequest)
+			log.Printf("Invalid user ID: %v", err)
+			return
+		}
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if projectIDStr := r.URL.Query().Get("project_id"); projectIDStr != "" {
+		projectID, err := strconv.Atoi(projectIDStr)
+		if err != nil {
+			http.Error(w, "Invalid project ID", http.StatusBadRequest)
+			log.Printf("Invalid project ID: %v", err)
+			return
+		}
+		query = query.Where("project_id = ?", projectID)
+	}
+
+	if err := query.Order("effective_from, id").Find(&rates).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching rates: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rates)
+
+	log.Printf("Fetched %d rates", len(rates))
+}
+
+// @Summary Add an hourly rate
+// @Description Adds a rate effective from the given time. Earlier rates stay in the history and keep applying to time tracked before it.
+// @Tags rates
+// @Accept json
+// @Produce json
+// @Param rate body RateRequest true "Rate to be added"
+// @Success 201 {object} models.Rate
+// @Router /rates [post]
+func (rc *RateController) AddRate(w http.ResponseWriter, r *http.Request) {
+	var request RateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	if request.HourlyRate < 0 {
+		http.Error(w, "Hourly rate must not be negative", http.StatusBadRequest)
+		return
+	}
+	if request.EffectiveFrom.IsZero() {
+		http.Error(w, "Effective date is required", http.StatusBadRequest)
+		return
+	}
+	if request.UserID != nil && !recordExists(w, rc.DB, &models.User{}, "User", *request.UserID) {
+		return
+	}
+	if request.ProjectID != nil && !recordExists(w, rc.DB, &models.Project{}, "Project", *request.ProjectID) {
+		return
+	}
+
+	rate := models.Rate{
+		UserID:        request.UserID,
+		ProjectID:     request.ProjectID,
+		HourlyRate:    request.HourlyRate,
+		EffectiveFrom: request.EffectiveFrom,
+	}
+	if err := rc.DB.Create(&rate).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error creating rate: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rate)
+
+	log.Printf("Created rate with ID %d", rate.ID)
+}
+
+// @Summary Delete an hourly rate by ID
+// @Description Deletes a rate from the history. Time it applied to falls back to the previous rate with the same scope.
+// @Tags rates
+// @Accept json
+// @Produce json
+// @Param rateID path int true "Rate ID"
+// @Success 200 {object} map[string]string
+// @Router /rates/{rateID} [delete]
+func (rc *RateController) DeleteRate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["rateID"])
+	if err != nil {
+		http.Error(w, "Invalid rate ID", http.StatusBadRequest)
+		log.Printf("Invalid rate ID: %v", err)
+		return
+	}
+
+	result := rc.DB.Delete(&models.Rate{}, id)
+	if result.Error != nil {
+		http.Error(w, result.Error.Error(), http.StatusInternalServerError)
+		log.Printf("Error deleting rate: %v", result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		http.Error(w, "Rate not found", http.StatusNotFound)
+		log.Printf("Rate not found with ID %d", id)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Rate deleted successfully"})
+
+	log.Printf("Deleted rate with ID %d", id)
+}
@@ -0,0 +1,437 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"time-tracker-go/config"
+	"time-tracker-go/exports"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// ReportController handles HTTP requests related to aggregated time reports.
+type ReportController struct {
+	DB     *gorm.DB
+	Config config.Config
+}
+
+// NewReportController creates a new instance of ReportController with the given DB connection and configuration.
+func NewReportController(db *gorm.DB, config config.Config) *ReportController {
+	return &ReportController{DB: db, Config: config}
+}
+
+// @Summary Get a timesheet of a user
+// @Description Aggregates the time a user spent on tasks within a period by day, ISO week or month,
+// @Description with per-bucket and per-task totals. Running tasks are counted up to now.
+// @Description With format=xlsx the timesheet is exported as an Excel workbook with a row per bucket.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "User ID"
+// @Param start_date query string true "Start date, inclusive (format: 2006-01-02T15:04:05)"
+// @Param end_date query string true "End date, exclusive (format: 2006-01-02T15:04:05)"
+// @Param group_by query string false "Bucket size: day, week or month" default(day)
+// @Param project_id query string false "Comma-separated list of project IDs"
+// @Param client_id query string false "Comma-separated list of client IDs"
+// @Param tags_any query string false "Comma-separated tag names, any of which a task must have"
+// @Param tags_all query string false "Comma-separated tag names, all of which a task must have"
+// @Param tags_none query string false "Comma-separated tag names, none of which a task may have"
+// @Param billable query bool false "Only billable (true) or non-billable (false) tasks"
+// @Param format query string false "Response format: json, csv or xlsx (also selected with the Accept header)"
+// @Param delimiter query string false "CSV field delimiter: a single character, tab or semicolon" default(,)
+// @Param decimal query string false "CSV decimal separator: . or ," default(.)
+// @Param bom query bool false "Prefix CSV output with a UTF-8 byte order mark"
+// @Success 200 {object} reports.Timesheet
+// @Router /users/{id}/reports/timesheet [get]
+func (rc *ReportController) GetUserTimesheet(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userID, err := strconv.Atoi(params["id"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		log.Printf("Invalid user ID: %v", err)
+		return
+	}
+
+	period, ok := parsePeriod(w, r)
+	if !ok {
+		return
+	}
+
+	groupBy, ok := parseTimesheetGroupBy(w, r)
+	if !ok {
+		return
+	}
+
+	filter, ok := parseTaskFilter(w, r)
+	if !ok {
+		return
+	}
+
+	format, ok := parseResponseFormat(w, r, formatJSON, formatCSV, formatXLSX)
+	if !ok {
+		return
+	}
+
+	timesheet, err := reports.UserTimesheet(rc.DB, uint(userID), period, groupBy, filter, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error building timesheet: %v", err)
+		return
+	}
+
+	switch format {
+	case formatCSV:
+		exportCSV(w, r, fmt.Sprintf("timesheet-%d.csv", userID), func(export *csvExport) error {
+			return writeTimesheetCSV(export, timesheet)
+		})
+		return
+	case formatXLSX:
+		var user models.User
+		if err := rc.DB.First(&user, userID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				http.Error(w, "User not found", http.StatusNotFound)
+				log.Printf("User not found with ID %d", userID)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			log.Printf("Error fetching user: %v", err)
+			return
+		}
+		exportWorkbook(w, fmt.Sprintf("timesheet-%d.xlsx", userID), period, []exports.UserTimesheet{{User: user, Timesheet: timesheet}})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timesheet)
+
+	log.Printf("Built %s timesheet for user %d with %d buckets", groupBy, userID, len(timesheet.Buckets))
+}
+
+// @Summary Get a team report
+// @Description Ranks users by the time they spent on tasks within a period, with task counts and average task length.
+// @Description Users are selected with the same filters as the user listing.
+// @Description With format=xlsx the report is exported as an Excel workbook with a summary sheet and a timesheet sheet per user.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param start_date query string true "Start date, inclusive (format: 2006-01-02T15:04:05)"
+// @Param end_date query string true "End date, exclusive (format: 2006-01-02T15:04:05)"
+// @Param group_by query string false "Bucket size of the xlsx timesheet sheets: day, week or month" default(day)
+// @Param ids query string false "Comma-separated list of user IDs"
+// @Param passportNumber query string false "Passport number"
+// @Param surname query string false "Surname"
+// @Param name query string false "Name"
+// @Param patronymic query string false "Patronymic"
+// @Param address query string false "Address"
+// @Param city query string false "City (substring of the address)"
+// @Param project_id query string false "Comma-separated list of project IDs"
+// @Param client_id query string false "Comma-separated list of client IDs"
+// @Param tags_any query string false "Comma-separated tag names, any of which a task must have"
+// @Param tags_all query string false "Comma-separated tag names, all of which a task must have"
+// @Param tags_none query string false "Comma-separated tag names, none of which a task may have"
+// @Param billable query bool false "Only billable (true) or non-billable (false) tasks"
+// @Param format query string false "Response format: json, csv or xlsx (also selected with the Accept header)"
+// @Param delimiter query string false "CSV field delimiter: a single character, tab or semicolon" default(,)
+// @Param decimal query string false "CSV decimal separator: . or ," default(.)
+// @Param bom query bool false "Prefix CSV output with a UTF-8 byte order mark"
+// @Success 200 {object} reports.TeamReport
+// @Router /reports/team [get]
+func (rc *ReportController) GetTeamReport(w http.ResponseWriter, r *http.Request) {
+	period, ok := parsePeriod(w, r)
+	if !ok {
+		return
+	}
+
+	users, err := userFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("Invalid user filters: %v", err)
+		return
+	}
+
+	filter, ok := parseTaskFilter(w, r)
+	if !ok {
+		return
+	}
+
+	format, ok := parseResponseFormat(w, r, formatJSON, formatCSV, formatXLSX)
+	if !ok {
+		return
+	}
+
+	report, err := reports.Team(rc.DB, period, filter, time.Now(), users.Scope)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error building team report: %v", err)
+		return
+	}
+
+	switch format {
+	case formatCSV:
+		exportCSV(w, r, "team-report.csv", func(export *csvExport) error {
+			return writeTeamReportCSV(export, report)
+		})
+		return
+	case formatXLSX:
+		rc.exportTeamWorkbook(w, r, period, filter, report)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+
+	log.Printf("Built team report for %d users", len(report.Users))
+}
+
+// @Summary Get a project report
+// @Description Sums the time spent on tasks within a period by project or by client, with task and user counts.
+// @Description Users are selected with the same filters as the user listing.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param start_date query string true "Start date, inclusive (format: 2006-01-02T15:04:05)"
+// @Param end_date query string true "End date, exclusive (format: 2006-01-02T15:04:05)"
+// @Param group_by query string false "Grouping: project or client" default(project)
+// @Param project_id query string false "Comma-separated list of project IDs"
+// @Param client_id query string false "Comma-separated list of client IDs"
+// @Param tags_any query string false "Comma-separated tag names, any of which a task must have"
+// @Param tags_all query string false "Comma-separated tag names, all of which a task must have"
+// @Param tags_none query string false "Comma-separated tag names, none of which a task may have"
+// @Param billable query bool false "Only billable (true) or non-billable (false) tasks"
+// @Param ids query string false "Comma-separated list of user IDs"
+// @Param passportNumber query string false "Passport number"
+// @Param surname query string false "Surname"
+// @Param name query string false "Name"
+// @Param patronymic query string false "Patronymic"
+// @Param address query string false "Address"
+// @Param city query string false "City (substring of the address)"
+// @Param format query string false "Response format: json or csv (also selected with Accept: text/csv)"
+// @Param delimiter query string false "CSV field delimiter: a single character, tab or semicolon" default(,)
+// @Param decimal query string false "CSV decimal separator: . or ," default(.)
+// @Param bom query bool false "Prefix CSV output with a UTF-8 byte order mark"
+// @Success 200 {object} reports.ProjectReport
+// @Router /reports/projects [get]
+func (rc *ReportController) GetProjectReport(w http.ResponseWriter, r *http.Request) {
+	period, ok := parsePeriod(w, r)
+	if !ok {
+		return
+	}
+
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy == "" {
+		groupBy = reports.GroupByProject
+	}
+	if groupBy != reports.GroupByProject && groupBy != reports.GroupByClient {
+		http.Error(w, "Invalid group_by, expected project or client", http.StatusBadRequest)
+		log.Printf("Invalid group_by: %s", groupBy)
+		return
+	}
+
+	filter, ok := parseTaskFilter(w, r)
+	if !ok {
+		return
+	}
+
+	users, err := userFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("Invalid user filters: %v", err)
+		return
+	}
+
+	format, ok := parseResponseFormat(w, r, formatJSON, formatCSV)
+	if !ok {
+		return
+	}
+
+	report, err := reports.Projects(rc.DB, period, groupBy, filter, time.Now(), users.Scope)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error building project report: %v", err)
+		return
+	}
+
+	if format == formatCSV {
+		exportCSV(w, r, "project-report.csv", func(export *csvExport) error {
+			return writeProjectReportCSV(export, report)
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+
+	log.Printf("Built project report with %d rows", len(report.Rows))
+}
+
+// @Summary Get the budget overrun report
+// @Description Lists tasks that have exceeded their estimates and projects that have exceeded their hour budgets,
+// @Description or have spent at least the threshold share of them (level at_risk). Running tasks are counted up to now.
+// @Description Done tasks are listed only once they exceed their estimates; cancelled tasks are not listed.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param threshold query number false "Share of the estimate or budget in percent from which tasks and projects are at risk (default: OVERRUN_THRESHOLD, 80)"
+// @Param user_id query int false "Only tasks of this user"
+// @Param client_id query int false "Only projects of this client and their tasks"
+// @Success 200 {object} reports.OverrunReport
+// @Router /reports/overruns [get]
+func (rc *ReportController) GetOverrunReport(w http.ResponseWriter, r *http.Request) {
+	threshold := rc.Config.OverrunThreshold
+	if thresholdStr := r.URL.Query().Get("threshold"); thresholdStr != "" {
+		var err error
+		threshold, err = strconv.ParseFloat(thresholdStr, 64)
+		if err != nil || threshold <= 0 || threshold > 100 {
+			http.Error(w, "Invalid threshold, expected a percentage between 0 and 100", http.StatusBadRequest)
+			log.Printf("Invalid threshold: %s", thresholdStr)
+			return
+		}
+	}
+
+	var filter reports.OverrunFilter
+	for param, target := range map[string]**uint{"user_id": &filter.UserID, "client_id": &filter.ClientID} {
+		if idStr := r.URL.Query().Get(param); idStr != "" {
+			id, err := strconv.ParseUint(idStr, 10, 64)
+			if err != nil {
+				http.Error(w, "Invalid "+param, http.StatusBadRequest)
+				log.Printf("Invalid %s: %v", param, err)
+				return
+			}
+			value := uint(id)
+			*target = &value
+		}
+	}
+
+	report, err := reports.Overruns(rc.DB, threshold, filter, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error building overrun report: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+
+	log.Printf("Built overrun report with %d tasks and %d projects", len(report.Tasks), len(report.Projects))
+}
+
+// exportTeamWorkbook exports the timesheets of the users of the team report as an XLSX workbook.
+// The timesheets are built with the same period and task filters as the report.
+func (rc *ReportController) exportTeamWorkbook(w http.ResponseWriter, r *http.Request, period reports.Period, filter reports.TaskFilter, report reports.TeamReport) {
+	groupBy, ok := parseTimesheetGroupBy(w, r)
+	if !ok {
+		return
+	}
+
+	userIDs := make([]uint, len(report.Users))
+	for i, member := range report.Users {
+		userIDs[i] = member.UserID
+	}
+	userTimesheets, err := reports.UserTimesheets(rc.DB, userIDs, period, groupBy, filter, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error building timesheets: %v", err)
+		return
+	}
+
+	timesheets := make([]exports.UserTimesheet, len(report.Users))
+	for i, member := range report.Users {
+		user := models.User{Surname: member.Surname, Name: member.Name, Patronymic: member.Patronymic}
+		user.ID = member.UserID
+		timesheets[i] = exports.UserTimesheet{User: user, Timesheet: userTimesheets[i]}
+	}
+
+	exportWorkbook(w, "team-timesheet.xlsx", period, timesheets)
+}
+
+// parseTimesheetGroupBy reads the optional "group_by" query parameter of timesheets, which defaults to days.
+// It writes an error response and returns false if it is invalid.
+func parseTimesheetGroupBy(w http.ResponseWriter, r *http.Request) (string, bool) {
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy == "" {
+		groupBy = reports.GroupByDay
+	}
+	if !reports.IsValidGroupBy(groupBy) {
+		http.Error(w, "Invalid group_by, expected day, week or month", http.StatusBadRequest)
+		log.Printf("Invalid group_by: %s", groupBy)
+		return "", false
+	}
+	return groupBy, true
+}
+
+// parseTaskFilter reads the optional "project_id", "client_id", "tags_any", "tags_all", "tags_none"
+// and "billable" query parameters.
+// It writes an error response and returns false if they are invalid.
+func parseTaskFilter(w http.ResponseWriter, r *http.Request) (reports.TaskFilter, bool) {
+	var filter reports.TaskFilter
+	var err error
+
+	if filter.ProjectIDs, err = parseIDList(r.URL.Query().Get("project_id")); err != nil {
+		http.Error(w, "Invalid project_id", http.StatusBadRequest)
+		log.Printf("Invalid project_id: %v", err)
+		return filter, false
+	}
+
+	if filter.ClientIDs, err = parseIDList(r.URL.Query().Get("client_id")); err != nil {
+		http.Error(w, "Invalid client_id", http.StatusBadRequest)
+		log.Printf("Invalid client_id: %v", err)
+		return filter, false
+	}
+
+	filter.TagsAny = parseTagList(r.URL.Query().Get("tags_any"))
+	filter.TagsAll = parseTagList(r.URL.Query().Get("tags_all"))
+	filter.TagsNone = parseTagList(r.URL.Query().Get("tags_none"))
+
+	if billableStr := r.URL.Query().Get("billable"); billableStr != "" {
+		billable, err := strconv.ParseBool(billableStr)
+		if err != nil {
+			http.Error(w, "Invalid billable flag", http.StatusBadRequest)
+			log.Printf("Invalid billable flag: %v", err)
+			return filter, false
+		}
+		filter.Billable = &billable
+	}
+
+	return filter, true
+}
+
+// parseTagList parses a comma-separated list of tag names. An empty string yields an empty list.
+func parseTagList(value string) []string {
+	var names []string
+	for _, part := range strings.Split(value, ",") {
+		if name := normalizeTagName(part); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseIDList parses a comma-separated list of IDs. An empty string yields an empty list.
+func parseIDList(value string) ([]uint, error) {
+	var ids []uint
+	if value == "" {
+		return ids, nil
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
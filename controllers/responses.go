@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/timesheets"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Machine-readable reasons for conflicts that are not related to the task lifecycle.
+const (
+	ReasonClientHasProjects = "client_has_projects"
+	ReasonTagExists         = "tag_exists"
+	ReasonAlreadyInvoiced   = "already_invoiced"
+)
+
+var errInvoiceNotFound = errors.New("invoice not found")
+
+// ConflictResponse is returned with 409 Conflict when a request is not allowed in the current state.
+type ConflictResponse struct {
+	Error         string            `json:"error"`                   // Human-readable description
+	Reason        string            `json:"reason"`                  // Machine-readable reason
+	Status        models.TaskStatus `json:"status,omitempty"`        // Current task status, if applicable
+	Action        models.TaskAction `json:"action,omitempty"`        // Rejected action, if applicable
+	RunningTaskID uint              `json:"runningTaskID,omitempty"` // Task that is already running, if applicable
+}
+
+// writeConflict writes a 409 Conflict response with a JSON body.
+func writeConflict(w http.ResponseWriter, response ConflictResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(response)
+}
+
+// LockedResponse is returned with 423 Locked when a request would change time in an approved timesheet.
+type LockedResponse struct {
+	Error     string    `json:"error"`     // Human-readable description
+	Reason    string    `json:"reason"`    // Machine-readable reason
+	WeekStart time.Time `json:"weekStart"` // Monday the locked week starts on
+}
+
+// writeLocked writes a 423 Locked response with a JSON body.
+func writeLocked(w http.ResponseWriter, err *timesheets.LockedError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusLocked)
+	json.NewEncoder(w).Encode(LockedResponse{Error: err.Error(), Reason: models.ReasonPeriodLocked, WeekStart: err.WeekStart})
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// recordExists checks that the record with the given ID referenced by a request exists.
+// It writes an error response and returns false if it does not.
+func recordExists(w http.ResponseWriter, db *gorm.DB, model interface{}, name string, id uint) bool {
+	if err := db.First(model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, name+" not found", http.StatusBadRequest)
+			log.Printf("%s not found with ID %d", name, id)
+			return false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching %s: %v", name, err)
+		return false
+	}
+	return true
+}
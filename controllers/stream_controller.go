@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"time-tracker-go/live"
+	"time-tracker-go/models"
+	"time-tracker-go/services"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second // Interval of comments that keep idle streams open through proxies
+	reconnectDelay    = 3000             // Milliseconds clients wait before reconnecting to a dropped stream
+)
+
+// EventSnapshot is the type of the event that carries the running tasks when a stream starts
+// or cannot be resumed. Clients replace their state with it.
+const EventSnapshot = "snapshot"
+
+// StreamController handles Server-Sent Events streams of timer changes.
+type StreamController struct {
+	DB    *gorm.DB
+	Tasks *services.Tasks
+	Live  *live.Broker
+}
+
+// NewStreamController creates a new instance of StreamController with the given DB connection, task service and broker.
+func NewStreamController(db *gorm.DB, tasks *services.Tasks, broker *live.Broker) *StreamController {
+	return &StreamController{DB: db, Tasks: tasks, Live: broker}
+}
+
+// Snapshot lists the running tasks at the time a stream started.
+type Snapshot struct {
+	Tasks []models.Task `json:"tasks"` // Running tasks with their intervals
+}
+
+// @Summary Stream timer changes of the team
+// @Description Opens a Server-Sent Events stream of the tasks of all users being added, started, paused, ended and cancelled.
+// @Description The stream starts with a "snapshot" event listing the running tasks. Every event has an ID; a client that reconnects
+// @Description with the Last-Event-ID header (or the lastEventId query parameter) receives the events it missed, or a new snapshot
+// @Description if they are no longer available. Idle streams receive a heartbeat comment every 15 seconds.
+// @Tags stream
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param lastEventId query string false "ID of the last event received, for clients that cannot set headers"
+// @Success 200 {object} live.Event
+// @Router /stream [get]
+func (sc *StreamController) StreamTeam(w http.ResponseWriter, r *http.Request) {
+	sc.stream(w, r, 0)
+}
+
+// @Summary Stream timer changes of a user
+// @Description Opens a Server-Sent Events stream of the tasks of a user being added, started, paused, ended and cancelled.
+// @Description It behaves like the team stream, limited to the user.
+// @Tags stream
+// @Produce text/event-stream
+// @Param id path int true "User ID"
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param lastEventId query string false "ID of the last event received, for clients that cannot set headers"
+// @Success 200 {object} live.Event
+// @Router /users/{id}/stream [get]
+func (sc *StreamController) StreamUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil || userID == 0 {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		log.Printf("Invalid user ID: %s", mux.Vars(r)["id"])
+		return
+	}
+
+	if err := sc.DB.Select("id").First(&models.User{}, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "User not found", http.StatusNotFound)
+			log.Printf("User not found with ID %d", userID)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching user: %v", err)
+		return
+	}
+
+	sc.stream(w, r, uint(userID))
+}
+
+// stream sends the events of the user, or of all users if userID is 0, until the client disconnects.
+func (sc *StreamController) stream(w http.ResponseWriter, r *http.Request, userID uint) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		log.Printf("Response writer does not support flushing")
+		return
+	}
+
+	var lastEventID uint64
+	lastEventIDStr := r.Header.Get("Last-Event-ID")
+	if lastEventIDStr == "" {
+		lastEventIDStr = r.URL.Query().Get("lastEventId")
+	}
+	if lastEventIDStr != "" {
+		var err error
+		if lastEventID, err = strconv.ParseUint(lastEventIDStr, 10, 64); err != nil {
+			http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+			log.Printf("Invalid Last-Event-ID: %v", err)
+			return
+		}
+	}
+
+	subscription, missed, complete, lastID := sc.Live.Subscribe(userID, lastEventID)
+	defer subscription.Close()
+
+	// The snapshot is loaded after subscribing, so no change is lost between the two;
+	// events already reflected in it are harmless to apply again.
+	var snapshot *live.Event
+	if lastEventID == 0 || !complete {
+		tasks, err := sc.Tasks.Running(userID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			log.Printf("Error fetching running tasks: %v", err)
+			return
+		}
+		snapshot = &live.Event{ID: lastID, Type: EventSnapshot, UserID: userID, Time: time.Now(), Data: Snapshot{Tasks: tasks}}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay)
+
+	if snapshot != nil {
+		missed = []live.Event{*snapshot}
+	}
+	for _, event := range missed {
+		if err := writeEvent(w, event); err != nil {
+			log.Printf("Error writing stream event: %v", err)
+			return
+		}
+	}
+	flusher.Flush()
+
+	log.Printf("Opened stream of user %d after event %d (%d events resent)", userID, lastEventID, len(missed))
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			log.Printf("Closed stream of user %d", userID)
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events:
+			if !ok {
+				// The stream fell behind; the client reconnects and resumes from the last event it received.
+				log.Printf("Dropped slow stream of user %d", userID)
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				log.Printf("Error writing stream event: %v", err)
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes an event in the Server-Sent Events format.
+func writeEvent(w http.ResponseWriter, event live.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
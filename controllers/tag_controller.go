@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time-tracker-go/models"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// TagController handles HTTP requests related to the tag catalogue.
+type TagController struct {
+	DB *gorm.DB
+}
+
+// NewTagController creates a new instance of TagController with the given DB connection.
+func NewTagController(db *gorm.DB) *TagController {
+	return &TagController{DB: db}
+}
+
+// TagRequest describes the editable fields of a tag.
+type TagRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// @Summary Get tags
+// @Description Retrieves the tag catalogue ordered by name
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Tag
+// @Router /tags [get]
+func (tgc *TagController) GetTags(w http.ResponseWriter, r *http.Request) {
+	var tags []models.Tag
+	if err := tgc.DB.Order("name").Find(&tags).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching tags: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+
+	log.Printf("Fetched %d tags", len(tags))
+}
+
+// @Summary Add a new tag
+// @Description Adds a new tag to the catalogue. Names are stored in lower case and must be unique.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body TagRequest true "Tag to be added"
+// @Success 201 {object} models.Tag
+// @Failure 409 {object} ConflictResponse
+// @Router /tags [post]
+func (tgc *TagController) AddTag(w http.ResponseWriter, r *http.Request) {
+	var request TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	tag := models.Tag{Name: normalizeTagName(request.Name), Color: request.Color}
+	if tag.Name == "" {
+		http.Error(w, "Tag name is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := tgc.DB.Create(&tag).Error; err != nil {
+		writeTagSaveError(w, err)
+		log.Printf("Error creating tag: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tag)
+
+	log.Printf("Created tag %q with ID %d", tag.Name, tag.ID)
+}
+
+// @Summary Update a tag by ID
+// @Description Renames or recolors a tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tagID path int true "Tag ID"
+// @Param tag body TagRequest true "Updated tag"
+// @Success 200 {object} models.Tag
+// @Failure 409 {object} ConflictResponse
+// @Router /tags/{tagID} [put]
+func (tgc *TagController) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	tag, ok := tgc.findTag(w, r)
+	if !ok {
+		return
+	}
+
+	var request TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	tag.Name = normalizeTagName(request.Name)
+	tag.Color = request.Color
+	if tag.Name == "" {
+		http.Error(w, "Tag name is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := tgc.DB.Save(&tag).Error; err != nil {
+		writeTagSaveError(w, err)
+		log.Printf("Error updating tag: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tag)
+
+	log.Printf("Updated tag with ID %d", tag.ID)
+}
+
+// @Summary Delete a tag by ID
+// @Description Deletes a tag from the catalogue and removes it from all tasks
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tagID path int true "Tag ID"
+// @Success 200 {object} map[string]string
+// @Router /tags/{tagID} [delete]
+func (tgc *TagController) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	tag, ok := tgc.findTag(w, r)
+	if !ok {
+		return
+	}
+
+	err := tgc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&tag).Error
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error deleting tag: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Tag deleted successfully"})
+
+	log.Printf("Deleted tag with ID %d", tag.ID)
+}
+
+// findTag loads the tag identified by the "tagID" route parameter.
+// It writes an error response and returns false if the tag cannot be loaded.
+func (tgc *TagController) findTag(w http.ResponseWriter, r *http.Request) (models.Tag, bool) {
+	var tag models.Tag
+
+	id, err := strconv.Atoi(mux.Vars(r)["tagID"])
+	if err != nil {
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		log.Printf("Invalid tag ID: %v", err)
+		return tag, false
+	}
+
+	if err := tgc.DB.First(&tag, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "Tag not found", http.StatusNotFound)
+			log.Printf("Tag not found with ID %d", id)
+			return tag, false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching tag: %v", err)
+		return tag, false
+	}
+
+	return tag, true
+}
+
+// writeTagSaveError maps an error returned while saving a tag to an HTTP response.
+func writeTagSaveError(w http.ResponseWriter, err error) {
+	if isUniqueViolation(err) {
+		writeConflict(w, ConflictResponse{
+			Error:  "a tag with this name already exists",
+			Reason: ReasonTagExists,
+		})
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// normalizeTagName trims and lower-cases a tag name.
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -2,11 +2,17 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"time-tracker-go/services"
+	"time-tracker-go/timesheets"
 
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
@@ -14,24 +20,49 @@ import (
 
 // TaskController handles HTTP requests related to tasks.
 type TaskController struct {
-	DB *gorm.DB
+	DB    *gorm.DB
+	Tasks *services.Tasks
 }
 
-// NewTaskController creates a new instance of TaskController with the given DB connection.
-func NewTaskController(db *gorm.DB) *TaskController {
-	return &TaskController{DB: db}
+// timeLayout is the format of date-time query parameters.
+const timeLayout = "2006-01-02T15:04:05"
+
+// UpdateTaskRequest describes the editable fields of a task. Omitted fields are left unchanged.
+type UpdateTaskRequest struct {
+	Description       *string `json:"description"`
+	ProjectID         *uint   `json:"projectID"` // 0 removes the task from its project
+	TagIDs            *[]uint `json:"tagIDs"`    // Replaces the tags of the task
+	Billable          *bool   `json:"billable"`
+	EstimatedDuration *int    `json:"estimatedDuration"` // Estimated duration in minutes; 0 removes the estimate
+}
+
+// NewTaskController creates a new instance of TaskController with the given DB connection and task service.
+func NewTaskController(db *gorm.DB, tasks *services.Tasks) *TaskController {
+	return &TaskController{DB: db, Tasks: tasks}
 }
 
 // @Summary Get time entries by user ID and period
-// @Description Retrieves time entries for a user within a specified time period
+// @Description Retrieves tasks of a user whose work intervals overlap the specified period.
+// @Description Intervals are clipped to the period, running tasks are counted up to now.
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Produce text/csv
 // @Param id path int true "User ID"
-// @Param start_date query string true "Start date (format: 2006-01-02T15:04:05)"
-// @Param end_date query string true "End date (format: 2006-01-02T15:04:05)"
-// @Success 200 {array} models.Task
-// @Router /users/{id}/tasks [get]
+// @Param start_date query string true "Start date, inclusive (format: 2006-01-02T15:04:05)"
+// @Param end_date query string true "End date, exclusive (format: 2006-01-02T15:04:05)"
+// @Param project_id query string false "Comma-separated list of project IDs"
+// @Param client_id query string false "Comma-separated list of client IDs"
+// @Param tags_any query string false "Comma-separated tag names, any of which a task must have"
+// @Param tags_all query string false "Comma-separated tag names, all of which a task must have"
+// @Param tags_none query string false "Comma-separated tag names, none of which a task may have"
+// @Param billable query bool false "Only billable (true) or non-billable (false) tasks"
+// @Param format query string false "Response format: json or csv (also selected with Accept: text/csv)"
+// @Param delimiter query string false "CSV field delimiter: a single character, tab or semicolon" default(,)
+// @Param decimal query string false "CSV decimal separator: . or ," default(.)
+// @Param bom query bool false "Prefix CSV output with a UTF-8 byte order mark"
+// @Success 200 {object} reports.TimeEntries
+// @Router /users/{id}/time-entries [get]
 func (tc *TaskController) GetTimeEntriesByUserAndPeriod(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userID, err := strconv.Atoi(params["id"])
@@ -41,53 +72,128 @@ func (tc *TaskController) GetTimeEntriesByUserAndPeriod(w http.ResponseWriter, r
 		return
 	}
 
-	startDateStr := r.URL.Query().Get("start_date")
-	endDateStr := r.URL.Query().Get("end_date")
-	if startDateStr == "" || endDateStr == "" {
-		http.Error(w, "Missing start_date and end_date", http.StatusBadRequest)
-		log.Println("Missing start_date or end_date")
+	period, ok := parsePeriod(w, r)
+	if !ok {
 		return
 	}
 
-	startDate, err := time.Parse("2006-01-02T15:04:05", startDateStr)
-	if err != nil {
-		http.Error(w, "Invalid start_date format", http.StatusBadRequest)
-		log.Printf("Invalid start_date format: %v", err)
+	log.Printf("Fetching time entries for user %d between %s and %s", userID, period.From, period.To)
+
+	filter, ok := parseTaskFilter(w, r)
+	if !ok {
 		return
 	}
 
-	endDate, err := time.Parse("2006-01-02T15:04:05", endDateStr)
+	entries, err := tc.Tasks.TimeEntries(uint(userID), period, filter)
 	if err != nil {
-		http.Error(w, "Invalid end_date format", http.StatusBadRequest)
-		log.Printf("Invalid end_date format: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching time entries: %v", err)
 		return
 	}
 
-	log.Printf("Fetching time entries for user %d between %s and %s", userID, startDateStr, endDateStr)
-
-	var tasks []models.Task
-	if err := tc.DB.Where("user_id = ? AND start_time >= ? AND end_time <= ?", userID, startDate, endDate).Order("duration DESC").Find(&tasks).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error fetching time entries: %v", err)
+	if responseFormat(r) == formatCSV {
+		exportCSV(w, r, fmt.Sprintf("time-entries-%d.csv", userID), func(export *csvExport) error {
+			return writeTimeEntriesCSV(export, entries)
+		})
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tasks)
+	json.NewEncoder(w).Encode(entries)
 
-	log.Printf("Successfully fetched %d time entries", len(tasks))
+	log.Printf("Successfully fetched %d time entries", len(entries.Entries))
 }
 
 // @Summary Start a task for a user
-// @Description Starts a task for a user
+// @Description Starts a created task for a user by opening its first work interval. A user can have only one running task.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
 // @Param taskID path int true "Task ID"
+// @Param switch query bool false "End the currently running task of the user first"
 // @Success 200 {object} models.Task
-// @Router /users/{id}/tasks/{taskID}/start [post]
+// @Failure 409 {object} ConflictResponse
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks/{taskID}/start [put]
 func (tc *TaskController) StartTaskForUser(w http.ResponseWriter, r *http.Request) {
+	tc.applyTaskAction(w, r, models.TaskActionStart)
+}
+
+// @Summary Pause a task for a user
+// @Description Pauses a running task for a user by closing its current work interval
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param taskID path int true "Task ID"
+// @Success 200 {object} models.Task
+// @Failure 409 {object} ConflictResponse
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks/{taskID}/pause [put]
+func (tc *TaskController) PauseTaskForUser(w http.ResponseWriter, r *http.Request) {
+	tc.applyTaskAction(w, r, models.TaskActionPause)
+}
+
+// @Summary Resume a task for a user
+// @Description Resumes a paused task for a user by opening a new work interval. A user can have only one running task.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param taskID path int true "Task ID"
+// @Param switch query bool false "End the currently running task of the user first"
+// @Success 200 {object} models.Task
+// @Failure 409 {object} ConflictResponse
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks/{taskID}/resume [put]
+func (tc *TaskController) ResumeTaskForUser(w http.ResponseWriter, r *http.Request) {
+	tc.applyTaskAction(w, r, models.TaskActionResume)
+}
+
+// @Summary End a task for a user
+// @Description Finishes a running or paused task for a user, closing its running work interval if there is one.
+// @Description If the task has exceeded its estimate or its project its budget, a budget event is recorded for managers.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param taskID path int true "Task ID"
+// @Success 200 {object} models.Task
+// @Failure 409 {object} ConflictResponse
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks/{taskID}/end [put]
+func (tc *TaskController) EndTaskForUser(w http.ResponseWriter, r *http.Request) {
+	tc.applyTaskAction(w, r, models.TaskActionEnd)
+}
+
+// @Summary Cancel a task for a user
+// @Description Cancels a task that is not finished yet, closing its running work interval if there is one
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param taskID path int true "Task ID"
+// @Success 200 {object} models.Task
+// @Failure 409 {object} ConflictResponse
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks/{taskID}/cancel [put]
+func (tc *TaskController) CancelTaskForUser(w http.ResponseWriter, r *http.Request) {
+	tc.applyTaskAction(w, r, models.TaskActionCancel)
+}
+
+// @Summary Add a task for a user
+// @Description Adds a new task for a user, optionally with an estimated duration in minutes. A task with both start and end time is a manual entry, which must end after it starts and cannot be added to an approved week.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param task body models.Task true "Task object to be added. Tags are referenced by ID."
+// @Success 201 {object} models.Task
+// @Failure 400 {string} string "Invalid task"
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks [post]
+func (tc *TaskController) AddTaskForUser(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userID, err := strconv.Atoi(params["id"])
 	if err != nil {
@@ -96,126 +202,373 @@ func (tc *TaskController) StartTaskForUser(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	taskID, err := strconv.Atoi(params["taskID"])
+	var newTask models.Task
+	if err := json.NewDecoder(r.Body).Decode(&newTask); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	newTask, err = tc.Tasks.Add(uint(userID), newTask)
 	if err != nil {
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
-		log.Printf("Invalid task ID: %v", err)
+		writeTaskActionError(w, err)
+		log.Printf("Error creating task: %v", err)
 		return
 	}
 
-	var task models.Task
-	if err := tc.DB.Where("user_id=? AND id=?", userID, taskID).First(&task).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			http.Error(w, "Task not found", http.StatusNotFound)
-			log.Printf("Task not found for user %d with task ID %d", userID, taskID)
-			return
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newTask)
+
+	log.Printf("Task created successfully for user %d with ID %d", userID, newTask.ID)
+}
+
+// @Summary Get tasks of a user
+// @Description Retrieves tasks of a user with optional status and creation date filters and supports pagination
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param status query string false "Comma-separated list of statuses (created, running, paused, done, cancelled)"
+// @Param start_date query string false "Created at or after (format: 2006-01-02T15:04:05)"
+// @Param end_date query string false "Created at or before (format: 2006-01-02T15:04:05)"
+// @Param project_id query string false "Comma-separated list of project IDs"
+// @Param client_id query string false "Comma-separated list of client IDs"
+// @Param tags_any query string false "Comma-separated tag names, any of which a task must have"
+// @Param tags_all query string false "Comma-separated tag names, all of which a task must have"
+// @Param tags_none query string false "Comma-separated tag names, none of which a task may have"
+// @Param billable query bool false "Only billable (true) or non-billable (false) tasks"
+// @Param deleted query bool false "List soft-deleted tasks instead of active ones"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} models.Task
+// @Router /users/{id}/tasks [get]
+func (tc *TaskController) GetTasksForUser(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userID, err := strconv.Atoi(params["id"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		log.Printf("Invalid user ID: %v", err)
+		return
+	}
+
+	var query services.TaskQuery
+
+	// Filtration
+	query.Deleted = r.URL.Query().Get("deleted") == "true"
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		for _, value := range strings.Split(status, ",") {
+			taskStatus := models.TaskStatus(strings.TrimSpace(value))
+			if !taskStatus.IsValid() {
+				http.Error(w, "Invalid status", http.StatusBadRequest)
+				log.Printf("Invalid status: %s", value)
+				return
+			}
+			query.Statuses = append(query.Statuses, taskStatus)
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error fetching task: %v", err)
+	}
+
+	filter, ok := parseTaskFilter(w, r)
+	if !ok {
 		return
 	}
+	query.Filter = filter
+
+	if startDateStr := r.URL.Query().Get("start_date"); startDateStr != "" {
+		startDate, err := time.Parse(timeLayout, startDateStr)
+		if err != nil {
+			http.Error(w, "Invalid start_date format", http.StatusBadRequest)
+			log.Printf("Invalid start_date format: %v", err)
+			return
+		}
+		query.CreatedFrom = &startDate
+	}
 
-	task.StartTime = time.Now()
+	if endDateStr := r.URL.Query().Get("end_date"); endDateStr != "" {
+		endDate, err := time.Parse(timeLayout, endDateStr)
+		if err != nil {
+			http.Error(w, "Invalid end_date format", http.StatusBadRequest)
+			log.Printf("Invalid end_date format: %v", err)
+			return
+		}
+		query.CreatedTo = &endDate
+	}
 
-	if err := tc.DB.Save(&task).Error; err != nil {
+	// Pagination
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	tasks, err := tc.Tasks.List(uint(userID), query, services.Page{Number: page, Size: pageSize})
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error saving task: %v", err)
+		log.Printf("Error fetching tasks: %v", err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(task)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tasks)
 
-	log.Printf("Task %d started for user %d", taskID, userID)
+	log.Printf("Fetched %d tasks for user %d", len(tasks), userID)
 }
 
-// @Summary End a task for a user
-// @Description Ends a task for a user
+// @Summary Get a task of a user
+// @Description Retrieves a single task of a user together with its work intervals
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
 // @Param taskID path int true "Task ID"
 // @Success 200 {object} models.Task
-// @Router /users/{id}/tasks/{taskID}/end [post]
-func (tc *TaskController) EndTaskForUser(w http.ResponseWriter, r *http.Request) {
-	params := mux.Vars(r)
-	userID, err := strconv.Atoi(params["id"])
-	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
-		log.Printf("Invalid user ID: %v", err)
+// @Router /users/{id}/tasks/{taskID} [get]
+func (tc *TaskController) GetTaskForUser(w http.ResponseWriter, r *http.Request) {
+	userID, taskID, ok := parseTaskParams(w, r)
+	if !ok {
 		return
 	}
 
-	taskID, err := strconv.Atoi(params["taskID"])
+	task, err := tc.Tasks.Get(userID, taskID)
 	if err != nil {
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
-		log.Printf("Invalid task ID: %v", err)
+		writeTaskActionError(w, err)
+		log.Printf("Error fetching task %d of user %d: %v", taskID, userID, err)
 		return
 	}
 
-	var task models.Task
-	if err := tc.DB.Where("user_id=? AND id=?", userID, taskID).First(&task).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			http.Error(w, "Task not found", http.StatusNotFound)
-			log.Printf("Task not found for user %d with task ID %d", userID, taskID)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error fetching task: %v", err)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+
+	log.Printf("Fetched task %d for user %d", taskID, userID)
+}
+
+// @Summary Update a task of a user
+// @Description Updates editable fields of a task. Status changes go through the start/pause/resume/end/cancel endpoints.
+// @Description Tasks with time in an approved week cannot be edited. The billable flag and project of a task
+// @Description with time on an invoice cannot be changed until the invoice is deleted.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param taskID path int true "Task ID"
+// @Param task body UpdateTaskRequest true "Fields to update"
+// @Success 200 {object} models.Task
+// @Failure 409 {object} ConflictResponse
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks/{taskID} [patch]
+func (tc *TaskController) UpdateTaskForUser(w http.ResponseWriter, r *http.Request) {
+	userID, taskID, ok := parseTaskParams(w, r)
+	if !ok {
 		return
 	}
 
-	task.EndTime = time.Now()
-	task.Duration = int(task.EndTime.Sub(task.StartTime).Minutes())
+	var request UpdateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
 
-	if err := tc.DB.Save(&task).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error saving task: %v", err)
+	task, err := tc.Tasks.Update(userID, taskID, services.TaskChanges(request))
+	if err != nil {
+		writeTaskActionError(w, err)
+		log.Printf("Error updating task: %v", err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(task)
 
-	log.Printf("Task %d ended for user %d", taskID, userID)
+	log.Printf("Updated task %d for user %d", taskID, userID)
 }
 
-// @Summary Add a task for a user
-// @Description Adds a new task for a user
+// @Summary Delete a task of a user
+// @Description Soft-deletes a task of a user. Running tasks must be paused or ended first; tasks with time in an approved week
+// @Description or on an invoice that has not been deleted cannot be deleted.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
-// @Param task body models.Task true "Task object to be added"
-// @Success 201 {object} models.Task
-// @Router /users/{id}/tasks [post]
-func (tc *TaskController) AddTaskForUser(w http.ResponseWriter, r *http.Request) {
+// @Param taskID path int true "Task ID"
+// @Success 200 {object} map[string]string
+// @Failure 409 {object} ConflictResponse
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks/{taskID} [delete]
+func (tc *TaskController) DeleteTaskForUser(w http.ResponseWriter, r *http.Request) {
+	userID, taskID, ok := parseTaskParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := tc.Tasks.Delete(userID, taskID); err != nil {
+		writeTaskActionError(w, err)
+		log.Printf("Error deleting task: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Task deleted successfully"})
+
+	log.Printf("Deleted task %d for user %d", taskID, userID)
+}
+
+// @Summary Restore a deleted task of a user
+// @Description Restores a soft-deleted task of a user, unless it has time in an approved week
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param taskID path int true "Task ID"
+// @Success 200 {object} models.Task
+// @Failure 423 {object} LockedResponse
+// @Router /users/{id}/tasks/{taskID}/restore [put]
+func (tc *TaskController) RestoreTaskForUser(w http.ResponseWriter, r *http.Request) {
+	userID, taskID, ok := parseTaskParams(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := tc.Tasks.Restore(userID, taskID)
+	if err != nil {
+		writeTaskActionError(w, err)
+		log.Printf("Error restoring task: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(task)
+
+	log.Printf("Restored task %d for user %d", taskID, userID)
+}
+
+// parsePeriod reads the required "start_date" and "end_date" query parameters.
+// It writes an error response and returns false if they are missing or invalid.
+func parsePeriod(w http.ResponseWriter, r *http.Request) (reports.Period, bool) {
+	startDateStr := r.URL.Query().Get("start_date")
+	endDateStr := r.URL.Query().Get("end_date")
+	if startDateStr == "" || endDateStr == "" {
+		http.Error(w, "Missing start_date and end_date", http.StatusBadRequest)
+		log.Println("Missing start_date or end_date")
+		return reports.Period{}, false
+	}
+
+	startDate, err := time.Parse(timeLayout, startDateStr)
+	if err != nil {
+		http.Error(w, "Invalid start_date format", http.StatusBadRequest)
+		log.Printf("Invalid start_date format: %v", err)
+		return reports.Period{}, false
+	}
+
+	endDate, err := time.Parse(timeLayout, endDateStr)
+	if err != nil {
+		http.Error(w, "Invalid end_date format", http.StatusBadRequest)
+		log.Printf("Invalid end_date format: %v", err)
+		return reports.Period{}, false
+	}
+
+	if !endDate.After(startDate) {
+		http.Error(w, "end_date must be after start_date", http.StatusBadRequest)
+		log.Printf("Invalid period: %s - %s", startDateStr, endDateStr)
+		return reports.Period{}, false
+	}
+
+	return reports.Period{From: startDate, To: endDate}, true
+}
+
+// parseTaskParams extracts the "id" and "taskID" route parameters.
+// It writes an error response and returns false if either of them is invalid.
+func parseTaskParams(w http.ResponseWriter, r *http.Request) (uint, uint, bool) {
 	params := mux.Vars(r)
-	userID, err := strconv.Atoi(params["id"])
+	userID, err := strconv.ParseUint(params["id"], 10, 64)
 	if err != nil {
 		http.Error(w, "Invalid user ID", http.StatusBadRequest)
 		log.Printf("Invalid user ID: %v", err)
-		return
+		return 0, 0, false
 	}
 
-	var newTask models.Task
-	if err := json.NewDecoder(r.Body).Decode(&newTask); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
-		log.Printf("Invalid request payload: %v", err)
-		return
+	taskID, err := strconv.ParseUint(params["taskID"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		log.Printf("Invalid task ID: %v", err)
+		return 0, 0, false
 	}
 
-	newTask.UserID = uint(userID)
+	return uint(userID), uint(taskID), true
+}
 
-	if err := tc.DB.Create(&newTask).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error creating task: %v", err)
+// applyTaskAction moves the task identified by the route parameters through the given lifecycle action.
+// Illegal transitions are answered with 409 Conflict, and opening or closing an interval in an approved week with 423 Locked.
+//
+// A user may have at most one running task. Starting or resuming a task while another one is running
+// is rejected unless the "switch" query parameter is true, in which case the running task is ended first.
+func (tc *TaskController) applyTaskAction(w http.ResponseWriter, r *http.Request, action models.TaskAction) {
+	userID, taskID, ok := parseTaskParams(w, r)
+	if !ok {
 		return
 	}
+	switchRunning := r.URL.Query().Get("switch") == "true"
 
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(newTask)
+	task, err := tc.Tasks.Apply(userID, taskID, action, switchRunning)
+	if err != nil {
+		writeTaskActionError(w, err)
+		log.Printf("Error applying %s to task %d of user %d: %v", action, taskID, userID, err)
+		return
+	}
 
-	log.Printf("Task created successfully for user %d with ID %d", userID, newTask.ID)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(task)
+
+	log.Printf("Task %d: %s for user %d, status is now %s", task.ID, action, task.UserID, task.Status)
+}
+
+// writeTaskActionError maps an error returned by the task service to an HTTP response.
+func writeTaskActionError(w http.ResponseWriter, err error) {
+	var transitionErr *models.TransitionError
+	var runningErr *models.RunningTaskError
+	var lockedErr *timesheets.LockedError
+	var invalidErr *services.InvalidError
+	switch {
+	case errors.As(err, &invalidErr):
+		http.Error(w, invalidErr.Message, http.StatusBadRequest)
+	case errors.Is(err, services.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrTaskNotFound):
+		http.Error(w, "Task not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrDeletedTaskNotFound):
+		http.Error(w, "Deleted task not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrDeleteRunning):
+		writeConflict(w, ConflictResponse{
+			Error:  err.Error(),
+			Reason: models.ReasonTaskRunning,
+			Status: models.TaskStatusRunning,
+		})
+	case errors.Is(err, services.ErrTaskInvoiced):
+		writeConflict(w, ConflictResponse{Error: err.Error(), Reason: models.ReasonTaskInvoiced})
+	case errors.As(err, &transitionErr):
+		writeConflict(w, ConflictResponse{
+			Error:  err.Error(),
+			Reason: transitionErr.Reason,
+			Status: transitionErr.Status,
+			Action: transitionErr.Action,
+		})
+	case errors.As(err, &runningErr):
+		writeConflict(w, ConflictResponse{
+			Error:         err.Error(),
+			Reason:        models.ReasonAnotherTaskRunning,
+			RunningTaskID: runningErr.TaskID,
+		})
+	case errors.As(err, &lockedErr):
+		writeLocked(w, lockedErr)
+	case isUniqueViolation(err):
+		// The database index guarantees the invariant even if the row lock was bypassed.
+		writeConflict(w, ConflictResponse{
+			Error:  "another task is already running",
+			Reason: models.ReasonAnotherTaskRunning,
+		})
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
@@ -0,0 +1,263 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/timesheets"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// TimesheetController handles HTTP requests related to the weekly timesheet approval workflow.
+type TimesheetController struct {
+	DB *gorm.DB
+}
+
+// NewTimesheetController creates a new instance of TimesheetController with the given DB connection.
+func NewTimesheetController(db *gorm.DB) *TimesheetController {
+	return &TimesheetController{DB: db}
+}
+
+// ReviewRequest identifies the manager approving or rejecting a timesheet.
+type ReviewRequest struct {
+	ReviewerID uint   `json:"reviewerID"` // ID of the reviewing user, who cannot be the owner of the timesheet
+	Comment    string `json:"comment"`    // Comment for the user; required to reject a timesheet
+}
+
+// @Summary Get timesheets
+// @Description Retrieves submitted, approved and rejected weekly timesheets with optional status and user filters and supports pagination
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param status query string false "Timesheet status: submitted, approved or rejected"
+// @Param user_id query int false "User ID"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} models.TimesheetApproval
+// @Router /timesheets [get]
+func (tc *TimesheetController) GetTimesheets(w http.ResponseWriter, r *http.Request) {
+	var approvals []models.TimesheetApproval
+	query := tc.DB.Preload("User").Preload("Reviewer")
+
+	// Filtration
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		status := models.TimesheetStatus(statusStr)
+		if !status.IsValid() {
+			http.Error(w, "Invalid status", http.StatusBadRequest)
+			log.Printf("Invalid status: %s", statusStr)
+			return
+		}
+		query = query.Where("status = ?", status)
+	}
+
+	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
+		userID, err := strconv.Atoi(userIDStr)
+		if err != nil {
+			http.Error(w, "Invalid user_id", http.StatusBadRequest)
+			log.Printf("Invalid user_id: %v", err)
+			return
+		}
+		query = query.Where("user_id = ?", userID)
+	}
+
+	// Pagination
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := query.Order("week_start DESC, user_id").Limit(pageSize).Offset(offset).Find(&approvals).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching timesheets: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(approvals)
+
+	log.Printf("Fetched %d timesheets", len(approvals))
+}
+
+// @Summary Get the timesheet of a user for a week
+// @Description Retrieves the approval state of a week of a user together with the time tracked in it. A week that has never been submitted has an empty status.
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param week path string true "Monday the week starts on (format: 2006-01-02)"
+// @Success 200 {object} timesheets.Timesheet
+// @Router /users/{id}/timesheets/{week} [get]
+func (tc *TimesheetController) GetTimesheet(w http.ResponseWriter, r *http.Request) {
+	userID, week, ok := parseTimesheetParams(w, r)
+	if !ok {
+		return
+	}
+	if !recordExists(w, tc.DB, &models.User{}, "User", userID) {
+		return
+	}
+
+	timesheet, err := timesheets.Find(tc.DB, userID, week, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching timesheet: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timesheet)
+
+	log.Printf("Fetched timesheet of user %d for the week of %s", userID, week.Format("2006-01-02"))
+}
+
+// @Summary Submit a timesheet
+// @Description Submits a week of a user for approval, recording the total time tracked in it. Rejected weeks can be submitted again after corrections.
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param week path string true "Monday the week starts on (format: 2006-01-02)"
+// @Success 200 {object} models.TimesheetApproval
+// @Failure 409 {object} ConflictResponse
+// @Router /users/{id}/timesheets/{week}/submit [put]
+func (tc *TimesheetController) SubmitTimesheet(w http.ResponseWriter, r *http.Request) {
+	tc.applyTimesheetAction(w, r, models.TimesheetActionSubmit, func(tx *gorm.DB, userID uint, week time.Time, now time.Time) (models.TimesheetApproval, error) {
+		return timesheets.Submit(tx, userID, week, now)
+	})
+}
+
+// @Summary Approve a timesheet
+// @Description Approves a submitted week of a user. The time of an approved week is locked: tasks with time in it cannot be added, started, ended, edited or deleted.
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param week path string true "Monday the week starts on (format: 2006-01-02)"
+// @Param review body ReviewRequest true "Reviewer and optional comment"
+// @Success 200 {object} models.TimesheetApproval
+// @Failure 409 {object} ConflictResponse
+// @Router /users/{id}/timesheets/{week}/approve [put]
+func (tc *TimesheetController) ApproveTimesheet(w http.ResponseWriter, r *http.Request) {
+	tc.reviewTimesheet(w, r, models.TimesheetActionApprove, timesheets.Approve)
+}
+
+// @Summary Reject a timesheet
+// @Description Returns a submitted week to the user with a comment explaining what to correct
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param week path string true "Monday the week starts on (format: 2006-01-02)"
+// @Param review body ReviewRequest true "Reviewer and comment"
+// @Success 200 {object} models.TimesheetApproval
+// @Failure 409 {object} ConflictResponse
+// @Router /users/{id}/timesheets/{week}/reject [put]
+func (tc *TimesheetController) RejectTimesheet(w http.ResponseWriter, r *http.Request) {
+	tc.reviewTimesheet(w, r, models.TimesheetActionReject, timesheets.Reject)
+}
+
+// reviewTimesheet reads the review request and applies the approve or reject action with it.
+func (tc *TimesheetController) reviewTimesheet(w http.ResponseWriter, r *http.Request, action models.TimesheetAction, review func(*gorm.DB, uint, time.Time, uint, string, time.Time) (models.TimesheetApproval, error)) {
+	var request ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+	if request.ReviewerID == 0 {
+		http.Error(w, "Reviewer ID is required", http.StatusBadRequest)
+		return
+	}
+	if !recordExists(w, tc.DB, &models.User{}, "Reviewer", request.ReviewerID) {
+		return
+	}
+
+	tc.applyTimesheetAction(w, r, action, func(tx *gorm.DB, userID uint, week time.Time, now time.Time) (models.TimesheetApproval, error) {
+		return review(tx, userID, week, request.ReviewerID, request.Comment, now)
+	})
+}
+
+// applyTimesheetAction applies the action to the timesheet identified by the route parameters in a transaction
+// and responds with the updated timesheet. Actions not allowed in the current state are answered with 409 Conflict.
+func (tc *TimesheetController) applyTimesheetAction(w http.ResponseWriter, r *http.Request, action models.TimesheetAction, apply func(*gorm.DB, uint, time.Time, time.Time) (models.TimesheetApproval, error)) {
+	userID, week, ok := parseTimesheetParams(w, r)
+	if !ok {
+		return
+	}
+
+	var approval models.TimesheetApproval
+	err := tc.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		approval, err = apply(tx, userID, week, time.Now())
+		return err
+	})
+	if err != nil {
+		writeTimesheetActionError(w, err)
+		log.Printf("Error applying %s to the timesheet of user %d for the week of %s: %v", action, userID, week.Format("2006-01-02"), err)
+		return
+	}
+
+	if err := tc.DB.Preload("User").Preload("Reviewer").First(&approval, approval.ID).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching timesheet: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(approval)
+
+	log.Printf("Applied %s to the timesheet of user %d for the week of %s", action, userID, week.Format("2006-01-02"))
+}
+
+// parseTimesheetParams extracts the "id" and "week" route parameters.
+// It writes an error response and returns false if either of them is invalid.
+func parseTimesheetParams(w http.ResponseWriter, r *http.Request) (uint, time.Time, bool) {
+	params := mux.Vars(r)
+	userID, err := strconv.ParseUint(params["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		log.Printf("Invalid user ID: %v", err)
+		return 0, time.Time{}, false
+	}
+
+	week, err := timesheets.ParseWeek(params["week"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("Invalid week: %s", params["week"])
+		return 0, time.Time{}, false
+	}
+
+	return uint(userID), week, true
+}
+
+// writeTimesheetActionError maps an error returned while changing a timesheet state to an HTTP response.
+func writeTimesheetActionError(w http.ResponseWriter, err error) {
+	var transitionErr *models.TimesheetTransitionError
+	switch {
+	case errors.Is(err, timesheets.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case errors.Is(err, timesheets.ErrFutureWeek), errors.Is(err, timesheets.ErrCommentRequired):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, timesheets.ErrOwnTimesheet):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, timesheets.ErrTaskRunning):
+		writeConflict(w, ConflictResponse{Error: err.Error(), Reason: models.ReasonTaskRunning})
+	case errors.As(err, &transitionErr):
+		writeConflict(w, ConflictResponse{Error: err.Error(), Reason: transitionErr.Reason})
+	case isUniqueViolation(err):
+		// The unique index guarantees one timesheet per week even if the row lock was bypassed.
+		writeConflict(w, ConflictResponse{Error: "the timesheet has just been submitted", Reason: models.ReasonTimesheetSubmitted})
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+)
+
+// uploadedFile returns an uploaded file: the "file" field of a multipart form or, for any other
+// content type, the request body. Reads beyond maxSize bytes fail with *http.MaxBytesError.
+// It writes an error response and returns false if the upload is invalid.
+func uploadedFile(w http.ResponseWriter, r *http.Request, maxSize int64) (io.ReadCloser, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, true
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		if isTooLarge(err) {
+			http.Error(w, "File is too large", http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, "Missing file", http.StatusBadRequest)
+		}
+		log.Printf("Invalid upload: %v", err)
+		return nil, false
+	}
+	return file, true
+}
+
+// isTooLarge reports whether err was caused by an upload exceeding its size limit.
+func isTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
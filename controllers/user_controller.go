@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time-tracker-go/config"
 	"time-tracker-go/models"
+	"time-tracker-go/services"
 
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
@@ -18,11 +21,12 @@ import (
 type UserController struct {
 	DB     *gorm.DB
 	Config config.Config
+	Users  *services.Users
 }
 
-// NewUserController creates a new instance of UserController with the given DB connection and configuration.
-func NewUserController(db *gorm.DB, config config.Config) *UserController {
-	return &UserController{DB: db, Config: config}
+// NewUserController creates a new instance of UserController with the given DB connection, configuration and user service.
+func NewUserController(db *gorm.DB, config config.Config, users *services.Users) *UserController {
+	return &UserController{DB: db, Config: config, Users: users}
 }
 
 type AddUserRequest struct {
@@ -34,38 +38,37 @@ type AddUserRequest struct {
 // @Tags users
 // @Accept json
 // @Produce json
+// @Produce text/csv
 // @Param passportNumber query string false "Passport number"
 // @Param surname query string false "Surname"
 // @Param name query string false "Name"
 // @Param patronymic query string false "Patronymic"
 // @Param address query string false "Address"
+// @Param city query string false "City (substring of the address)"
+// @Param ids query string false "Comma-separated list of user IDs"
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Page size" default(10)
+// @Param format query string false "Response format: json or csv (also selected with Accept: text/csv)"
+// @Param delimiter query string false "CSV field delimiter: a single character, tab or semicolon" default(,)
+// @Param decimal query string false "CSV decimal separator: . or ," default(.)
+// @Param bom query bool false "Prefix CSV output with a UTF-8 byte order mark"
 // @Success 200 {array} models.User
 // @Router /users [get]
 func (uc *UserController) GetUsers(w http.ResponseWriter, r *http.Request) {
-	var users []models.User
-	query := uc.DB
-
 	// Filtration
-	if passportNumber := r.URL.Query().Get("passportNumber"); passportNumber != "" {
-		query = query.Where("passport_number = ?", passportNumber)
-	}
-
-	if surname := r.URL.Query().Get("surname"); surname != "" {
-		query = query.Where("surname = ?", surname)
-	}
-
-	if name := r.URL.Query().Get("name"); name != "" {
-		query = query.Where("name = ?", name)
-	}
-
-	if patronymic := r.URL.Query().Get("patronymic"); patronymic != "" {
-		query = query.Where("patronymic = ?", patronymic)
+	filter, err := userFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("Invalid user filters: %v", err)
+		return
 	}
+	query := uc.DB.Scopes(filter.Scope)
 
-	if address := r.URL.Query().Get("address"); address != "" {
-		query = query.Where("address = ?", address)
+	// CSV exports include all matching users unless a page is requested explicitly.
+	exportCSV := responseFormat(r) == formatCSV
+	if exportCSV && !r.URL.Query().Has("page") && !r.URL.Query().Has("pageSize") {
+		uc.exportUsers(w, r, query)
+		return
 	}
 
 	// Pagination
@@ -79,11 +82,15 @@ func (uc *UserController) GetUsers(w http.ResponseWriter, r *http.Request) {
 	}
 	offset := (page - 1) * pageSize
 
-	query = query.Limit(pageSize).Offset(offset).Find(&users)
+	if exportCSV {
+		uc.exportUsers(w, r, query.Limit(pageSize).Offset(offset))
+		return
+	}
 
-	if query.Error != nil {
-		http.Error(w, query.Error.Error(), http.StatusInternalServerError)
-		log.Printf("Error fetching users: %v", query.Error)
+	users, err := uc.Users.List(filter, services.Page{Number: page, Size: pageSize})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching users: %v", err)
 		return
 	}
 
@@ -110,21 +117,9 @@ func (uc *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var user models.User
-	if err := uc.DB.First(&user, id).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			http.Error(w, "User not found", http.StatusNotFound)
-			log.Printf("User not found with ID %d", id)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error fetching user: %v", err)
-		return
-	}
-
-	if err := uc.DB.Delete(&user).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error deleting user: %v", err)
+	if _, err := uc.Users.Delete(uint(id)); err != nil {
+		writeUserError(w, err)
+		log.Printf("Error deleting user %d: %v", id, err)
 		return
 	}
 
@@ -152,18 +147,6 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var user models.User
-	if err := uc.DB.First(&user, id).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			http.Error(w, "User not found", http.StatusNotFound)
-			log.Printf("User not found with ID %d", id)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error fetching user: %v", err)
-		return
-	}
-
 	var updatedUser models.User
 	if err := json.NewDecoder(r.Body).Decode(&updatedUser); err != nil {
 		http.Error(w, "Invalid request payload", http.StatusBadRequest)
@@ -171,15 +154,10 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user.PassportNumber = updatedUser.PassportNumber
-	user.Surname = updatedUser.Surname
-	user.Name = updatedUser.Name
-	user.Patronymic = updatedUser.Patronymic
-	user.Address = updatedUser.Address
-
-	if err := uc.DB.Save(&user).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error updating user: %v", err)
+	user, err := uc.Users.Update(uint(id), updatedUser)
+	if err != nil {
+		writeUserError(w, err)
+		log.Printf("Error updating user %d: %v", id, err)
 		return
 	}
 
@@ -205,66 +183,94 @@ func (uc *UserController) AddUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	parts := strings.Split(request.PassportNumber, " ")
-	if len(parts) != 2 {
-		http.Error(w, "Invalid passport number format", http.StatusBadRequest)
-		return
-	}
-
-	passportSeries, err := strconv.Atoi(parts[0])
+	user, err := uc.Users.Add(request.PassportNumber)
 	if err != nil {
-		http.Error(w, "Invalid passport series", http.StatusBadRequest)
-		log.Printf("Invalid passport series: %v", err)
+		writeUserError(w, err)
+		log.Printf("Error creating user: %v", err)
 		return
 	}
 
-	passportNumber, err := strconv.Atoi(parts[1])
-	if err != nil {
-		http.Error(w, "Invalid passport number", http.StatusBadRequest)
-		log.Printf("Invalid passport number: %v", err)
-		return
-	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
 
-	apiURL := fmt.Sprintf(uc.Config.ExternalAPIURL+"/info?passportSeries=%d&passportNumber=%d", passportSeries, passportNumber)
-	log.Printf("Calling external API: %s", apiURL)
+	log.Printf("Created user with Passport Number %s", user.PassportNumber)
+}
 
-	resp, err := http.Get(apiURL)
+// exportUsers streams the users selected by the query as CSV, reading them from the database row by row.
+func (uc *UserController) exportUsers(w http.ResponseWriter, r *http.Request, query *gorm.DB) {
+	rows, err := query.Model(&models.User{}).Order("users.id").Rows()
 	if err != nil {
-		http.Error(w, "Failed to fetch user info from external API", http.StatusInternalServerError)
-		log.Printf("Failed to fetch user info from external API: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching users: %v", err)
 		return
 	}
-	defer resp.Body.Close()
+	defer rows.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		http.Error(w, "Failed to fetch user info from external API", http.StatusInternalServerError)
-		log.Printf("Failed to fetch user info from external API. Status code: %d", resp.StatusCode)
+	export, ok := newCSVExport(w, r, "users.csv")
+	if !ok {
 		return
 	}
 
-	var people models.People
-	if err := json.NewDecoder(resp.Body).Decode(&people); err != nil {
-		http.Error(w, "Failed to decode API response", http.StatusInternalServerError)
-		log.Printf("Failed to decode API response: %v", err)
+	count := 0
+	err = export.write(userCSVHeader)
+	for err == nil && rows.Next() {
+		var user models.User
+		if err = uc.DB.ScanRows(rows, &user); err == nil {
+			err = export.write(userCSVRecord(user))
+			count++
+		}
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	if err == nil {
+		err = export.close()
+	}
+	if err != nil {
+		// The response has already started, so the export can only be cut short.
+		log.Printf("Error exporting users: %v", err)
 		return
 	}
 
-	user := models.User{
-		PassportNumber: request.PassportNumber,
-		Surname:        people.Surname,
-		Name:           people.Name,
-		Patronymic:     people.Patronymic,
-		Address:        people.Address,
+	log.Printf("Exported %d users as CSV", count)
+}
+
+// userFilters reads the user filter parameters shared by user listings and team reports:
+// ids, passportNumber, surname, name, patronymic, address and city.
+func userFilters(values url.Values) (services.UserFilter, error) {
+	filter := services.UserFilter{
+		PassportNumber: values.Get("passportNumber"),
+		Surname:        values.Get("surname"),
+		Name:           values.Get("name"),
+		Patronymic:     values.Get("patronymic"),
+		Address:        values.Get("address"),
+		City:           values.Get("city"),
 	}
 
-	if err := uc.DB.Create(&user).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		log.Printf("Error creating user: %v", err)
-		return
+	if idsStr := values.Get("ids"); idsStr != "" {
+		for _, value := range strings.Split(idsStr, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return filter, fmt.Errorf("invalid user ID %q in ids", value)
+			}
+			filter.IDs = append(filter.IDs, uint(id))
+		}
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(user)
+	return filter, nil
+}
 
-	log.Printf("Created user with Passport Number %s", user.PassportNumber)
+// writeUserError maps an error returned by the user service to an HTTP response.
+func writeUserError(w http.ResponseWriter, err error) {
+	var invalidErr *services.InvalidError
+	switch {
+	case errors.As(err, &invalidErr):
+		http.Error(w, invalidErr.Message, http.StatusBadRequest)
+	case errors.Is(err, services.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrExternalAPI):
+		http.Error(w, "Failed to fetch user info from external API", http.StatusInternalServerError)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
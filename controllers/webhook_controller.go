@@ -0,0 +1,420 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time-tracker-go/models"
+	"time-tracker-go/webhooks"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// WebhookController handles HTTP requests related to webhook subscriptions and their deliveries.
+type WebhookController struct {
+	DB       *gorm.DB
+	Webhooks *webhooks.Dispatcher
+}
+
+// NewWebhookController creates a new instance of WebhookController with the given DB connection and dispatcher.
+func NewWebhookController(db *gorm.DB, dispatcher *webhooks.Dispatcher) *WebhookController {
+	return &WebhookController{DB: db, Webhooks: dispatcher}
+}
+
+// WebhookRequest describes the editable fields of a webhook.
+type WebhookRequest struct {
+	URL         string   `json:"url"`         // http or https URL the events are posted to
+	Description string   `json:"description"` // Description of the subscriber
+	Events      []string `json:"events"`      // user.created, user.deleted, task.created, task.started and/or task.ended
+	Secret      string   `json:"secret"`      // Signing secret; a random one is generated when a webhook is added without it, and kept when updated without it
+	Active      *bool    `json:"active"`      // Whether events are delivered (default true)
+}
+
+// WebhookSecretResponse is a webhook together with its signing secret. It is only returned when
+// a webhook is added and when its secret is rotated, so listing webhooks does not reveal secrets.
+type WebhookSecretResponse struct {
+	models.Webhook
+	Secret string `json:"secret"` // Signing secret of the deliveries
+}
+
+// @Summary Get webhooks
+// @Description Retrieves webhook subscriptions with an optional event filter and supports pagination
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param event query string false "Only webhooks subscribed to this event"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} models.Webhook
+// @Router /webhooks [get]
+func (wc *WebhookController) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	var hooks []models.Webhook
+	query := wc.DB
+
+	// Filtration
+	if event := r.URL.Query().Get("event"); event != "" {
+		if !webhooks.IsEvent(event) {
+			http.Error(w, "Invalid event", http.StatusBadRequest)
+			log.Printf("Invalid event: %s", event)
+			return
+		}
+		query = query.Where("events @> ?::jsonb", `["`+event+`"]`)
+	}
+
+	// Pagination
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := query.Order("id").Limit(pageSize).Offset(offset).Find(&hooks).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching webhooks: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hooks)
+
+	log.Printf("Fetched %d webhooks", len(hooks))
+}
+
+// @Summary Get a webhook by ID
+// @Description Retrieves a webhook subscription
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {object} models.Webhook
+// @Router /webhooks/{webhookID} [get]
+func (wc *WebhookController) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := wc.findWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhook)
+
+	log.Printf("Fetched webhook with ID %d", webhook.ID)
+}
+
+// @Summary Add a new webhook
+// @Description Subscribes a URL to events. Every delivery is a POST of a JSON payload signed with the secret of the webhook:
+// @Description the X-Webhook-Signature header is "sha256=" followed by the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body WebhookRequest true "Webhook to be added"
+// @Success 201 {object} WebhookSecretResponse
+// @Router /webhooks [post]
+func (wc *WebhookController) AddWebhook(w http.ResponseWriter, r *http.Request) {
+	var request WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	webhook := models.Webhook{Active: true}
+	if !applyWebhookRequest(w, &webhook, request) {
+		return
+	}
+
+	if err := wc.DB.Create(&webhook).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error creating webhook: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(WebhookSecretResponse{Webhook: webhook, Secret: webhook.Secret})
+
+	log.Printf("Created webhook with ID %d", webhook.ID)
+}
+
+// @Summary Update a webhook by ID
+// @Description Updates the URL, events, secret or state of a webhook. Pending deliveries are sent with the updated settings.
+// @Description The secret is not returned; use POST /webhooks/{webhookID}/secret to generate a new one.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Param webhook body WebhookRequest true "Updated webhook"
+// @Success 200 {object} models.Webhook
+// @Router /webhooks/{webhookID} [put]
+func (wc *WebhookController) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := wc.findWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	var request WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		log.Printf("Invalid request payload: %v", err)
+		return
+	}
+
+	if !applyWebhookRequest(w, &webhook, request) {
+		return
+	}
+
+	if err := wc.DB.Save(&webhook).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error updating webhook: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(webhook)
+
+	log.Printf("Updated webhook with ID %d", webhook.ID)
+}
+
+// @Summary Rotate the secret of a webhook
+// @Description Replaces the signing secret of a webhook with a new random one and returns it. Pending deliveries are signed with the new secret.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {object} WebhookSecretResponse
+// @Router /webhooks/{webhookID}/secret [post]
+func (wc *WebhookController) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := wc.findWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error generating webhook secret: %v", err)
+		return
+	}
+
+	if err := wc.DB.Model(&webhook).Update("secret", secret).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error rotating webhook secret: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(WebhookSecretResponse{Webhook: webhook, Secret: secret})
+
+	log.Printf("Rotated the secret of webhook with ID %d", webhook.ID)
+}
+
+// @Summary Delete a webhook by ID
+// @Description Deletes a webhook subscription. Its pending deliveries fail on their next attempt; the delivery log is kept.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {object} map[string]string
+// @Router /webhooks/{webhookID} [delete]
+func (wc *WebhookController) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := wc.findWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	if err := wc.DB.Delete(&webhook).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error deleting webhook: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Webhook deleted successfully"})
+
+	log.Printf("Deleted webhook with ID %d", webhook.ID)
+}
+
+// @Summary Get deliveries of a webhook
+// @Description Retrieves the delivery log of a webhook, newest first, with an optional status filter and supports pagination
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Param status query string false "Delivery status: pending, succeeded or failed"
+// @Param event query string false "Event name"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Page size" default(10)
+// @Success 200 {array} models.WebhookDelivery
+// @Router /webhooks/{webhookID}/deliveries [get]
+func (wc *WebhookController) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := wc.findWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	var deliveries []models.WebhookDelivery
+	query := wc.DB.Where("webhook_id = ?", webhook.ID)
+
+	// Filtration
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		status := models.WebhookDeliveryStatus(statusStr)
+		if !status.IsValid() {
+			http.Error(w, "Invalid status", http.StatusBadRequest)
+			log.Printf("Invalid status: %s", statusStr)
+			return
+		}
+		query = query.Where("status = ?", status)
+	}
+
+	if event := r.URL.Query().Get("event"); event != "" {
+		query = query.Where("event = ?", event)
+	}
+
+	// Pagination
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	if err := query.Order("id DESC").Limit(pageSize).Offset(offset).Find(&deliveries).Error; err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching webhook deliveries: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+
+	log.Printf("Fetched %d deliveries of webhook %d", len(deliveries), webhook.ID)
+}
+
+// @Summary Redeliver a webhook delivery
+// @Description Queues the payload of a delivery again as a new delivery with a fresh set of attempts.
+// @Description The payload keeps its event ID, so receivers can recognise events they have already processed.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Param deliveryID path int true "Delivery ID"
+// @Success 202 {object} models.WebhookDelivery
+// @Router /webhooks/{webhookID}/deliveries/{deliveryID}/redeliver [post]
+func (wc *WebhookController) RedeliverDelivery(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := wc.findWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := strconv.Atoi(mux.Vars(r)["deliveryID"])
+	if err != nil {
+		http.Error(w, "Invalid delivery ID", http.StatusBadRequest)
+		log.Printf("Invalid delivery ID: %v", err)
+		return
+	}
+
+	var delivery models.WebhookDelivery
+	if err := wc.DB.Where("webhook_id = ?", webhook.ID).First(&delivery, deliveryID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "Delivery not found", http.StatusNotFound)
+			log.Printf("Delivery %d not found for webhook %d", deliveryID, webhook.ID)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching webhook delivery: %v", err)
+		return
+	}
+
+	redelivery, err := wc.Webhooks.Redeliver(delivery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error redelivering webhook delivery: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(redelivery)
+
+	log.Printf("Queued delivery %d of webhook %d again as delivery %d", delivery.ID, webhook.ID, redelivery.ID)
+}
+
+// applyWebhookRequest validates the request and copies it into the webhook.
+// It writes an error response and returns false if the request is invalid.
+func applyWebhookRequest(w http.ResponseWriter, webhook *models.Webhook, request WebhookRequest) bool {
+	target, err := url.Parse(request.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		http.Error(w, "URL must be an absolute http or https URL", http.StatusBadRequest)
+		return false
+	}
+
+	if len(request.Events) == 0 {
+		http.Error(w, "At least one event is required", http.StatusBadRequest)
+		return false
+	}
+	events := make([]string, 0, len(request.Events))
+	seen := map[string]bool{}
+	for _, event := range request.Events {
+		if !webhooks.IsEvent(event) {
+			http.Error(w, "Unknown event: "+event, http.StatusBadRequest)
+			return false
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	if request.Secret != "" {
+		webhook.Secret = request.Secret
+	}
+	if webhook.Secret == "" {
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			log.Printf("Error generating webhook secret: %v", err)
+			return false
+		}
+		webhook.Secret = secret
+	}
+
+	webhook.URL = request.URL
+	webhook.Description = request.Description
+	webhook.Events = events
+	if request.Active != nil {
+		webhook.Active = *request.Active
+	}
+	return true
+}
+
+// findWebhook loads the webhook identified by the "webhookID" route parameter.
+// It writes an error response and returns false if the webhook cannot be loaded.
+func (wc *WebhookController) findWebhook(w http.ResponseWriter, r *http.Request) (models.Webhook, bool) {
+	var webhook models.Webhook
+
+	id, err := strconv.Atoi(mux.Vars(r)["webhookID"])
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		log.Printf("Invalid webhook ID: %v", err)
+		return webhook, false
+	}
+
+	if err := wc.DB.First(&webhook, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			log.Printf("Webhook not found with ID %d", id)
+			return webhook, false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error fetching webhook: %v", err)
+		return webhook, false
+	}
+	return webhook, true
+}
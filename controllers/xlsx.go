@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time-tracker-go/exports"
+	"time-tracker-go/reports"
+)
+
+// exportWorkbook writes the timesheets as an XLSX workbook attachment.
+func exportWorkbook(w http.ResponseWriter, filename string, period reports.Period, timesheets []exports.UserTimesheet) {
+	w.Header().Set("Content-Type", xlsxMediaType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if err := exports.WriteTimesheetWorkbook(w, period, timesheets); err != nil {
+		// The response may have already started, so the export can only be cut short.
+		log.Printf("Error exporting %s: %v", filename, err)
+		return
+	}
+	log.Printf("Exported %s with %d user sheets", filename, len(timesheets))
+}
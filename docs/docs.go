@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/info": {
+        "/budget-events": {
             "get": {
-                "description": "Retrieves information about a person from the database based on passport series and number",
+                "description": "Retrieves the events recorded when ending a task pushed it over its estimate or its project over budget, newest first.\nSupports kind, acknowledgement, project and user filters and pagination.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,22 +25,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "people"
+                    "budgets"
                 ],
-                "summary": "Get information about a person by passport series and number",
+                "summary": "Get budget events",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event kind: task_estimate_exceeded or project_budget_exceeded",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only acknowledged (true) or new (false) events",
+                        "name": "acknowledged",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Passport series",
-                        "name": "passportSeries",
-                        "in": "query",
-                        "required": true
+                        "description": "Project ID",
+                        "name": "project_id",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Passport number",
-                        "name": "passportNumber",
-                        "in": "query",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BudgetEvent"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/budget-events/{eventID}/acknowledge": {
+            "put": {
+                "description": "Marks a budget event as seen by a manager. Acknowledging an event again keeps the original time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Acknowledge a budget event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Budget event ID",
+                        "name": "eventID",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -48,15 +107,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.People"
+                            "$ref": "#/definitions/models.BudgetEvent"
                         }
                     }
                 }
             }
         },
-        "/users": {
+        "/clients": {
             "get": {
-                "description": "Retrieves users based on optional filters and supports pagination",
+                "description": "Retrieves clients with optional name filter and supports pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,40 +123,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "clients"
                 ],
-                "summary": "Get users with optional filters and pagination",
+                "summary": "Get clients",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Passport number",
-                        "name": "passportNumber",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Surname",
-                        "name": "surname",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "Name",
                         "name": "name",
                         "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "Patronymic",
-                        "name": "patronymic",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Address",
-                        "name": "address",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -119,14 +154,14 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.User"
+                                "$ref": "#/definitions/models.Client"
                             }
                         }
                     }
                 }
             },
             "post": {
-                "description": "Adds a new user based on the provided passport number, fetching details from an external API",
+                "description": "Adds a new client",
                 "consumes": [
                     "application/json"
                 ],
@@ -134,17 +169,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "clients"
                 ],
-                "summary": "Add a new user",
+                "summary": "Add a new client",
                 "parameters": [
                     {
-                        "description": "Request body with passport number",
-                        "name": "request",
+                        "description": "Client to be added",
+                        "name": "client",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.AddUserRequest"
+                            "$ref": "#/definitions/controllers.ClientRequest"
                         }
                     }
                 ],
@@ -152,15 +187,15 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Client"
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
-            "put": {
-                "description": "Updates a user's details by their ID",
+        "/clients/{clientID}": {
+            "get": {
+                "description": "Retrieves a client together with its projects",
                 "consumes": [
                     "application/json"
                 ],
@@ -168,38 +203,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "clients"
                 ],
-                "summary": "Update a user by ID",
+                "summary": "Get a client by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Client ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated user object",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.User"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Client"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes a user by their ID",
+            "put": {
+                "description": "Updates a client's details by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -207,34 +233,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "clients"
                 ],
-                "summary": "Delete a user by ID",
+                "summary": "Update a client by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Client ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated client",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ClientRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Client"
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}/tasks": {
-            "get": {
-                "description": "Retrieves time entries for a user within a specified time period",
+            },
+            "delete": {
+                "description": "Deletes a client by its ID. Clients that still have projects cannot be deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -242,46 +272,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "clients"
                 ],
-                "summary": "Get time entries by user ID and period",
+                "summary": "Delete a client by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Client ID",
+                        "name": "clientID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (format: 2006-01-02T15:04:05)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (format: 2006-01-02T15:04:05)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Task"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/graphql": {
             "post": {
-                "description": "Adds a new task for a user",
+                "description": "Executes a query or mutation against the GraphQL schema of users, tasks and reports.\nA user can be fetched with their tasks and period totals in one request; tasks and totals of a list of users\nare loaded with one database query per field. Errors are returned in the \"errors\" array with status 200,\nwith a machine-readable extensions.code and, for conflicts, the reason of the REST API.",
                 "consumes": [
                     "application/json"
                 ],
@@ -289,192 +313,4979 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "graphql"
                 ],
-                "summary": "Add a task for a user",
+                "summary": "Execute a GraphQL query or mutation",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Task object to be added",
-                        "name": "task",
+                        "description": "GraphQL request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/controllers.GraphQLRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/controllers.GraphQLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/users/{id}/tasks/{taskID}/end": {
+        "/imports/clockify": {
             "post": {
-                "description": "Ends a task for a user",
+                "description": "Imports the detailed report of Clockify exported as CSV or JSON.\nEntries become done tasks with a single work interval; descriptions, billable flags, projects, clients and tags are carried over.\nUsers are matched by name unless user_id is given; projects by name and client; tags by name.\nEntries of unknown users are skipped, unknown projects and tags are left out unless create_missing=true; the response lists them under \"unmatched\".\nEntries already imported, running entries, duplicates, entries overlapping tracked time and entries in approved weeks are skipped. The import runs in one transaction.",
                 "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "imports"
                 ],
-                "summary": "End a task for a user",
+                "summary": "Import a Clockify export",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "file",
+                        "description": "Clockify export",
+                        "name": "file",
+                        "in": "formData"
                     },
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
-                        "in": "path",
-                        "required": true
+                        "description": "User to import all entries for",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create missing clients, projects and tags",
+                        "name": "create_missing",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time zone of CSV exports, e.g. Europe/Moscow (default: UTC)",
+                        "name": "time_zone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go layout of dates in CSV exports (default: 2006-01-02, 01/02/2006 or 02.01.2006)",
+                        "name": "date_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "CSV field delimiter: a single character, tab or semicolon",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the import without changing anything",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Dry run",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/imports.TrackerResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/imports.TrackerResult"
                         }
                     }
                 }
             }
         },
-        "/users/{id}/tasks/{taskID}/start": {
+        "/imports/timesheets": {
             "post": {
-                "description": "Starts a task for a user",
+                "description": "Imports historical time from a CSV file with a header row; every row becomes a done task with a single work interval.\nColumns are matched to the fields passport_number, user, surname, name, patronymic, description, project, tags, billable, date, start, end and duration by name;\ncolumns with other names are mapped with map=field=Column. Users are matched by passport number or by name.\nRows are validated (user, project and tags exist, valid times in the past, non-negative durations, no overlaps with other rows or tracked time)\nand the import is all-or-nothing: if any row is invalid, nothing is imported and 422 lists the errors by row.\nThe file is sent as the \"file\" field of a multipart form or as the raw request body.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data",
+                    "text/csv"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "imports"
                 ],
-                "summary": "Start a task for a user",
+                "summary": "Import a CSV timesheet",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "file",
+                        "description": "CSV timesheet",
+                        "name": "file",
+                        "in": "formData"
                     },
                     {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.Task"
-                        }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "config.Config": {
-            "type": "object",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Column mapping field=Column, repeated for each field",
+                        "name": "map",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "Field delimiter: a single character, tab or semicolon",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go layout of start and end times (default: common ISO and Russian formats)",
+                        "name": "time_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time zone of times without an offset, e.g. Europe/Moscow (default: UTC)",
+                        "name": "time_zone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "minutes",
+                        "description": "Unit of durations without a colon: minutes or hours",
+                        "name": "duration_unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the timesheet without importing it",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run",
+                        "schema": {
+                            "$ref": "#/definitions/imports.TimesheetResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/imports.TimesheetResult"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/imports.TimesheetResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/toggl": {
+            "post": {
+                "description": "Imports the detailed report of Toggl Track exported as CSV or JSON (Reports API v2), or time entries of the API v9 requested with meta=true.\nEntries become done tasks with a single work interval; descriptions, billable flags, projects, clients and tags are carried over.\nUsers are matched by name unless user_id is given; projects by name and client; tags by name.\nEntries of unknown users are skipped, unknown projects and tags are left out unless create_missing=true; the response lists them under \"unmatched\".\nEntries already imported, running entries, duplicates, entries overlapping tracked time and entries in approved weeks are skipped. The import runs in one transaction.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Import a Toggl Track export",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Toggl Track export",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User to import all entries for",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create missing clients, projects and tags",
+                        "name": "create_missing",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time zone of CSV exports, e.g. Europe/Moscow (default: UTC)",
+                        "name": "time_zone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Go layout of dates in CSV exports (default: 2006-01-02, 01/02/2006 or 02.01.2006)",
+                        "name": "date_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "CSV field delimiter: a single character, tab or semicolon",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the import without changing anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run",
+                        "schema": {
+                            "$ref": "#/definitions/imports.TrackerResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/imports.TrackerResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/info": {
+            "get": {
+                "description": "Retrieves information about a person from the database based on passport series and number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Get information about a person by passport series and number",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Passport series",
+                        "name": "passportSeries",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Passport number",
+                        "name": "passportNumber",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.People"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices": {
+            "get": {
+                "description": "Retrieves invoices with optional status, client and user filters and supports pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "Get invoices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice status: draft, sent or paid",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Invoice"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a draft invoice from the uninvoiced billable time of a client and/or user. Closed intervals starting within [startDate, endDate) are billed at the rate in effect at their start, aggregated into one line per task and rate, and marked as invoiced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "Generate an invoice",
+                "parameters": [
+                    {
+                        "description": "Client, user and period to bill",
+                        "name": "invoice",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.InvoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{invoiceID}": {
+            "get": {
+                "description": "Retrieves an invoice as JSON, or as a document rendered to HTML or PDF. The format is selected with the format parameter or the Accept header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/html",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "Get an invoice by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoiceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json, html or pdf",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a draft invoice and releases its time so it can be invoiced again. Sent and paid invoices cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "Delete a draft invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoiceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{invoiceID}/pay": {
+            "put": {
+                "description": "Marks a sent invoice as paid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "Mark an invoice as paid",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoiceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{invoiceID}/send": {
+            "put": {
+                "description": "Marks a draft invoice as sent and assigns it the next number of the year",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "Send an invoice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "invoiceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "description": "Retrieves projects with optional client and name filters and supports pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Get projects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Project"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new project, optionally for a client",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Add a new project",
+                "parameters": [
+                    {
+                        "description": "Project to be added",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{projectID}": {
+            "get": {
+                "description": "Retrieves a project together with its client",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Get a project by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a project's details by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Update a project by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated project",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a project by its ID. Tasks of the project keep their reference for reporting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Delete a project by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rates": {
+            "get": {
+                "description": "Retrieves the rate history ordered by effective date, optionally for a user or a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get hourly rates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "project_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Rate"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a rate effective from the given time. Earlier rates stay in the history and keep applying to time tracked before it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Add an hourly rate",
+                "parameters": [
+                    {
+                        "description": "Rate to be added",
+                        "name": "rate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Rate"
+                        }
+                    }
+                }
+            }
+        },
+        "/rates/{rateID}": {
+            "delete": {
+                "description": "Deletes a rate from the history. Time it applied to falls back to the previous rate with the same scope.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Delete an hourly rate by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rate ID",
+                        "name": "rateID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/overruns": {
+            "get": {
+                "description": "Lists tasks that have exceeded their estimates and projects that have exceeded their hour budgets,\nor have spent at least the threshold share of them (level at_risk). Running tasks are counted up to now.\nDone tasks are listed only once they exceed their estimates; cancelled tasks are not listed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get the budget overrun report",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Share of the estimate or budget in percent from which tasks and projects are at risk (default: OVERRUN_THRESHOLD, 80)",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tasks of this user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only projects of this client and their tasks",
+                        "name": "client_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/reports.OverrunReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/projects": {
+            "get": {
+                "description": "Sums the time spent on tasks within a period by project or by client, with task and user counts.\nUsers are selected with the same filters as the user listing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get a project report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date, inclusive (format: 2006-01-02T15:04:05)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, exclusive (format: 2006-01-02T15:04:05)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "project",
+                        "description": "Grouping: project or client",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of project IDs",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of client IDs",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, any of which a task must have",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, all of which a task must have",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, none of which a task may have",
+                        "name": "tags_none",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only billable (true) or non-billable (false) tasks",
+                        "name": "billable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of user IDs",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Passport number",
+                        "name": "passportNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Surname",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patronymic",
+                        "name": "patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Address",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City (substring of the address)",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json or csv (also selected with Accept: text/csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "CSV field delimiter: a single character, tab or semicolon",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ".",
+                        "description": "CSV decimal separator: . or ,",
+                        "name": "decimal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Prefix CSV output with a UTF-8 byte order mark",
+                        "name": "bom",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/reports.ProjectReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/team": {
+            "get": {
+                "description": "Ranks users by the time they spent on tasks within a period, with task counts and average task length.\nUsers are selected with the same filters as the user listing.\nWith format=xlsx the report is exported as an Excel workbook with a summary sheet and a timesheet sheet per user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get a team report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date, inclusive (format: 2006-01-02T15:04:05)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, exclusive (format: 2006-01-02T15:04:05)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "Bucket size of the xlsx timesheet sheets: day, week or month",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of user IDs",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Passport number",
+                        "name": "passportNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Surname",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patronymic",
+                        "name": "patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Address",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City (substring of the address)",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of project IDs",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of client IDs",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, any of which a task must have",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, all of which a task must have",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, none of which a task may have",
+                        "name": "tags_none",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only billable (true) or non-billable (false) tasks",
+                        "name": "billable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json, csv or xlsx (also selected with the Accept header)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "CSV field delimiter: a single character, tab or semicolon",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ".",
+                        "description": "CSV decimal separator: . or ,",
+                        "name": "decimal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Prefix CSV output with a UTF-8 byte order mark",
+                        "name": "bom",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/reports.TeamReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "description": "Opens a Server-Sent Events stream of the tasks of all users being added, started, paused, ended and cancelled.\nThe stream starts with a \"snapshot\" event listing the running tasks. Every event has an ID; a client that reconnects\nwith the Last-Event-ID header (or the lastEventId query parameter) receives the events it missed, or a new snapshot\nif they are no longer available. Idle streams receive a heartbeat comment every 15 seconds.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream timer changes of the team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received, for clients that cannot set headers",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/live.Event"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Retrieves the tag catalogue ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tag"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new tag to the catalogue. Names are stored in lower case and must be unique.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Add a new tag",
+                "parameters": [
+                    {
+                        "description": "Tag to be added",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tagID}": {
+            "put": {
+                "description": "Renames or recolors a tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update a tag by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a tag from the catalogue and removes it from all tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/timesheets": {
+            "get": {
+                "description": "Retrieves submitted, approved and rejected weekly timesheets with optional status and user filters and supports pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timesheets"
+                ],
+                "summary": "Get timesheets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Timesheet status: submitted, approved or rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TimesheetApproval"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Retrieves users based on optional filters and supports pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get users with optional filters and pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Passport number",
+                        "name": "passportNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Surname",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patronymic",
+                        "name": "patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Address",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City (substring of the address)",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of user IDs",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json or csv (also selected with Accept: text/csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "CSV field delimiter: a single character, tab or semicolon",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ".",
+                        "description": "CSV decimal separator: . or ,",
+                        "name": "decimal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Prefix CSV output with a UTF-8 byte order mark",
+                        "name": "bom",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new user based on the provided passport number, fetching details from an external API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Add a new user",
+                "parameters": [
+                    {
+                        "description": "Request body with passport number",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.AddUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "put": {
+                "description": "Updates a user's details by their ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated user object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a user by their ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/calendar.ics": {
+            "get": {
+                "description": "Returns the work intervals of a user as an iCalendar feed with one event per interval.\nEvents carry the task description, ID, project, client and tags; running intervals end now.\nWithout start_date and end_date the feed covers the last 90 days.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the calendar feed of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date, inclusive (format: 2006-01-02T15:04:05)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, exclusive (format: 2006-01-02T15:04:05)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of project IDs",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of client IDs",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, any of which a task must have",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, all of which a task must have",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, none of which a task may have",
+                        "name": "tags_none",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only billable (true) or non-billable (false) tasks",
+                        "name": "billable",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/calendar/import": {
+            "post": {
+                "description": "Creates a done task with a single work interval for every finished event of an uploaded iCalendar file.\nThe file is sent as the \"file\" field of a multipart form or as the raw request body.\nEvents already imported for the user, all-day, cancelled and unfinished events, and events exported by this service are skipped.\nRecurring events are imported as their occurrences within the period, or up to now; events with unsupported recurrence rules are skipped. Event categories are matched to existing tags by name.\nWith dry_run=true nothing is created and the response previews the import.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/calendar"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Import calendar events as tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "iCalendar file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the import without creating tasks",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Project to assign the imported tasks to",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only import events starting at or after (format: 2006-01-02T15:04:05)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only import events starting before (format: 2006-01-02T15:04:05)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time zone of event times without one, e.g. Europe/Moscow (default: UTC)",
+                        "name": "time_zone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run",
+                        "schema": {
+                            "$ref": "#/definitions/imports.CalendarResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/imports.CalendarResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reports/timesheet": {
+            "get": {
+                "description": "Aggregates the time a user spent on tasks within a period by day, ISO week or month,\nwith per-bucket and per-task totals. Running tasks are counted up to now.\nWith format=xlsx the timesheet is exported as an Excel workbook with a row per bucket.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get a timesheet of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date, inclusive (format: 2006-01-02T15:04:05)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, exclusive (format: 2006-01-02T15:04:05)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "Bucket size: day, week or month",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of project IDs",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of client IDs",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, any of which a task must have",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, all of which a task must have",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, none of which a task may have",
+                        "name": "tags_none",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only billable (true) or non-billable (false) tasks",
+                        "name": "billable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json, csv or xlsx (also selected with the Accept header)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "CSV field delimiter: a single character, tab or semicolon",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ".",
+                        "description": "CSV decimal separator: . or ,",
+                        "name": "decimal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Prefix CSV output with a UTF-8 byte order mark",
+                        "name": "bom",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/reports.Timesheet"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/stream": {
+            "get": {
+                "description": "Opens a Server-Sent Events stream of the tasks of a user being added, started, paused, ended and cancelled.\nIt behaves like the team stream, limited to the user.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream timer changes of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received, for clients that cannot set headers",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/live.Event"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tasks": {
+            "get": {
+                "description": "Retrieves tasks of a user with optional status and creation date filters and supports pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get tasks of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of statuses (created, running, paused, done, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (format: 2006-01-02T15:04:05)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before (format: 2006-01-02T15:04:05)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of project IDs",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of client IDs",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, any of which a task must have",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, all of which a task must have",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, none of which a task may have",
+                        "name": "tags_none",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only billable (true) or non-billable (false) tasks",
+                        "name": "billable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "List soft-deleted tasks instead of active ones",
+                        "name": "deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Task"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new task for a user, optionally with an estimated duration in minutes. A task with both start and end time is a manual entry, which must end after it starts and cannot be added to an approved week.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Add a task for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Task object to be added. Tags are referenced by ID.",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid task",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tasks/{taskID}": {
+            "get": {
+                "description": "Retrieves a single task of a user together with its work intervals",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get a task of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft-deletes a task of a user. Running tasks must be paused or ended first; tasks with time in an approved week\nor on an invoice that has not been deleted cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete a task of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates editable fields of a task. Status changes go through the start/pause/resume/end/cancel endpoints.\nTasks with time in an approved week cannot be edited. The billable flag and project of a task\nwith time on an invoice cannot be changed until the invoice is deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Update a task of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UpdateTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tasks/{taskID}/cancel": {
+            "put": {
+                "description": "Cancels a task that is not finished yet, closing its running work interval if there is one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Cancel a task for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tasks/{taskID}/end": {
+            "put": {
+                "description": "Finishes a running or paused task for a user, closing its running work interval if there is one.\nIf the task has exceeded its estimate or its project its budget, a budget event is recorded for managers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "End a task for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tasks/{taskID}/pause": {
+            "put": {
+                "description": "Pauses a running task for a user by closing its current work interval",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Pause a task for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tasks/{taskID}/restore": {
+            "put": {
+                "description": "Restores a soft-deleted task of a user, unless it has time in an approved week",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Restore a deleted task of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tasks/{taskID}/resume": {
+            "put": {
+                "description": "Resumes a paused task for a user by opening a new work interval. A user can have only one running task.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Resume a task for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "End the currently running task of the user first",
+                        "name": "switch",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tasks/{taskID}/start": {
+            "put": {
+                "description": "Starts a created task for a user by opening its first work interval. A user can have only one running task.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Start a task for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "End the currently running task of the user first",
+                        "name": "switch",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LockedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/time-entries": {
+            "get": {
+                "description": "Retrieves tasks of a user whose work intervals overlap the specified period.\nIntervals are clipped to the period, running tasks are counted up to now.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get time entries by user ID and period",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date, inclusive (format: 2006-01-02T15:04:05)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, exclusive (format: 2006-01-02T15:04:05)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of project IDs",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of client IDs",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, any of which a task must have",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, all of which a task must have",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, none of which a task may have",
+                        "name": "tags_none",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only billable (true) or non-billable (false) tasks",
+                        "name": "billable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json or csv (also selected with Accept: text/csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "CSV field delimiter: a single character, tab or semicolon",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": ".",
+                        "description": "CSV decimal separator: . or ,",
+                        "name": "decimal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Prefix CSV output with a UTF-8 byte order mark",
+                        "name": "bom",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/reports.TimeEntries"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/timesheets/{week}": {
+            "get": {
+                "description": "Retrieves the approval state of a week of a user together with the time tracked in it. A week that has never been submitted has an empty status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timesheets"
+                ],
+                "summary": "Get the timesheet of a user for a week",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Monday the week starts on (format: 2006-01-02)",
+                        "name": "week",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timesheets.Timesheet"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/timesheets/{week}/approve": {
+            "put": {
+                "description": "Approves a submitted week of a user. The time of an approved week is locked: tasks with time in it cannot be added, started, ended, edited or deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timesheets"
+                ],
+                "summary": "Approve a timesheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Monday the week starts on (format: 2006-01-02)",
+                        "name": "week",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reviewer and optional comment",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TimesheetApproval"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/timesheets/{week}/reject": {
+            "put": {
+                "description": "Returns a submitted week to the user with a comment explaining what to correct",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timesheets"
+                ],
+                "summary": "Reject a timesheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Monday the week starts on (format: 2006-01-02)",
+                        "name": "week",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reviewer and comment",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TimesheetApproval"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/timesheets/{week}/submit": {
+            "put": {
+                "description": "Submits a week of a user for approval, recording the total time tracked in it. Rejected weeks can be submitted again after corrections.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timesheets"
+                ],
+                "summary": "Submit a timesheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Monday the week starts on (format: 2006-01-02)",
+                        "name": "week",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TimesheetApproval"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Retrieves webhook subscriptions with an optional event filter and supports pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only webhooks subscribed to this event",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Webhook"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribes a URL to events. Every delivery is a POST of a JSON payload signed with the secret of the webhook:\nthe X-Webhook-Signature header is \"sha256=\" followed by the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Add a new webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook to be added",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.WebhookSecretResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}": {
+            "get": {
+                "description": "Retrieves a webhook subscription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates the URL, events, secret or state of a webhook. Pending deliveries are sent with the updated settings.\nThe secret is not returned; use POST /webhooks/{webhookID}/secret to generate a new one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a webhook subscription. Its pending deliveries fail on their next attempt; the delivery log is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}/deliveries": {
+            "get": {
+                "description": "Retrieves the delivery log of a webhook, newest first, with an optional status filter and supports pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get deliveries of a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery status: pending, succeeded or failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event name",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver": {
+            "post": {
+                "description": "Queues the payload of a delivery again as a new delivery with a fresh set of attempts.\nThe payload keeps its event ID, so receivers can recognise events they have already processed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}/secret": {
+            "post": {
+                "description": "Replaces the signing secret of a webhook with a new random one and returns it. Pending deliveries are signed with the new secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Rotate the secret of a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.WebhookSecretResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "config.Config": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency of rates and invoices (ISO 4217 code)",
+                    "type": "string"
+                },
+                "databaseURL": {
+                    "type": "string"
+                },
+                "environment": {
+                    "description": "Environment of the database, e.g. \"dev\" or \"production\"",
+                    "type": "string"
+                },
+                "externalAPIURL": {
+                    "type": "string"
+                },
+                "grpcport": {
+                    "description": "Port of the gRPC server",
+                    "type": "string"
+                },
+                "overrunThreshold": {
+                    "description": "Share of an estimate or budget in percent from which overruns are reported as at risk",
+                    "type": "number"
+                },
+                "vatrate": {
+                    "description": "Default VAT rate of invoices in percent",
+                    "type": "number"
+                }
+            }
+        },
+        "controllers.AddUserRequest": {
+            "type": "object",
+            "properties": {
+                "passportNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ClientRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ConflictResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Rejected action, if applicable",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaskAction"
+                        }
+                    ]
+                },
+                "error": {
+                    "description": "Human-readable description",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Machine-readable reason",
+                    "type": "string"
+                },
+                "runningTaskID": {
+                    "description": "Task that is already running, if applicable",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Current task status, if applicable",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaskStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "controllers.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "description": "Operation to execute if the document has several",
+                    "type": "string"
+                },
+                "query": {
+                    "description": "Query or mutation document",
+                    "type": "string"
+                },
+                "variables": {
+                    "description": "Values of the variables of the operation",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "controllers.GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Result of the operation"
+                },
+                "errors": {
+                    "description": "Errors with their path and extensions.code: BAD_USER_INPUT, NOT_FOUND, CONFLICT, UNAVAILABLE or INTERNAL",
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "controllers.InvoiceRequest": {
+            "type": "object",
+            "properties": {
+                "clientID": {
+                    "description": "Bill time on projects of this client",
+                    "type": "integer"
+                },
+                "endDate": {
+                    "description": "End of the billed period (exclusive)",
+                    "type": "string"
+                },
+                "startDate": {
+                    "description": "Start of the billed period (inclusive)",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "Bill time of this user",
+                    "type": "integer"
+                },
+                "vatRate": {
+                    "description": "VAT rate in percent (the configured rate if omitted)",
+                    "type": "number"
+                }
+            }
+        },
+        "controllers.LockedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Human-readable description",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Machine-readable reason",
+                    "type": "string"
+                },
+                "weekStart": {
+                    "description": "Monday the locked week starts on",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ProjectRequest": {
+            "type": "object",
+            "properties": {
+                "budgetHours": {
+                    "description": "Budget in hours (omit for no budget)",
+                    "type": "number"
+                },
+                "clientID": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.RateRequest": {
+            "type": "object",
+            "properties": {
+                "effectiveFrom": {
+                    "description": "Time from which the rate applies",
+                    "type": "string"
+                },
+                "hourlyRate": {
+                    "description": "Amount charged per hour",
+                    "type": "number"
+                },
+                "projectID": {
+                    "description": "nil for all projects",
+                    "type": "integer"
+                },
+                "userID": {
+                    "description": "nil for all users",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.ReviewRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Comment for the user; required to reject a timesheet",
+                    "type": "string"
+                },
+                "reviewerID": {
+                    "description": "ID of the reviewing user, who cannot be the owner of the timesheet",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.TagRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.UpdateTaskRequest": {
+            "type": "object",
+            "properties": {
+                "billable": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "estimatedDuration": {
+                    "description": "Estimated duration in minutes; 0 removes the estimate",
+                    "type": "integer"
+                },
+                "projectID": {
+                    "description": "0 removes the task from its project",
+                    "type": "integer"
+                },
+                "tagIDs": {
+                    "description": "Replaces the tags of the task",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "controllers.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Whether events are delivered (default true)",
+                    "type": "boolean"
+                },
+                "description": {
+                    "description": "Description of the subscriber",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "user.created, user.deleted, task.created, task.started and/or task.ended",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Signing secret; a random one is generated when a webhook is added without it, and kept when updated without it",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "http or https URL the events are posted to",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.WebhookSecretResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Whether events are delivered to the webhook",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "Description of the subscriber",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Names of the events the webhook is subscribed to",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Signing secret of the deliveries",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL the events are posted to",
+                    "type": "string"
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "imports.CalendarResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Number of tasks created (or that would be created in a dry run)",
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "description": "Whether the import was a preview only",
+                    "type": "boolean"
+                },
+                "skipped": {
+                    "description": "Events that were not imported",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.SkippedEvent"
+                    }
+                },
+                "tasks": {
+                    "description": "Created tasks; in a dry run they have no IDs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Task"
+                    }
+                }
+            }
+        },
+        "imports.Mapping": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.MappingItem"
+                    }
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.MappingItem"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.MappingItem"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.MappingItem"
+                    }
+                }
+            }
+        },
+        "imports.MappingItem": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "description": "Client of a project",
+                    "type": "string"
+                },
+                "entries": {
+                    "description": "Number of entries referring to the item",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name in the export",
+                    "type": "string"
+                }
+            }
+        },
+        "imports.RowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "description": "Column the problem was found in, if any",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Description of the problem",
+                    "type": "string"
+                },
+                "row": {
+                    "description": "Line of the row in the file, the header being line 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "imports.SkippedEntry": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the entry in the time tracker",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Machine-readable reason the entry was skipped",
+                    "type": "string"
+                },
+                "row": {
+                    "description": "Line in a CSV export or position in a JSON export",
+                    "type": "integer"
+                }
+            }
+        },
+        "imports.SkippedEvent": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Machine-readable reason the event was skipped",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "Start of the event",
+                    "type": "string"
+                },
+                "summary": {
+                    "description": "Title of the event",
+                    "type": "string"
+                },
+                "uid": {
+                    "description": "UID of the event",
+                    "type": "string"
+                }
+            }
+        },
+        "imports.TimesheetResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Number of tasks created (or that would be created in a dry run)",
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "description": "Whether the import was a validation only",
+                    "type": "boolean"
+                },
+                "errors": {
+                    "description": "Problems found; if there are any, nothing is imported",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.RowError"
+                    }
+                },
+                "rows": {
+                    "description": "Number of data rows read",
+                    "type": "integer"
+                },
+                "users": {
+                    "description": "Number of users the tasks belong to",
+                    "type": "integer"
+                }
+            }
+        },
+        "imports.TrackerResult": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Clients, projects and tags created (or that would be created) with CreateMissing",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/imports.Mapping"
+                        }
+                    ]
+                },
+                "created": {
+                    "description": "Number of tasks created (or that would be created in a dry run)",
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "description": "Whether the import was a preview only",
+                    "type": "boolean"
+                },
+                "entries": {
+                    "description": "Number of entries read",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "Entries that were not imported",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.SkippedEntry"
+                    }
+                },
+                "source": {
+                    "description": "Time tracker the entries come from",
+                    "type": "string"
+                },
+                "unmatched": {
+                    "description": "Items that were not found; entries of unknown users are skipped, other items are left out of the tasks",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/imports.Mapping"
+                        }
+                    ]
+                }
+            }
+        },
+        "live.Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "The task the event is about"
+                },
+                "id": {
+                    "description": "Position of the event in the stream; IDs increase across restarts",
+                    "type": "integer"
+                },
+                "time": {
+                    "description": "Time the event occurred",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type of the event",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "User whose timer changed",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BudgetEvent": {
+            "type": "object",
+            "properties": {
+                "acknowledgedAt": {
+                    "description": "Time a manager acknowledged the event (nil while it is new)",
+                    "type": "string"
+                },
+                "actual": {
+                    "description": "Minutes spent on the task or project when the event occurred",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "estimated": {
+                    "description": "Estimate of the task or budget of the project in minutes when the event occurred",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "Kind of the event: task_estimate_exceeded or project_budget_exceeded",
+                    "type": "string"
+                },
+                "project": {
+                    "description": "Project of the task",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    ]
+                },
+                "projectID": {
+                    "description": "ID of the project of the task (nil if unassigned)",
+                    "type": "integer"
+                },
+                "task": {
+                    "description": "Task whose end caused the event",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    ]
+                },
+                "taskID": {
+                    "description": "ID of the task whose end caused the event",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "User who ended the task",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "userID": {
+                    "description": "ID of the user who ended the task",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Client": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "email": {
+                    "description": "Contact email of the client",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name of the client",
+                    "type": "string"
+                },
+                "projects": {
+                    "description": "Projects of the client",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Project"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Estimate": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "description": "Minutes spent",
+                    "type": "integer"
+                },
+                "estimated": {
+                    "description": "Estimated minutes",
+                    "type": "integer"
+                },
+                "exceeded": {
+                    "description": "Whether more time was spent than estimated",
+                    "type": "boolean"
+                },
+                "remaining": {
+                    "description": "Minutes left before the estimate is exceeded (negative once it is)",
+                    "type": "integer"
+                },
+                "usage": {
+                    "description": "Share of the estimate spent in percent, rounded to tenths",
+                    "type": "number"
+                }
+            }
+        },
+        "models.Invoice": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "description": "Billed client",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Client"
+                        }
+                    ]
+                },
+                "clientID": {
+                    "description": "ID of the billed client (nil if not restricted to a client)",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217 currency code",
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "endDate": {
+                    "description": "End of the billed period (exclusive)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "description": "Line items, one per task and rate",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvoiceLine"
+                    }
+                },
+                "number": {
+                    "description": "Invoice number, assigned when the invoice is sent",
+                    "type": "string"
+                },
+                "paidAt": {
+                    "description": "Time the invoice was paid",
+                    "type": "string"
+                },
+                "sentAt": {
+                    "description": "Time the invoice was sent",
+                    "type": "string"
+                },
+                "startDate": {
+                    "description": "Start of the billed period (inclusive)",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Lifecycle state of the invoice",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.InvoiceStatus"
+                        }
+                    ]
+                },
+                "subtotal": {
+                    "description": "Sum of the line amounts",
+                    "type": "number"
+                },
+                "total": {
+                    "description": "Subtotal plus VAT",
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "User whose time is billed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "userID": {
+                    "description": "ID of the user whose time is billed (nil for all users)",
+                    "type": "integer"
+                },
+                "vatAmount": {
+                    "description": "VAT charged on the subtotal",
+                    "type": "number"
+                },
+                "vatRate": {
+                    "description": "VAT rate in percent",
+                    "type": "number"
+                }
+            }
+        },
+        "models.InvoiceLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount charged for the line",
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "Project name and task description",
+                    "type": "string"
+                },
+                "hourlyRate": {
+                    "description": "Rate the hours are billed at",
+                    "type": "number"
+                },
+                "hours": {
+                    "description": "Billed hours, rounded to hundredths",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invoiceID": {
+                    "description": "ID of the invoice the line belongs to",
+                    "type": "integer"
+                },
+                "projectID": {
+                    "description": "ID of the project of the task (nil if unassigned)",
+                    "type": "integer"
+                },
+                "taskID": {
+                    "description": "ID of the billed task",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InvoiceStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "sent",
+                "paid"
+            ],
+            "x-enum-varnames": [
+                "InvoiceStatusDraft",
+                "InvoiceStatusSent",
+                "InvoiceStatusPaid"
+            ]
+        },
+        "models.People": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address of the person",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name of the person",
+                    "type": "string"
+                },
+                "passportNumber": {
+                    "description": "Passport number (unique, not null)",
+                    "type": "integer"
+                },
+                "passportSeries": {
+                    "description": "Passport series (unique, not null)",
+                    "type": "integer"
+                },
+                "patronymic": {
+                    "description": "Patronymic (middle name) of the person",
+                    "type": "string"
+                },
+                "surname": {
+                    "description": "Surname of the person",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Project": {
+            "type": "object",
+            "properties": {
+                "budgetHours": {
+                    "description": "Budget of the project in hours (nil if not budgeted)",
+                    "type": "number"
+                },
+                "client": {
+                    "description": "Client the project belongs to",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Client"
+                        }
+                    ]
+                },
+                "clientID": {
+                    "description": "ID of the client the project belongs to (nil for internal projects)",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "Description of the project",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name of the project",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Rate": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "effectiveFrom": {
+                    "description": "Time from which the rate applies",
+                    "type": "string"
+                },
+                "hourlyRate": {
+                    "description": "Amount charged per hour",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "projectID": {
+                    "description": "ID of the project the rate applies to (nil for all projects)",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID of the user the rate applies to (nil for all users)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Tag": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "description": "Display color of the tag, e.g. #ff8800",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name of the tag (unique, lower case)",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Task": {
+            "type": "object",
+            "properties": {
+                "billable": {
+                    "description": "Whether time spent on the task is billed to the client",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "Description of the task",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "Duration of the task in minutes (sum of all closed intervals)",
+                    "type": "integer"
+                },
+                "endTime": {
+                    "description": "End time of the last closed interval of the task",
+                    "type": "string"
+                },
+                "estimate": {
+                    "description": "Estimated versus actual duration (nil if not estimated)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Estimate"
+                        }
+                    ]
+                },
+                "estimatedDuration": {
+                    "description": "Estimated duration of the task in minutes (nil if not estimated)",
+                    "type": "integer"
+                },
+                "externalID": {
+                    "description": "ID of the entry the task was imported from (nil if created here)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "intervals": {
+                    "description": "Work intervals of the task, ordered by start time",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskInterval"
+                    }
+                },
+                "project": {
+                    "description": "Project the task belongs to",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    ]
+                },
+                "projectID": {
+                    "description": "ID of the project the task belongs to (nil if unassigned)",
+                    "type": "integer"
+                },
+                "startTime": {
+                    "description": "Start time of the first interval of the task",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Lifecycle state of the task",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaskStatus"
+                        }
+                    ]
+                },
+                "tags": {
+                    "description": "Tags of the task",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID of the user associated with the task",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TaskAction": {
+            "type": "string",
+            "enum": [
+                "start",
+                "pause",
+                "resume",
+                "end",
+                "cancel"
+            ],
+            "x-enum-varnames": [
+                "TaskActionStart",
+                "TaskActionPause",
+                "TaskActionResume",
+                "TaskActionEnd",
+                "TaskActionCancel"
+            ]
+        },
+        "models.TaskInterval": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "endTime": {
+                    "description": "End time of the interval (nil while the interval is open)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invoiceID": {
+                    "description": "ID of the invoice the interval was billed on (nil if not invoiced)",
+                    "type": "integer"
+                },
+                "startTime": {
+                    "description": "Start time of the interval",
+                    "type": "string"
+                },
+                "taskID": {
+                    "description": "ID of the task the interval belongs to",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TaskStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "running",
+                "paused",
+                "done",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "TaskStatusCreated",
+                "TaskStatusRunning",
+                "TaskStatusPaused",
+                "TaskStatusDone",
+                "TaskStatusCancelled"
+            ]
+        },
+        "models.TimesheetApproval": {
+            "type": "object",
             "properties": {
-                "databaseURL": {
+                "comment": {
+                    "description": "Comment of the manager",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reviewedAt": {
+                    "description": "Time the week was approved or rejected",
+                    "type": "string"
+                },
+                "reviewer": {
+                    "description": "Manager who approved or rejected the week",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "reviewerID": {
+                    "description": "ID of the manager who approved or rejected the week",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Approval state of the week",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TimesheetStatus"
+                        }
+                    ]
+                },
+                "submittedAt": {
+                    "description": "Time the week was last submitted",
+                    "type": "string"
+                },
+                "totalDuration": {
+                    "description": "Minutes tracked in the week when it was last submitted or approved",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "User whose time is submitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "userID": {
+                    "description": "ID of the user whose time is submitted",
+                    "type": "integer"
+                },
+                "weekStart": {
+                    "description": "Monday the week starts on",
+                    "type": "string"
+                }
+            }
+        },
+        "models.TimesheetStatus": {
+            "type": "string",
+            "enum": [
+                "submitted",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "TimesheetStatusSubmitted",
+                "TimesheetStatusApproved",
+                "TimesheetStatusRejected"
+            ]
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address of the user",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name of the user",
+                    "type": "string"
+                },
+                "passportNumber": {
+                    "description": "Passport number of the user (unique and not null)",
+                    "type": "string"
+                },
+                "patronymic": {
+                    "description": "Patronymic (middle name) of the user",
+                    "type": "string"
+                },
+                "surname": {
+                    "description": "Surname of the user",
+                    "type": "string"
+                },
+                "tasks": {
+                    "description": "List of tasks associated with the user",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Task"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Whether events are delivered to the webhook",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "Description of the subscriber",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Names of the events the webhook is subscribed to",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL the events are posted to",
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Number of attempts made",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "error": {
+                    "description": "Error of the last attempt",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "Name of the event",
+                    "type": "string"
+                },
+                "eventID": {
+                    "description": "ID of the event, shared by its deliveries to all webhooks",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastAttemptAt": {
+                    "description": "Time of the last attempt",
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "description": "Time of the next attempt (nil once the delivery is finished)",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "JSON body posted to the webhook",
+                    "type": "string"
+                },
+                "redeliveryOf": {
+                    "description": "ID of the delivery this one was manually redelivered from",
+                    "type": "integer"
+                },
+                "responseBody": {
+                    "description": "Beginning of the body of the last response",
+                    "type": "string"
+                },
+                "responseStatus": {
+                    "description": "HTTP status of the last response (0 if there was none)",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Delivery state",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.WebhookDeliveryStatus"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "webhookID": {
+                    "description": "ID of the webhook",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "WebhookDeliveryFailed": "Given up after the last attempt",
+                "WebhookDeliveryPending": "Waiting for its first attempt or a retry",
+                "WebhookDeliverySucceeded": "Answered with a 2xx status"
+            },
+            "x-enum-varnames": [
+                "WebhookDeliveryPending",
+                "WebhookDeliverySucceeded",
+                "WebhookDeliveryFailed"
+            ]
+        },
+        "reports.OverrunReport": {
+            "type": "object",
+            "properties": {
+                "projects": {
+                    "description": "Projects over or near their budgets, highest usage first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reports.ProjectOverrun"
+                    }
+                },
+                "tasks": {
+                    "description": "Tasks over or near their estimates, highest usage first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reports.TaskOverrun"
+                    }
+                },
+                "threshold": {
+                    "description": "Share of the estimate in percent from which tasks and projects are at risk",
+                    "type": "number"
+                }
+            }
+        },
+        "reports.ProjectOverrun": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "description": "Minutes spent",
+                    "type": "integer"
+                },
+                "budgetHours": {
+                    "description": "Budget of the project in hours",
+                    "type": "number"
+                },
+                "clientID": {
+                    "description": "ID of the client (nil for internal projects)",
+                    "type": "integer"
+                },
+                "clientName": {
+                    "description": "Name of the client",
+                    "type": "string"
+                },
+                "estimated": {
+                    "description": "Estimated minutes",
+                    "type": "integer"
+                },
+                "exceeded": {
+                    "description": "Whether more time was spent than estimated",
+                    "type": "boolean"
+                },
+                "hours": {
+                    "description": "Hours spent on the project, rounded to hundredths",
+                    "type": "number"
+                },
+                "level": {
+                    "description": "exceeded or at_risk",
+                    "type": "string"
+                },
+                "projectID": {
+                    "description": "ID of the project",
+                    "type": "integer"
+                },
+                "projectName": {
+                    "description": "Name of the project",
+                    "type": "string"
+                },
+                "remaining": {
+                    "description": "Minutes left before the estimate is exceeded (negative once it is)",
+                    "type": "integer"
+                },
+                "usage": {
+                    "description": "Share of the estimate spent in percent, rounded to tenths",
+                    "type": "number"
+                }
+            }
+        },
+        "reports.ProjectReport": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "description": "End of the period (exclusive)",
+                    "type": "string"
+                },
+                "groupBy": {
+                    "description": "Grouping: project or client",
+                    "type": "string"
+                },
+                "rows": {
+                    "description": "Totals per project or client, most time first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reports.ProjectTotal"
+                    }
+                },
+                "startDate": {
+                    "description": "Start of the period (inclusive)",
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "description": "Total amount charged for the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "totalBillableDuration": {
+                    "description": "Total billable minutes spent within the period",
+                    "type": "integer"
+                },
+                "totalBillableHours": {
+                    "description": "Total billable hours spent within the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "totalDuration": {
+                    "description": "Total minutes spent within the period",
+                    "type": "integer"
+                },
+                "totalHours": {
+                    "description": "Total hours spent within the period, rounded to hundredths",
+                    "type": "number"
+                }
+            }
+        },
+        "reports.ProjectTotal": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount charged for the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "billableDuration": {
+                    "description": "Billable minutes spent within the period",
+                    "type": "integer"
+                },
+                "billableHours": {
+                    "description": "Billable hours spent within the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "clientID": {
+                    "description": "ID of the client (nil for internal projects and unassigned tasks)",
+                    "type": "integer"
+                },
+                "clientName": {
+                    "description": "Name of the client",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "Minutes spent within the period",
+                    "type": "integer"
+                },
+                "hours": {
+                    "description": "Hours spent within the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "projectID": {
+                    "description": "ID of the project (omitted when grouped by client)",
+                    "type": "integer"
+                },
+                "projectName": {
+                    "description": "Name of the project (omitted when grouped by client)",
                     "type": "string"
                 },
-                "externalAPIURL": {
-                    "type": "string"
+                "taskCount": {
+                    "description": "Number of tasks worked on within the period",
+                    "type": "integer"
+                },
+                "userCount": {
+                    "description": "Number of users who worked within the period",
+                    "type": "integer"
                 }
             }
         },
-        "controllers.AddUserRequest": {
+        "reports.TaskDuration": {
             "type": "object",
             "properties": {
-                "passportNumber": {
+                "amount": {
+                    "description": "Amount charged for the task, rounded to hundredths",
+                    "type": "number"
+                },
+                "billable": {
+                    "description": "Whether time spent on the task is billed",
+                    "type": "boolean"
+                },
+                "description": {
+                    "description": "Description of the task",
                     "type": "string"
+                },
+                "duration": {
+                    "description": "Minutes spent on the task",
+                    "type": "integer"
+                },
+                "hours": {
+                    "description": "Hours spent on the task, rounded to hundredths",
+                    "type": "number"
+                },
+                "projectID": {
+                    "description": "ID of the project of the task (nil if unassigned)",
+                    "type": "integer"
+                },
+                "taskID": {
+                    "description": "ID of the task",
+                    "type": "integer"
                 }
             }
         },
-        "gorm.DeletedAt": {
+        "reports.TaskOverrun": {
             "type": "object",
             "properties": {
-                "time": {
+                "actual": {
+                    "description": "Minutes spent",
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "Description of the task",
                     "type": "string"
                 },
-                "valid": {
-                    "description": "Valid is true if Time is not NULL",
+                "estimated": {
+                    "description": "Estimated minutes",
+                    "type": "integer"
+                },
+                "exceeded": {
+                    "description": "Whether more time was spent than estimated",
                     "type": "boolean"
+                },
+                "level": {
+                    "description": "exceeded or at_risk",
+                    "type": "string"
+                },
+                "projectID": {
+                    "description": "ID of the project of the task (nil if unassigned)",
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "Minutes left before the estimate is exceeded (negative once it is)",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Lifecycle state of the task",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaskStatus"
+                        }
+                    ]
+                },
+                "taskID": {
+                    "description": "ID of the task",
+                    "type": "integer"
+                },
+                "usage": {
+                    "description": "Share of the estimate spent in percent, rounded to tenths",
+                    "type": "number"
+                },
+                "userID": {
+                    "description": "ID of the user of the task",
+                    "type": "integer"
                 }
             }
         },
-        "models.People": {
+        "reports.TeamMember": {
             "type": "object",
             "properties": {
-                "address": {
-                    "description": "Address of the person",
-                    "type": "string"
+                "amount": {
+                    "description": "Amount charged for the period, rounded to hundredths",
+                    "type": "number"
                 },
-                "createdAt": {
-                    "type": "string"
+                "averageTaskDuration": {
+                    "description": "Average minutes per task within the period",
+                    "type": "integer"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "billableDuration": {
+                    "description": "Billable minutes spent within the period",
+                    "type": "integer"
                 },
-                "id": {
+                "billableHours": {
+                    "description": "Billable hours spent within the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "duration": {
+                    "description": "Minutes spent within the period",
                     "type": "integer"
                 },
+                "hours": {
+                    "description": "Hours spent within the period, rounded to hundredths",
+                    "type": "number"
+                },
                 "name": {
-                    "description": "Name of the person",
+                    "description": "Name of the user",
                     "type": "string"
                 },
-                "passportNumber": {
-                    "description": "Passport number (unique, not null)",
+                "patronymic": {
+                    "description": "Patronymic (middle name) of the user",
+                    "type": "string"
+                },
+                "rank": {
+                    "description": "Position of the user by time spent, starting from 1",
                     "type": "integer"
                 },
-                "passportSeries": {
-                    "description": "Passport series (unique, not null)",
+                "surname": {
+                    "description": "Surname of the user",
+                    "type": "string"
+                },
+                "taskCount": {
+                    "description": "Number of tasks worked on within the period",
                     "type": "integer"
                 },
-                "patronymic": {
-                    "description": "Patronymic (middle name) of the person",
+                "userID": {
+                    "description": "ID of the user",
+                    "type": "integer"
+                }
+            }
+        },
+        "reports.TeamReport": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "description": "End of the period (exclusive)",
                     "type": "string"
                 },
-                "surname": {
-                    "description": "Surname of the person",
+                "startDate": {
+                    "description": "Start of the period (inclusive)",
                     "type": "string"
                 },
-                "updatedAt": {
+                "taskCount": {
+                    "description": "Total number of tasks worked on within the period",
+                    "type": "integer"
+                },
+                "totalAmount": {
+                    "description": "Total amount charged for the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "totalBillableDuration": {
+                    "description": "Total billable minutes spent by all users within the period",
+                    "type": "integer"
+                },
+                "totalBillableHours": {
+                    "description": "Total billable hours spent by all users, rounded to hundredths",
+                    "type": "number"
+                },
+                "totalDuration": {
+                    "description": "Total minutes spent by all users within the period",
+                    "type": "integer"
+                },
+                "totalHours": {
+                    "description": "Total hours spent by all users, rounded to hundredths",
+                    "type": "number"
+                },
+                "users": {
+                    "description": "Users ranked by time spent, most first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reports.TeamMember"
+                    }
+                }
+            }
+        },
+        "reports.TimeEntries": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "description": "End of the period (exclusive)",
+                    "type": "string"
+                },
+                "entries": {
+                    "description": "Tasks worked on within the period, longest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reports.TimeEntry"
+                    }
+                },
+                "startDate": {
+                    "description": "Start of the period (inclusive)",
                     "type": "string"
+                },
+                "totalAmount": {
+                    "description": "Total amount charged for the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "totalBillableDuration": {
+                    "description": "Total billable minutes spent within the period",
+                    "type": "integer"
+                },
+                "totalDuration": {
+                    "description": "Total minutes spent within the period",
+                    "type": "integer"
+                },
+                "userID": {
+                    "description": "ID of the user",
+                    "type": "integer"
                 }
             }
         },
-        "models.Task": {
+        "reports.TimeEntry": {
             "type": "object",
             "properties": {
+                "billable": {
+                    "description": "Whether time spent on the task is billed to the client",
+                    "type": "boolean"
+                },
                 "createdAt": {
                     "type": "string"
                 },
@@ -486,20 +5297,78 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "duration": {
-                    "description": "Duration of the task in minutes",
+                    "description": "Duration of the task in minutes (sum of all closed intervals)",
                     "type": "integer"
                 },
                 "endTime": {
-                    "description": "End time of the task",
+                    "description": "End time of the last closed interval of the task",
+                    "type": "string"
+                },
+                "estimate": {
+                    "description": "Estimated versus actual duration (nil if not estimated)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Estimate"
+                        }
+                    ]
+                },
+                "estimatedDuration": {
+                    "description": "Estimated duration of the task in minutes (nil if not estimated)",
+                    "type": "integer"
+                },
+                "externalID": {
+                    "description": "ID of the entry the task was imported from (nil if created here)",
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "intervals": {
+                    "description": "Work intervals of the task, ordered by start time",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskInterval"
+                    }
+                },
+                "periodAmount": {
+                    "description": "Amount charged for the task within the period (0 if not billable)",
+                    "type": "number"
+                },
+                "periodDuration": {
+                    "description": "Minutes spent on the task within the period",
+                    "type": "integer"
+                },
+                "project": {
+                    "description": "Project the task belongs to",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    ]
+                },
+                "projectID": {
+                    "description": "ID of the project the task belongs to (nil if unassigned)",
+                    "type": "integer"
+                },
                 "startTime": {
-                    "description": "Start time of the task",
+                    "description": "Start time of the first interval of the task",
                     "type": "string"
                 },
+                "status": {
+                    "description": "Lifecycle state of the task",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaskStatus"
+                        }
+                    ]
+                },
+                "tags": {
+                    "description": "Tags of the task",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
                 "updatedAt": {
                     "type": "string"
                 },
@@ -509,47 +5378,226 @@ const docTemplate = `{
                 }
             }
         },
-        "models.User": {
+        "reports.Timesheet": {
             "type": "object",
             "properties": {
-                "address": {
-                    "description": "Address of the user",
+                "buckets": {
+                    "description": "Buckets covering the period, in chronological order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reports.TimesheetBucket"
+                    }
+                },
+                "endDate": {
+                    "description": "End of the period (exclusive)",
                     "type": "string"
                 },
-                "createdAt": {
+                "groupBy": {
+                    "description": "Bucket size: day, week or month",
                     "type": "string"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "startDate": {
+                    "description": "Start of the period (inclusive)",
+                    "type": "string"
                 },
-                "id": {
+                "tasks": {
+                    "description": "Per-task totals for the whole period, longest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reports.TaskDuration"
+                    }
+                },
+                "totalAmount": {
+                    "description": "Total amount charged for the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "totalBillableDuration": {
+                    "description": "Total billable minutes spent within the period",
                     "type": "integer"
                 },
-                "name": {
-                    "description": "Name of the user",
-                    "type": "string"
+                "totalBillableHours": {
+                    "description": "Total billable hours spent within the period, rounded to hundredths",
+                    "type": "number"
                 },
-                "passportNumber": {
-                    "description": "Passport number of the user (unique and not null)",
-                    "type": "string"
+                "totalDuration": {
+                    "description": "Total minutes spent within the period",
+                    "type": "integer"
                 },
-                "patronymic": {
-                    "description": "Patronymic (middle name) of the user",
+                "totalHours": {
+                    "description": "Total hours spent within the period, rounded to hundredths",
+                    "type": "number"
+                },
+                "userID": {
+                    "description": "ID of the user",
+                    "type": "integer"
+                }
+            }
+        },
+        "reports.TimesheetBucket": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount charged for the bucket, rounded to hundredths",
+                    "type": "number"
+                },
+                "billableDuration": {
+                    "description": "Billable minutes spent within the bucket",
+                    "type": "integer"
+                },
+                "billableHours": {
+                    "description": "Billable hours spent within the bucket, rounded to hundredths",
+                    "type": "number"
+                },
+                "duration": {
+                    "description": "Minutes spent within the bucket",
+                    "type": "integer"
+                },
+                "end": {
+                    "description": "End of the bucket (exclusive)",
                     "type": "string"
                 },
-                "surname": {
-                    "description": "Surname of the user",
+                "hours": {
+                    "description": "Hours spent within the bucket, rounded to hundredths",
+                    "type": "number"
+                },
+                "start": {
+                    "description": "Start of the bucket",
                     "type": "string"
                 },
                 "tasks": {
-                    "description": "List of tasks associated with the user",
+                    "description": "Per-task breakdown, longest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reports.TaskDuration"
+                    }
+                }
+            }
+        },
+        "imports.CalendarResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Number of tasks created (or that would be created in a dry run)",
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "description": "Whether the import was a preview only",
+                    "type": "boolean"
+                },
+                "skipped": {
+                    "description": "Events that were not imported",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.SkippedEvent"
+                    }
+                },
+                "tasks": {
+                    "description": "Created tasks; in a dry run they have no IDs",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/models.Task"
                     }
+                }
+            }
+        },
+        "live.Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "The task the event is about"
+                },
+                "id": {
+                    "description": "Position of the event in the stream; IDs increase across restarts",
+                    "type": "integer"
+                },
+                "time": {
+                    "description": "Time the event occurred",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type of the event",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "User whose timer changed",
+                    "type": "integer"
+                }
+            }
+        },
+        "timesheets.Timesheet": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Comment of the manager",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "entries": {
+                    "description": "Time tracked in the week",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/reports.TimeEntries"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reviewedAt": {
+                    "description": "Time the week was approved or rejected",
+                    "type": "string"
+                },
+                "reviewer": {
+                    "description": "Manager who approved or rejected the week",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "reviewerID": {
+                    "description": "ID of the manager who approved or rejected the week",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Approval state of the week",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TimesheetStatus"
+                        }
+                    ]
+                },
+                "submittedAt": {
+                    "description": "Time the week was last submitted",
+                    "type": "string"
+                },
+                "totalDuration": {
+                    "description": "Minutes tracked in the week when it was last submitted or approved",
+                    "type": "integer"
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "user": {
+                    "description": "User whose time is submitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "userID": {
+                    "description": "ID of the user whose time is submitted",
+                    "type": "integer"
+                },
+                "weekStart": {
+                    "description": "Monday the week starts on",
+                    "type": "string"
                 }
             }
         }
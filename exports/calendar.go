@@ -0,0 +1,107 @@
+package exports
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"unicode/utf8"
+)
+
+// CalendarUIDDomain is the domain part of the UIDs of exported calendar events.
+// Importers use it to recognise events that originate from this service.
+const CalendarUIDDomain = "time-tracker-go"
+
+// icsTimeLayout is the iCalendar UTC date-time format.
+const icsTimeLayout = "20060102T150405Z"
+
+// icsLineLength is the maximum length of a content line in octets, excluding the line break.
+const icsLineLength = 75
+
+// WriteCalendar writes an iCalendar feed with one VEVENT per interval of the tasks that overlaps the period.
+// Events span whole intervals; running intervals end at now.
+func WriteCalendar(w io.Writer, name string, tasks []models.Task, period reports.Period, now time.Time) error {
+	out := bufio.NewWriter(w)
+	line := func(format string, args ...interface{}) {
+		writeContentLine(out, fmt.Sprintf(format, args...))
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//%s//time tracker//EN", CalendarUIDDomain)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:%s", escapeText(name))
+
+	for _, task := range tasks {
+		summary := task.Description
+		if summary == "" {
+			summary = fmt.Sprintf("Task %d", task.ID)
+		}
+
+		details := []string{fmt.Sprintf("Task ID: %d", task.ID), "Status: " + string(task.Status)}
+		if task.Project != nil {
+			details = append(details, "Project: "+task.Project.Name)
+			if task.Project.Client != nil {
+				details = append(details, "Client: "+task.Project.Client.Name)
+			}
+		}
+		tags := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			tags[i] = escapeText(tag.Name)
+		}
+
+		for _, interval := range task.Intervals {
+			end := now
+			if interval.EndTime != nil {
+				end = *interval.EndTime
+			}
+			if !interval.StartTime.Before(period.To) || !end.After(period.From) {
+				continue
+			}
+
+			line("BEGIN:VEVENT")
+			line("UID:interval-%d@%s", interval.ID, CalendarUIDDomain)
+			line("DTSTAMP:%s", now.UTC().Format(icsTimeLayout))
+			line("DTSTART:%s", interval.StartTime.UTC().Format(icsTimeLayout))
+			line("DTEND:%s", end.UTC().Format(icsTimeLayout))
+			line("SUMMARY:%s", escapeText(summary))
+			line("DESCRIPTION:%s", escapeText(strings.Join(details, "\n")))
+			if len(tags) > 0 {
+				line("CATEGORIES:%s", strings.Join(tags, ","))
+			}
+			line("X-TIME-TRACKER-TASK-ID:%d", task.ID)
+			line("END:VEVENT")
+		}
+	}
+
+	line("END:VCALENDAR")
+	return out.Flush()
+}
+
+// escapeText escapes an iCalendar TEXT value.
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// writeContentLine writes a content line terminated by CRLF, folding it into lines of at most
+// icsLineLength octets without splitting multi-byte characters.
+func writeContentLine(out *bufio.Writer, line string) {
+	limit := icsLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		out.WriteString(line[:cut])
+		out.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards their length.
+		limit = icsLineLength - 1
+	}
+	out.WriteString(line)
+	out.WriteString("\r\n")
+}
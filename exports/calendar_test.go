@@ -0,0 +1,139 @@
+package exports
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"time-tracker-go/imports"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC) }
+	moscow := time.FixedZone("MSK", 3*60*60)
+	end, noon := at(4, 10), at(4, 12)
+	before := at(1, 10)
+	now := time.Date(2024, 3, 5, 12, 30, 0, 0, moscow)
+
+	tasks := []models.Task{
+		{
+			Model:       gorm.Model{ID: 7},
+			Description: "Review; fix\\deploy, then\nreport",
+			Status:      models.TaskStatusRunning,
+			Project:     &models.Project{Name: "Website", Client: &models.Client{Name: "Acme, Inc."}},
+			Tags:        []models.Tag{{Name: "backend"}, {Name: "r,d"}},
+			Intervals: []models.TaskInterval{
+				// Intervals outside the period are left out.
+				{Model: gorm.Model{ID: 1}, StartTime: at(1, 9), EndTime: &before},
+				// Times are written in UTC.
+				{Model: gorm.Model{ID: 2}, StartTime: time.Date(2024, 3, 4, 12, 0, 0, 0, moscow), EndTime: &end},
+				// The running interval ends at now.
+				{Model: gorm.Model{ID: 3}, StartTime: at(5, 8)},
+			},
+		},
+		{
+			Model:     gorm.Model{ID: 8},
+			Status:    models.TaskStatusDone,
+			Intervals: []models.TaskInterval{{Model: gorm.Model{ID: 4}, StartTime: at(4, 11), EndTime: &noon}},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := WriteCalendar(&out, "Ivan; Petrov", tasks, reports.Period{From: at(4, 0), To: at(11, 0)}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//time-tracker-go//time tracker//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		`X-WR-CALNAME:Ivan\; Petrov`,
+		"BEGIN:VEVENT",
+		"UID:interval-2@time-tracker-go",
+		"DTSTAMP:20240305T093000Z",
+		"DTSTART:20240304T090000Z",
+		"DTEND:20240304T100000Z",
+		`SUMMARY:Review\; fix\\deploy\, then\nreport`,
+		// The line is folded at 75 octets.
+		`DESCRIPTION:Task ID: 7\nStatus: running\nProject: Website\nClient: Acme\, I`,
+		` nc.`,
+		`CATEGORIES:backend,r\,d`,
+		"X-TIME-TRACKER-TASK-ID:7",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:interval-3@time-tracker-go",
+		"DTSTAMP:20240305T093000Z",
+		"DTSTART:20240305T080000Z",
+		"DTEND:20240305T093000Z",
+		`SUMMARY:Review\; fix\\deploy\, then\nreport`,
+		`DESCRIPTION:Task ID: 7\nStatus: running\nProject: Website\nClient: Acme\, I`,
+		` nc.`,
+		`CATEGORIES:backend,r\,d`,
+		"X-TIME-TRACKER-TASK-ID:7",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:interval-4@time-tracker-go",
+		"DTSTAMP:20240305T093000Z",
+		"DTSTART:20240304T110000Z",
+		"DTEND:20240304T120000Z",
+		"SUMMARY:Task 8",
+		`DESCRIPTION:Task ID: 8\nStatus: done`,
+		"X-TIME-TRACKER-TASK-ID:8",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if got := out.String(); got != want {
+		t.Errorf("got calendar\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteContentLineFolding(t *testing.T) {
+	// Two-byte Cyrillic letters between one-byte spaces put some of the folds inside a character.
+	summary := "Планирование спринта: " + strings.Repeat("обсуждение задач, ", 12)
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	tasks := []models.Task{{
+		Model:       gorm.Model{ID: 1},
+		Description: summary,
+		Intervals:   []models.TaskInterval{{Model: gorm.Model{ID: 1}, StartTime: start, EndTime: &end}},
+	}}
+
+	var out bytes.Buffer
+	if err := WriteCalendar(&out, "Календарь", tasks, reports.Period{From: start, To: end}, end); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n")
+	folded := 0
+	for i, line := range lines {
+		if len(line) > icsLineLength {
+			t.Errorf("line %d is %d octets long: %q", i+1, len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a character: %q", i+1, line)
+		}
+		if strings.HasPrefix(line, " ") {
+			folded++
+		}
+	}
+	if folded < 4 {
+		t.Errorf("got %d continuation lines, want the summary folded at least 4 times", folded)
+	}
+
+	// The folded feed reads back as the original events.
+	events, err := imports.ParseCalendar(&out, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Summary != summary || !events[0].Start.Equal(start) || !events[0].End.Equal(end) {
+		t.Errorf("got events %+v, want %q from %s to %s", events, summary, start, end)
+	}
+}
@@ -0,0 +1,191 @@
+// Package exports renders reports into file formats consumed by other applications.
+package exports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// summarySheet is the name of the first sheet of a timesheet workbook.
+const summarySheet = "Сводка"
+
+// UserTimesheet is the timesheet of one user in a workbook.
+type UserTimesheet struct {
+	User      models.User
+	Timesheet reports.Timesheet
+}
+
+// workbookStyles holds the IDs of the cell styles used in timesheet workbooks.
+type workbookStyles struct {
+	header   int
+	date     int
+	duration int
+	money    int
+	total    int
+	totalDur int
+	totalSum int
+}
+
+// WriteTimesheetWorkbook writes an XLSX workbook with a summary sheet and one sheet per user.
+// User sheets have a row per timesheet bucket with durations stored as Excel time values and totals
+// computed with formulas; the summary sheet references the totals of the user sheets.
+func WriteTimesheetWorkbook(w io.Writer, period reports.Period, timesheets []UserTimesheet) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", summarySheet); err != nil {
+		return err
+	}
+	styles, err := newWorkbookStyles(f)
+	if err != nil {
+		return err
+	}
+
+	// Summary header
+	title := fmt.Sprintf("Табель за период %s — %s", period.From.Format("02.01.2006 15:04"), period.To.Format("02.01.2006 15:04"))
+	setRow(f, summarySheet, 1, title)
+	setRow(f, summarySheet, 3, "ID", "Сотрудник", "Всего", "Оплачиваемое", "Сумма")
+	f.SetCellStyle(summarySheet, "A3", "E3", styles.header)
+	f.SetColWidth(summarySheet, "B", "B", 40)
+	f.SetColWidth(summarySheet, "C", "E", 14)
+
+	for i, timesheet := range timesheets {
+		sheet := sheetName(timesheet.User)
+		if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+		totalRow, err := writeUserSheet(f, sheet, timesheet, styles)
+		if err != nil {
+			return err
+		}
+
+		row := 4 + i
+		user := timesheet.User
+		setRow(f, summarySheet, row, user.ID, strings.TrimSpace(user.Surname+" "+user.Name+" "+user.Patronymic))
+		// Columns C:E of the summary reference the totals in columns B:D of the user sheet.
+		for i, col := range []string{"C", "D", "E"} {
+			sourceCol := string(rune('B' + i))
+			f.SetCellFormula(summarySheet, fmt.Sprintf("%s%d", col, row), fmt.Sprintf("'%s'!%s%d", sheet, sourceCol, totalRow))
+		}
+		f.SetCellStyle(summarySheet, fmt.Sprintf("C%d", row), fmt.Sprintf("D%d", row), styles.duration)
+		f.SetCellStyle(summarySheet, fmt.Sprintf("E%d", row), fmt.Sprintf("E%d", row), styles.money)
+	}
+
+	// Summary totals
+	totalRow := 4 + len(timesheets)
+	f.SetCellValue(summarySheet, fmt.Sprintf("B%d", totalRow), "Итого")
+	for _, col := range []string{"C", "D", "E"} {
+		f.SetCellFormula(summarySheet, fmt.Sprintf("%s%d", col, totalRow), fmt.Sprintf("SUM(%s4:%s%d)", col, col, totalRow-1))
+	}
+	f.SetCellStyle(summarySheet, fmt.Sprintf("A%d", totalRow), fmt.Sprintf("B%d", totalRow), styles.total)
+	f.SetCellStyle(summarySheet, fmt.Sprintf("C%d", totalRow), fmt.Sprintf("D%d", totalRow), styles.totalDur)
+	f.SetCellStyle(summarySheet, fmt.Sprintf("E%d", totalRow), fmt.Sprintf("E%d", totalRow), styles.totalSum)
+
+	f.SetActiveSheet(0)
+	return f.Write(w)
+}
+
+// writeUserSheet fills the sheet of a user and returns the number of its totals row.
+func writeUserSheet(f *excelize.File, sheet string, timesheet UserTimesheet, styles workbookStyles) (int, error) {
+	user := timesheet.User
+	setRow(f, sheet, 1, strings.TrimSpace(user.Surname+" "+user.Name+" "+user.Patronymic))
+	setRow(f, sheet, 3, "Дата", "Время", "Оплачиваемое", "Сумма", "Задачи")
+	f.SetCellStyle(sheet, "A3", "E3", styles.header)
+	f.SetColWidth(sheet, "A", "A", 12)
+	f.SetColWidth(sheet, "B", "D", 14)
+	f.SetColWidth(sheet, "E", "E", 60)
+	if err := f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 3, TopLeftCell: "A4", ActivePane: "bottomLeft"}); err != nil {
+		return 0, err
+	}
+
+	row := 4
+	for _, bucket := range timesheet.Timesheet.Buckets {
+		descriptions := make([]string, len(bucket.Tasks))
+		for i, task := range bucket.Tasks {
+			descriptions[i] = task.Description
+		}
+		err := f.SetSheetRow(sheet, fmt.Sprintf("A%d", row), &[]interface{}{
+			bucket.Start, excelDuration(bucket.Duration), excelDuration(bucket.BillableDuration), bucket.Amount, strings.Join(descriptions, "; "),
+		})
+		if err != nil {
+			return 0, err
+		}
+		row++
+	}
+	if row > 4 {
+		f.SetCellStyle(sheet, "A4", fmt.Sprintf("A%d", row-1), styles.date)
+		f.SetCellStyle(sheet, "B4", fmt.Sprintf("C%d", row-1), styles.duration)
+		f.SetCellStyle(sheet, "D4", fmt.Sprintf("D%d", row-1), styles.money)
+	}
+
+	f.SetCellValue(sheet, fmt.Sprintf("A%d", row), "Итого")
+	for _, col := range []string{"B", "C", "D"} {
+		f.SetCellFormula(sheet, fmt.Sprintf("%s%d", col, row), fmt.Sprintf("SUM(%s4:%s%d)", col, col, row-1))
+	}
+	f.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("A%d", row), styles.total)
+	f.SetCellStyle(sheet, fmt.Sprintf("B%d", row), fmt.Sprintf("C%d", row), styles.totalDur)
+	f.SetCellStyle(sheet, fmt.Sprintf("D%d", row), fmt.Sprintf("D%d", row), styles.totalSum)
+
+	return row, nil
+}
+
+// newWorkbookStyles registers the cell styles of timesheet workbooks.
+func newWorkbookStyles(f *excelize.File) (workbookStyles, error) {
+	var styles workbookStyles
+	durationFormat := "[h]:mm"
+	dateFormat := "dd.mm.yyyy"
+	moneyFormat := "#,##0.00"
+	bold := &excelize.Font{Bold: true}
+
+	for _, style := range []struct {
+		id    *int
+		style *excelize.Style
+	}{
+		{&styles.header, &excelize.Style{Font: bold, Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"DDEBF7"}}}},
+		{&styles.date, &excelize.Style{CustomNumFmt: &dateFormat}},
+		{&styles.duration, &excelize.Style{CustomNumFmt: &durationFormat}},
+		{&styles.money, &excelize.Style{CustomNumFmt: &moneyFormat}},
+		{&styles.total, &excelize.Style{Font: bold}},
+		{&styles.totalDur, &excelize.Style{Font: bold, CustomNumFmt: &durationFormat}},
+		{&styles.totalSum, &excelize.Style{Font: bold, CustomNumFmt: &moneyFormat}},
+	} {
+		id, err := f.NewStyle(style.style)
+		if err != nil {
+			return styles, err
+		}
+		*style.id = id
+	}
+
+	return styles, nil
+}
+
+// setRow writes values into consecutive cells of a row starting from column A.
+func setRow(f *excelize.File, sheet string, row int, values ...interface{}) {
+	f.SetSheetRow(sheet, fmt.Sprintf("A%d", row), &values)
+}
+
+// excelDuration converts minutes to an Excel time value, which is a fraction of a day.
+func excelDuration(minutes int) float64 {
+	return float64(minutes) / (24 * 60)
+}
+
+// sheetName returns the sheet name of a user within Excel's limits: at most 31 characters, none of []:*?/\'.
+// Names start with the user ID, so they are unique.
+func sheetName(user models.User) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\'`, r) {
+			return '_'
+		}
+		return r
+	}, fmt.Sprintf("%d %s %s", user.ID, user.Surname, user.Name))
+
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
@@ -0,0 +1,59 @@
+package graphqlapi
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// batch loads a field for all items of a list with one query instead of one query per item.
+// The first item whose field is resolved loads it for all of them and the others reuse the result.
+// A field requested with different arguments, for instance under two aliases, is loaded once per
+// set of arguments.
+type batch[V any] struct {
+	ids   []uint
+	mu    sync.Mutex
+	loads map[string]*load[V]
+}
+
+// load is the result of loading a field with a set of arguments.
+type load[V any] struct {
+	once   sync.Once
+	values map[uint]V
+	err    error
+}
+
+// newBatch creates a batch for the items with the given IDs. Duplicate IDs are loaded once.
+func newBatch[V any](ids []uint) *batch[V] {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return &batch[V]{ids: unique, loads: map[string]*load[V]{}}
+}
+
+// get returns the value of the item with the ID, calling fetch with the IDs of all items
+// unless the field has already been loaded with the same arguments.
+func (b *batch[V]) get(id uint, args interface{}, fetch func(ids []uint) (map[uint]V, error)) (V, error) {
+	key, err := json.Marshal(args)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	b.mu.Lock()
+	l, ok := b.loads[string(key)]
+	if !ok {
+		l = &load[V]{}
+		b.loads[string(key)] = l
+	}
+	b.mu.Unlock()
+
+	l.once.Do(func() {
+		l.values, l.err = fetch(b.ids)
+	})
+	return l.values[id], l.err
+}
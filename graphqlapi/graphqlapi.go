@@ -0,0 +1,177 @@
+// Package graphqlapi serves users, tasks and reports over GraphQL. Queries and mutations call the
+// same services as the REST and gRPC APIs. Fields that would otherwise query the database once per
+// list item, such as the tasks and totals of users and the owners of tasks, are loaded for the
+// whole list at once.
+package graphqlapi
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"time-tracker-go/services"
+	"time-tracker-go/timesheets"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// schema is the GraphQL schema served by the API.
+//
+//go:embed schema.graphql
+var schema string
+
+// NewSchema parses the schema and binds it to resolvers using the given DB connection and services.
+func NewSchema(db *gorm.DB, users *services.Users, tasks *services.Tasks) *graphql.Schema {
+	return graphql.MustParseSchema(schema, &Resolver{db: db, users: users, tasks: tasks})
+}
+
+// Resolver resolves the queries and mutations.
+type Resolver struct {
+	db    *gorm.DB
+	users *services.Users
+	tasks *services.Tasks
+}
+
+// Error is an error reported to clients with a machine-readable code in its extensions,
+// and for conflicts the reason of the REST API.
+type Error struct {
+	Message string
+	Code    string                 // BAD_USER_INPUT, NOT_FOUND, CONFLICT, UNAVAILABLE or INTERNAL
+	Details map[string]interface{} // Additional extensions, such as the reason of a conflict
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions returns the code and details of the error for the "extensions" of the GraphQL error.
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.Code}
+	for key, value := range e.Details {
+		extensions[key] = value
+	}
+	return extensions
+}
+
+// toError maps an error returned by a service to an *Error.
+func toError(err error) error {
+	var invalidErr *services.InvalidError
+	var transitionErr *models.TransitionError
+	var runningErr *models.RunningTaskError
+	var lockedErr *timesheets.LockedError
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &invalidErr):
+		return &Error{Message: invalidErr.Message, Code: "BAD_USER_INPUT"}
+	case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrTaskNotFound), errors.Is(err, services.ErrDeletedTaskNotFound):
+		return &Error{Message: err.Error(), Code: "NOT_FOUND"}
+	case errors.As(err, &transitionErr):
+		return &Error{Message: err.Error(), Code: "CONFLICT", Details: map[string]interface{}{
+			"reason": transitionErr.Reason,
+			"status": transitionErr.Status,
+			"action": transitionErr.Action,
+		}}
+	case errors.As(err, &runningErr):
+		return &Error{Message: err.Error(), Code: "CONFLICT", Details: map[string]interface{}{
+			"reason":        models.ReasonAnotherTaskRunning,
+			"runningTaskID": runningErr.TaskID,
+		}}
+	case errors.As(err, &lockedErr):
+		return &Error{Message: err.Error(), Code: "CONFLICT", Details: map[string]interface{}{
+			"reason":    models.ReasonPeriodLocked,
+			"weekStart": lockedErr.WeekStart.Format("2006-01-02"),
+		}}
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		// The database index guarantees a single running task even if the row lock was bypassed.
+		return &Error{Message: "another task is already running", Code: "CONFLICT", Details: map[string]interface{}{
+			"reason": models.ReasonAnotherTaskRunning,
+		}}
+	case errors.Is(err, services.ErrExternalAPI):
+		return &Error{Message: err.Error(), Code: "UNAVAILABLE"}
+	default:
+		log.Printf("GraphQL resolver failed: %v", err)
+		return &Error{Message: "Internal server error", Code: "INTERNAL"}
+	}
+}
+
+// invalid returns an *Error for invalid arguments.
+func invalid(format string, args ...interface{}) error {
+	return &Error{Message: fmt.Sprintf(format, args...), Code: "BAD_USER_INPUT"}
+}
+
+// toID converts a model ID to a GraphQL ID.
+func toID(id uint) graphql.ID {
+	return graphql.ID(strconv.FormatUint(uint64(id), 10))
+}
+
+// parseID converts a GraphQL ID to a model ID.
+func parseID(id graphql.ID) (uint, error) {
+	value, err := strconv.ParseUint(string(id), 10, 64)
+	if err != nil || value == 0 {
+		return 0, invalid("Invalid ID %q", id)
+	}
+	return uint(value), nil
+}
+
+// parseIDs converts optional GraphQL IDs to model IDs.
+func parseIDs(ids *[]graphql.ID) ([]uint, error) {
+	if ids == nil {
+		return nil, nil
+	}
+	result := make([]uint, len(*ids))
+	for i, id := range *ids {
+		value, err := parseID(id)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+// toTime converts a time to a GraphQL time.
+func toTime(t time.Time) graphql.Time {
+	return graphql.Time{Time: t}
+}
+
+// toOptionalTime converts a time to a GraphQL time. Zero times are null.
+func toOptionalTime(t time.Time) *graphql.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: t}
+}
+
+// toPage converts optional page arguments to a page.
+func toPage(number, size *int32) services.Page {
+	var page services.Page
+	if number != nil {
+		page.Number = int(*number)
+	}
+	if size != nil {
+		page.Size = int(*size)
+	}
+	return page
+}
+
+// toPeriod converts period arguments to a period. The end must be after the start.
+func toPeriod(startDate, endDate graphql.Time) (reports.Period, error) {
+	if !endDate.After(startDate.Time) {
+		return reports.Period{}, invalid("endDate must be after startDate")
+	}
+	return reports.Period{From: startDate.Time, To: endDate.Time}, nil
+}
+
+// value returns the value of an optional string argument.
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
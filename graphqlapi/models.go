@@ -0,0 +1,104 @@
+package graphqlapi
+
+import (
+	"time-tracker-go/models"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// projectResolver resolves Project.
+type projectResolver struct {
+	project models.Project
+}
+
+// newProject creates a resolver for a preloaded project, or nil if there is none.
+func newProject(project *models.Project) *projectResolver {
+	if project == nil {
+		return nil
+	}
+	return &projectResolver{project: *project}
+}
+
+func (p *projectResolver) ID() graphql.ID        { return toID(p.project.ID) }
+func (p *projectResolver) Name() string          { return p.project.Name }
+func (p *projectResolver) Description() string   { return p.project.Description }
+func (p *projectResolver) BudgetHours() *float64 { return p.project.BudgetHours }
+
+// Client resolves Project.client. It is null for internal projects.
+func (p *projectResolver) Client() *clientResolver {
+	if p.project.Client == nil {
+		return nil
+	}
+	return &clientResolver{client: *p.project.Client}
+}
+
+// clientResolver resolves Client.
+type clientResolver struct {
+	client models.Client
+}
+
+func (c *clientResolver) ID() graphql.ID { return toID(c.client.ID) }
+func (c *clientResolver) Name() string   { return c.client.Name }
+func (c *clientResolver) Email() string  { return c.client.Email }
+
+// tagResolver resolves Tag.
+type tagResolver struct {
+	tag models.Tag
+}
+
+// newTags creates resolvers for tags.
+func newTags(tags []models.Tag) []*tagResolver {
+	resolvers := make([]*tagResolver, len(tags))
+	for i, tag := range tags {
+		resolvers[i] = &tagResolver{tag: tag}
+	}
+	return resolvers
+}
+
+func (t *tagResolver) ID() graphql.ID { return toID(t.tag.ID) }
+func (t *tagResolver) Name() string   { return t.tag.Name }
+func (t *tagResolver) Color() string  { return t.tag.Color }
+
+// intervalResolver resolves Interval.
+type intervalResolver struct {
+	interval models.TaskInterval
+}
+
+// newIntervals creates resolvers for task intervals.
+func newIntervals(intervals []models.TaskInterval) []*intervalResolver {
+	resolvers := make([]*intervalResolver, len(intervals))
+	for i, interval := range intervals {
+		resolvers[i] = &intervalResolver{interval: interval}
+	}
+	return resolvers
+}
+
+func (i *intervalResolver) ID() graphql.ID          { return toID(i.interval.ID) }
+func (i *intervalResolver) StartTime() graphql.Time { return toTime(i.interval.StartTime) }
+
+// EndTime resolves Interval.endTime. It is null while the interval is running.
+func (i *intervalResolver) EndTime() *graphql.Time {
+	if i.interval.EndTime == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *i.interval.EndTime}
+}
+
+// estimateResolver resolves Estimate.
+type estimateResolver struct {
+	estimate models.Estimate
+}
+
+// newEstimate creates a resolver for an estimate, or nil if the task is not estimated.
+func newEstimate(estimate *models.Estimate) *estimateResolver {
+	if estimate == nil {
+		return nil
+	}
+	return &estimateResolver{estimate: *estimate}
+}
+
+func (e *estimateResolver) Estimated() int32 { return int32(e.estimate.Estimated) }
+func (e *estimateResolver) Actual() int32    { return int32(e.estimate.Actual) }
+func (e *estimateResolver) Remaining() int32 { return int32(e.estimate.Remaining) }
+func (e *estimateResolver) Usage() float64   { return e.estimate.Usage }
+func (e *estimateResolver) Exceeded() bool   { return e.estimate.Exceeded }
@@ -0,0 +1,152 @@
+package graphqlapi
+
+import (
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// TimeEntries resolves Query.timeEntries.
+func (r *Resolver) TimeEntries(args struct {
+	UserID    graphql.ID
+	StartDate graphql.Time
+	EndDate   graphql.Time
+	Filter    *taskFilterInput
+}) (*timeEntriesResolver, error) {
+	userID, err := parseID(args.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := toPeriod(args.StartDate, args.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := args.Filter.toFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := r.tasks.TimeEntries(userID, period, filter)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	tasks := make([]models.Task, len(entries.Entries))
+	for i, entry := range entries.Entries {
+		tasks[i] = entry.Task
+	}
+	return &timeEntriesResolver{entries: entries, tasks: r.newTasks(tasks)}, nil
+}
+
+// timeEntriesResolver resolves TimeEntries.
+type timeEntriesResolver struct {
+	entries reports.TimeEntries
+	tasks   []*taskResolver // Tasks of the entries, in the same order
+}
+
+func (t *timeEntriesResolver) UserID() graphql.ID      { return toID(t.entries.UserID) }
+func (t *timeEntriesResolver) StartDate() graphql.Time { return toTime(t.entries.StartDate) }
+func (t *timeEntriesResolver) EndDate() graphql.Time   { return toTime(t.entries.EndDate) }
+func (t *timeEntriesResolver) TotalDuration() int32    { return int32(t.entries.TotalDuration) }
+func (t *timeEntriesResolver) TotalBillableDuration() int32 {
+	return int32(t.entries.TotalBillableDuration)
+}
+func (t *timeEntriesResolver) TotalAmount() float64 { return t.entries.TotalAmount }
+
+// Entries resolves TimeEntries.entries.
+func (t *timeEntriesResolver) Entries() []*timeEntryResolver {
+	resolvers := make([]*timeEntryResolver, len(t.entries.Entries))
+	for i, entry := range t.entries.Entries {
+		resolvers[i] = &timeEntryResolver{entry: entry, task: t.tasks[i]}
+	}
+	return resolvers
+}
+
+// timeEntryResolver resolves TimeEntry.
+type timeEntryResolver struct {
+	entry reports.TimeEntry
+	task  *taskResolver
+}
+
+func (t *timeEntryResolver) Task() *taskResolver   { return t.task }
+func (t *timeEntryResolver) PeriodDuration() int32 { return int32(t.entry.PeriodDuration) }
+func (t *timeEntryResolver) PeriodAmount() float64 { return t.entry.PeriodAmount }
+
+// TeamReport resolves Query.teamReport.
+func (r *Resolver) TeamReport(args struct {
+	StartDate graphql.Time
+	EndDate   graphql.Time
+	Filter    *taskFilterInput
+	Users     *userFilterInput
+}) (*teamReportResolver, error) {
+	period, err := toPeriod(args.StartDate, args.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := args.Filter.toFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := args.Users.toFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := reports.Team(r.db, period, filter, time.Now(), users.Scope)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	userIDs := make([]uint, len(report.Users))
+	for i, member := range report.Users {
+		userIDs[i] = member.UserID
+	}
+	return &teamReportResolver{resolver: r, report: report, users: newBatch[*userResolver](userIDs)}, nil
+}
+
+// teamReportResolver resolves TeamReport.
+type teamReportResolver struct {
+	resolver *Resolver
+	report   reports.TeamReport
+	users    *batch[*userResolver] // Users of the report members
+}
+
+func (t *teamReportResolver) StartDate() graphql.Time { return toTime(t.report.StartDate) }
+func (t *teamReportResolver) EndDate() graphql.Time   { return toTime(t.report.EndDate) }
+func (t *teamReportResolver) TotalDuration() int32    { return int32(t.report.TotalDuration) }
+func (t *teamReportResolver) TotalHours() float64     { return t.report.TotalHours }
+func (t *teamReportResolver) TaskCount() int32        { return int32(t.report.TaskCount) }
+func (t *teamReportResolver) TotalBillableDuration() int32 {
+	return int32(t.report.TotalBillableDuration)
+}
+func (t *teamReportResolver) TotalBillableHours() float64 { return t.report.TotalBillableHours }
+func (t *teamReportResolver) TotalAmount() float64        { return t.report.TotalAmount }
+
+// Users resolves TeamReport.users.
+func (t *teamReportResolver) Users() []*teamMemberResolver {
+	resolvers := make([]*teamMemberResolver, len(t.report.Users))
+	for i, member := range t.report.Users {
+		resolvers[i] = &teamMemberResolver{report: t, member: member}
+	}
+	return resolvers
+}
+
+// teamMemberResolver resolves TeamMember.
+type teamMemberResolver struct {
+	report *teamReportResolver
+	member reports.TeamMember
+}
+
+func (t *teamMemberResolver) Rank() int32             { return int32(t.member.Rank) }
+func (t *teamMemberResolver) Totals() *totalsResolver { return &totalsResolver{member: t.member} }
+
+// User resolves TeamMember.user for all members of the report at once.
+func (t *teamMemberResolver) User() (*userResolver, error) {
+	return t.report.users.get(t.member.UserID, nil, t.report.resolver.loadUsers)
+}
@@ -0,0 +1,316 @@
+package graphqlapi
+
+import (
+	"errors"
+	"strings"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"time-tracker-go/services"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// taskFilterInput is the TaskFilter input.
+type taskFilterInput struct {
+	ProjectIDs *[]graphql.ID
+	ClientIDs  *[]graphql.ID
+	TagsAny    *[]string
+	TagsAll    *[]string
+	TagsNone   *[]string
+	Billable   *bool
+}
+
+// toFilter converts the input to a task filter. A nil input does not restrict anything.
+func (in *taskFilterInput) toFilter() (reports.TaskFilter, error) {
+	var filter reports.TaskFilter
+	if in == nil {
+		return filter, nil
+	}
+
+	var err error
+	if filter.ProjectIDs, err = parseIDs(in.ProjectIDs); err != nil {
+		return filter, err
+	}
+	if filter.ClientIDs, err = parseIDs(in.ClientIDs); err != nil {
+		return filter, err
+	}
+	filter.TagsAny = tagNames(in.TagsAny)
+	filter.TagsAll = tagNames(in.TagsAll)
+	filter.TagsNone = tagNames(in.TagsNone)
+	filter.Billable = in.Billable
+	return filter, nil
+}
+
+// tagNames trims and lower-cases tag names like the REST query parameters, dropping empty ones.
+func tagNames(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	var names []string
+	for _, value := range *values {
+		if name := strings.ToLower(strings.TrimSpace(value)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// taskQueryInput is the TaskQuery input.
+type taskQueryInput struct {
+	Statuses    *[]string
+	Filter      *taskFilterInput
+	CreatedFrom *graphql.Time
+	CreatedTo   *graphql.Time
+	Deleted     *bool
+}
+
+// toQuery converts the input to a task query. A nil input selects all active tasks.
+func (in *taskQueryInput) toQuery() (services.TaskQuery, error) {
+	var query services.TaskQuery
+	if in == nil {
+		return query, nil
+	}
+
+	if in.Statuses != nil {
+		for _, value := range *in.Statuses {
+			status := models.TaskStatus(value)
+			if !status.IsValid() {
+				return query, invalid("Invalid status %q", value)
+			}
+			query.Statuses = append(query.Statuses, status)
+		}
+	}
+
+	var err error
+	if query.Filter, err = in.Filter.toFilter(); err != nil {
+		return query, err
+	}
+	if in.CreatedFrom != nil {
+		query.CreatedFrom = &in.CreatedFrom.Time
+	}
+	if in.CreatedTo != nil {
+		query.CreatedTo = &in.CreatedTo.Time
+	}
+	query.Deleted = in.Deleted != nil && *in.Deleted
+	return query, nil
+}
+
+// Tasks resolves Query.tasks.
+func (r *Resolver) Tasks(args struct {
+	UserID   graphql.ID
+	Query    *taskQueryInput
+	Page     *int32
+	PageSize *int32
+}) ([]*taskResolver, error) {
+	userID, err := parseID(args.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := args.Query.toQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := r.tasks.List(userID, query, toPage(args.Page, args.PageSize))
+	if err != nil {
+		return nil, toError(err)
+	}
+	return r.newTasks(tasks), nil
+}
+
+// Task resolves Query.task.
+func (r *Resolver) Task(args struct {
+	UserID graphql.ID
+	TaskID graphql.ID
+}) (*taskResolver, error) {
+	userID, taskID, err := parseTaskIDs(args.UserID, args.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := r.tasks.Get(userID, taskID)
+	if errors.Is(err, services.ErrTaskNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, toError(err)
+	}
+	return r.newTasks([]models.Task{task})[0], nil
+}
+
+// addTaskInput is the AddTaskInput input.
+type addTaskInput struct {
+	Description       string
+	ProjectID         *graphql.ID
+	TagIDs            *[]graphql.ID
+	Billable          *bool
+	EstimatedDuration *int32
+	StartTime         *graphql.Time
+	EndTime           *graphql.Time
+}
+
+// AddTask resolves Mutation.addTask.
+func (r *Resolver) AddTask(args struct {
+	UserID graphql.ID
+	Input  addTaskInput
+}) (*taskResolver, error) {
+	userID, err := parseID(args.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := args.Input
+	task := models.Task{
+		Description: input.Description,
+		Billable:    input.Billable != nil && *input.Billable,
+	}
+
+	if input.ProjectID != nil {
+		projectID, err := parseID(*input.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		task.ProjectID = &projectID
+	}
+
+	tagIDs, err := parseIDs(input.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, tagID := range tagIDs {
+		var tag models.Tag
+		tag.ID = tagID
+		task.Tags = append(task.Tags, tag)
+	}
+
+	if input.EstimatedDuration != nil {
+		estimatedDuration := int(*input.EstimatedDuration)
+		task.EstimatedDuration = &estimatedDuration
+	}
+
+	if input.StartTime != nil {
+		task.StartTime = input.StartTime.Time
+	}
+	if input.EndTime != nil {
+		task.EndTime = input.EndTime.Time
+	}
+
+	task, err = r.tasks.Add(userID, task)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return r.newTasks([]models.Task{task})[0], nil
+}
+
+// StartTask resolves Mutation.startTask.
+func (r *Resolver) StartTask(args struct {
+	UserID graphql.ID
+	TaskID graphql.ID
+	Switch *bool
+}) (*taskResolver, error) {
+	return r.apply(args.UserID, args.TaskID, models.TaskActionStart, args.Switch != nil && *args.Switch)
+}
+
+// EndTask resolves Mutation.endTask.
+func (r *Resolver) EndTask(args struct {
+	UserID graphql.ID
+	TaskID graphql.ID
+}) (*taskResolver, error) {
+	return r.apply(args.UserID, args.TaskID, models.TaskActionEnd, false)
+}
+
+// apply moves the task through the lifecycle action.
+func (r *Resolver) apply(userID, taskID graphql.ID, action models.TaskAction, switchRunning bool) (*taskResolver, error) {
+	user, task, err := parseTaskIDs(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.tasks.Apply(user, task, action, switchRunning)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return r.newTasks([]models.Task{result})[0], nil
+}
+
+// parseTaskIDs converts the IDs of a user and their task.
+func parseTaskIDs(userID, taskID graphql.ID) (uint, uint, error) {
+	user, err := parseID(userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	task, err := parseID(taskID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return user, task, nil
+}
+
+// taskResolver resolves Task.
+type taskResolver struct {
+	task  models.Task
+	batch *taskBatch
+}
+
+// taskBatch loads the owners of a list of tasks.
+type taskBatch struct {
+	resolver *Resolver
+	users    *batch[*userResolver]
+}
+
+// newTasks creates resolvers for a list of tasks that load their owners together.
+func (r *Resolver) newTasks(tasks []models.Task) []*taskResolver {
+	userIDs := make([]uint, len(tasks))
+	for i, task := range tasks {
+		userIDs[i] = task.UserID
+	}
+
+	batch := &taskBatch{resolver: r, users: newBatch[*userResolver](userIDs)}
+
+	resolvers := make([]*taskResolver, len(tasks))
+	for i, task := range tasks {
+		resolvers[i] = &taskResolver{task: task, batch: batch}
+	}
+	return resolvers
+}
+
+func (t *taskResolver) ID() graphql.ID                 { return toID(t.task.ID) }
+func (t *taskResolver) UserID() graphql.ID             { return toID(t.task.UserID) }
+func (t *taskResolver) Description() string            { return t.task.Description }
+func (t *taskResolver) Status() string                 { return string(t.task.Status) }
+func (t *taskResolver) Billable() bool                 { return t.task.Billable }
+func (t *taskResolver) StartTime() *graphql.Time       { return toOptionalTime(t.task.StartTime) }
+func (t *taskResolver) EndTime() *graphql.Time         { return toOptionalTime(t.task.EndTime) }
+func (t *taskResolver) Duration() int32                { return int32(t.task.Duration) }
+func (t *taskResolver) CreatedAt() graphql.Time        { return toTime(t.task.CreatedAt) }
+func (t *taskResolver) UpdatedAt() graphql.Time        { return toTime(t.task.UpdatedAt) }
+func (t *taskResolver) Estimate() *estimateResolver    { return newEstimate(t.task.Estimate) }
+func (t *taskResolver) Project() *projectResolver      { return newProject(t.task.Project) }
+func (t *taskResolver) ProjectID() *graphql.ID         { return optionalID(t.task.ProjectID) }
+func (t *taskResolver) EstimatedDuration() *int32      { return optionalInt(t.task.EstimatedDuration) }
+func (t *taskResolver) Tags() []*tagResolver           { return newTags(t.task.Tags) }
+func (t *taskResolver) Intervals() []*intervalResolver { return newIntervals(t.task.Intervals) }
+
+// User resolves Task.user for all tasks of the list at once. It is null for tasks of deleted users.
+func (t *taskResolver) User() (*userResolver, error) {
+	return t.batch.users.get(t.task.UserID, nil, t.batch.resolver.loadUsers)
+}
+
+// optionalID converts an optional model ID to a GraphQL ID.
+func optionalID(id *uint) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+	result := toID(*id)
+	return &result
+}
+
+// optionalInt converts an optional int to a GraphQL Int.
+func optionalInt(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+	result := int32(*value)
+	return &result
+}
@@ -0,0 +1,212 @@
+package graphqlapi
+
+import (
+	"errors"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"time-tracker-go/services"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// userFilterInput is the UserFilter input.
+type userFilterInput struct {
+	IDs            *[]graphql.ID
+	PassportNumber *string
+	Surname        *string
+	Name           *string
+	Patronymic     *string
+	Address        *string
+	City           *string
+}
+
+// toFilter converts the input to a user filter. A nil input selects all users.
+func (in *userFilterInput) toFilter() (services.UserFilter, error) {
+	if in == nil {
+		return services.UserFilter{}, nil
+	}
+
+	ids, err := parseIDs(in.IDs)
+	if err != nil {
+		return services.UserFilter{}, err
+	}
+
+	return services.UserFilter{
+		IDs:            ids,
+		PassportNumber: value(in.PassportNumber),
+		Surname:        value(in.Surname),
+		Name:           value(in.Name),
+		Patronymic:     value(in.Patronymic),
+		Address:        value(in.Address),
+		City:           value(in.City),
+	}, nil
+}
+
+// Users resolves Query.users.
+func (r *Resolver) Users(args struct {
+	Filter   *userFilterInput
+	Page     *int32
+	PageSize *int32
+}) ([]*userResolver, error) {
+	filter, err := args.Filter.toFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := r.users.List(filter, toPage(args.Page, args.PageSize))
+	if err != nil {
+		return nil, toError(err)
+	}
+	return r.newUsers(users), nil
+}
+
+// User resolves Query.user.
+func (r *Resolver) User(args struct{ ID graphql.ID }) (*userResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.users.Get(id)
+	if errors.Is(err, services.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, toError(err)
+	}
+	return r.newUsers([]models.User{user})[0], nil
+}
+
+// userResolver resolves User.
+type userResolver struct {
+	user  models.User
+	batch *userBatch
+}
+
+// userBatch loads the tasks and totals of a list of users.
+type userBatch struct {
+	resolver *Resolver
+	tasks    *batch[[]*taskResolver]
+	totals   *batch[reports.TeamMember]
+}
+
+// newUsers creates resolvers for a list of users that load their fields together.
+func (r *Resolver) newUsers(users []models.User) []*userResolver {
+	ids := make([]uint, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	batch := &userBatch{
+		resolver: r,
+		tasks:    newBatch[[]*taskResolver](ids),
+		totals:   newBatch[reports.TeamMember](ids),
+	}
+
+	resolvers := make([]*userResolver, len(users))
+	for i, user := range users {
+		resolvers[i] = &userResolver{user: user, batch: batch}
+	}
+	return resolvers
+}
+
+// loadUsers loads the users with the IDs as resolvers that load their fields together.
+func (r *Resolver) loadUsers(ids []uint) (map[uint]*userResolver, error) {
+	users, err := r.users.List(services.UserFilter{IDs: ids}, services.Page{Size: len(ids)})
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	result := make(map[uint]*userResolver, len(users))
+	for _, resolver := range r.newUsers(users) {
+		result[resolver.user.ID] = resolver
+	}
+	return result, nil
+}
+
+func (u *userResolver) ID() graphql.ID          { return toID(u.user.ID) }
+func (u *userResolver) PassportNumber() string  { return u.user.PassportNumber }
+func (u *userResolver) Surname() string         { return u.user.Surname }
+func (u *userResolver) Name() string            { return u.user.Name }
+func (u *userResolver) Patronymic() string      { return u.user.Patronymic }
+func (u *userResolver) Address() string         { return u.user.Address }
+func (u *userResolver) CreatedAt() graphql.Time { return toTime(u.user.CreatedAt) }
+func (u *userResolver) UpdatedAt() graphql.Time { return toTime(u.user.UpdatedAt) }
+
+// Tasks resolves User.tasks for all users of the list at once.
+func (u *userResolver) Tasks(args struct {
+	Query    *taskQueryInput
+	Page     *int32
+	PageSize *int32
+}) ([]*taskResolver, error) {
+	query, err := args.Query.toQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.batch.tasks.get(u.user.ID, args, func(ids []uint) (map[uint][]*taskResolver, error) {
+		byUser, err := u.batch.resolver.tasks.ListForUsers(ids, query, toPage(args.Page, args.PageSize))
+		if err != nil {
+			return nil, toError(err)
+		}
+
+		var tasks []models.Task
+		for _, id := range ids {
+			tasks = append(tasks, byUser[id]...)
+		}
+
+		result := make(map[uint][]*taskResolver, len(ids))
+		for _, resolver := range u.batch.resolver.newTasks(tasks) {
+			result[resolver.task.UserID] = append(result[resolver.task.UserID], resolver)
+		}
+		return result, nil
+	})
+}
+
+// Totals resolves User.totals for all users of the list at once.
+func (u *userResolver) Totals(args struct {
+	StartDate graphql.Time
+	EndDate   graphql.Time
+	Filter    *taskFilterInput
+}) (*totalsResolver, error) {
+	period, err := toPeriod(args.StartDate, args.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := args.Filter.toFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := u.batch.totals.get(u.user.ID, args, func(ids []uint) (map[uint]reports.TeamMember, error) {
+		report, err := reports.Team(u.batch.resolver.db, period, filter, time.Now(), services.UserFilter{IDs: ids}.Scope)
+		if err != nil {
+			return nil, toError(err)
+		}
+
+		result := make(map[uint]reports.TeamMember, len(report.Users))
+		for _, member := range report.Users {
+			result[member.UserID] = member
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &totalsResolver{member: member}, nil
+}
+
+// totalsResolver resolves Totals.
+type totalsResolver struct {
+	member reports.TeamMember
+}
+
+func (t *totalsResolver) Duration() int32            { return int32(t.member.Duration) }
+func (t *totalsResolver) Hours() float64             { return t.member.Hours }
+func (t *totalsResolver) TaskCount() int32           { return int32(t.member.TaskCount) }
+func (t *totalsResolver) AverageTaskDuration() int32 { return int32(t.member.AverageTaskDuration) }
+func (t *totalsResolver) BillableDuration() int32    { return int32(t.member.BillableDuration) }
+func (t *totalsResolver) BillableHours() float64     { return t.member.BillableHours }
+func (t *totalsResolver) Amount() float64            { return t.member.Amount }
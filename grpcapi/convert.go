@@ -0,0 +1,171 @@
+package grpcapi
+
+import (
+	"strings"
+	"time"
+	"time-tracker-go/grpcapi/timetrackerpb"
+	"time-tracker-go/live"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timestamp converts a time to a protobuf timestamp. Zero times are left unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeOf converts a protobuf timestamp to a time. Unset timestamps yield the zero time.
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// toUser converts a user to its message.
+func toUser(user models.User) *timetrackerpb.User {
+	return &timetrackerpb.User{
+		Id:             uint64(user.ID),
+		PassportNumber: user.PassportNumber,
+		Surname:        user.Surname,
+		Name:           user.Name,
+		Patronymic:     user.Patronymic,
+		Address:        user.Address,
+		CreatedAt:      timestamp(user.CreatedAt),
+		UpdatedAt:      timestamp(user.UpdatedAt),
+	}
+}
+
+// toTask converts a task with its intervals and tags to its message.
+func toTask(task models.Task) *timetrackerpb.Task {
+	message := &timetrackerpb.Task{
+		Id:          uint64(task.ID),
+		UserId:      uint64(task.UserID),
+		Description: task.Description,
+		Status:      string(task.Status),
+		Billable:    task.Billable,
+		StartTime:   timestamp(task.StartTime),
+		EndTime:     timestamp(task.EndTime),
+		Duration:    int32(task.Duration),
+		CreatedAt:   timestamp(task.CreatedAt),
+		UpdatedAt:   timestamp(task.UpdatedAt),
+	}
+
+	if task.ProjectID != nil {
+		projectID := uint64(*task.ProjectID)
+		message.ProjectId = &projectID
+	}
+
+	if task.EstimatedDuration != nil {
+		estimatedDuration := int32(*task.EstimatedDuration)
+		message.EstimatedDuration = &estimatedDuration
+	}
+
+	if task.Estimate != nil {
+		message.Estimate = &timetrackerpb.Estimate{
+			Estimated: int32(task.Estimate.Estimated),
+			Actual:    int32(task.Estimate.Actual),
+			Remaining: int32(task.Estimate.Remaining),
+			Usage:     task.Estimate.Usage,
+			Exceeded:  task.Estimate.Exceeded,
+		}
+	}
+
+	for _, tag := range task.Tags {
+		message.Tags = append(message.Tags, &timetrackerpb.Tag{Id: uint64(tag.ID), Name: tag.Name, Color: tag.Color})
+	}
+
+	for _, interval := range task.Intervals {
+		converted := &timetrackerpb.Interval{Id: uint64(interval.ID), StartTime: timestamp(interval.StartTime)}
+		if interval.EndTime != nil {
+			converted.EndTime = timestamp(*interval.EndTime)
+		}
+		message.Intervals = append(message.Intervals, converted)
+	}
+
+	return message
+}
+
+// toTasks converts tasks to their messages.
+func toTasks(tasks []models.Task) []*timetrackerpb.Task {
+	messages := make([]*timetrackerpb.Task, len(tasks))
+	for i, task := range tasks {
+		messages[i] = toTask(task)
+	}
+	return messages
+}
+
+// toTimeEntries converts time entries to their message.
+func toTimeEntries(entries reports.TimeEntries) *timetrackerpb.TimeEntries {
+	message := &timetrackerpb.TimeEntries{
+		UserId:                uint64(entries.UserID),
+		StartTime:             timestamp(entries.StartDate),
+		EndTime:               timestamp(entries.EndDate),
+		TotalDuration:         int32(entries.TotalDuration),
+		TotalBillableDuration: int32(entries.TotalBillableDuration),
+		TotalAmount:           entries.TotalAmount,
+	}
+	for _, entry := range entries.Entries {
+		message.Entries = append(message.Entries, &timetrackerpb.TimeEntry{
+			Task:           toTask(entry.Task),
+			PeriodDuration: int32(entry.PeriodDuration),
+			PeriodAmount:   entry.PeriodAmount,
+		})
+	}
+	return message
+}
+
+// toTimerEvent converts a live event to its message.
+func toTimerEvent(event live.Event) *timetrackerpb.TimerEvent {
+	message := &timetrackerpb.TimerEvent{
+		Id:     event.ID,
+		Type:   event.Type,
+		UserId: uint64(event.UserID),
+		Time:   timestamp(event.Time),
+	}
+	if task, ok := event.Data.(models.Task); ok {
+		message.Task = toTask(task)
+	}
+	return message
+}
+
+// taskFilter converts a task filter message. Tag names are normalized like the REST query parameters.
+func taskFilter(filter *timetrackerpb.TaskFilter) reports.TaskFilter {
+	var result reports.TaskFilter
+	if filter == nil {
+		return result
+	}
+
+	result.ProjectIDs = ids(filter.ProjectIds)
+	result.ClientIDs = ids(filter.ClientIds)
+	result.TagsAny = tagNames(filter.TagsAny)
+	result.TagsAll = tagNames(filter.TagsAll)
+	result.TagsNone = tagNames(filter.TagsNone)
+	result.Billable = filter.Billable
+	return result
+}
+
+// ids converts message IDs to model IDs.
+func ids(values []uint64) []uint {
+	var result []uint
+	for _, value := range values {
+		result = append(result, uint(value))
+	}
+	return result
+}
+
+// tagNames trims and lower-cases tag names, dropping empty ones.
+func tagNames(values []string) []string {
+	var names []string
+	for _, value := range values {
+		if name := strings.ToLower(strings.TrimSpace(value)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
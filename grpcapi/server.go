@@ -0,0 +1,107 @@
+// Package grpcapi serves the user and task operations over gRPC. It calls the same services
+// as the REST controllers and maps their errors to gRPC status codes.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time-tracker-go/grpcapi/timetrackerpb"
+	"time-tracker-go/live"
+	"time-tracker-go/models"
+	"time-tracker-go/services"
+	"time-tracker-go/timesheets"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the ErrorInfo details of conflicts.
+const errorDomain = "time-tracker-go"
+
+// NewServer creates a gRPC server with the user and task services registered.
+func NewServer(users *services.Users, tasks *services.Tasks, broker *live.Broker) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(logUnary),
+		grpc.StreamInterceptor(logStream),
+	)
+	timetrackerpb.RegisterUserServiceServer(server, &userServer{Users: users})
+	timetrackerpb.RegisterTaskServiceServer(server, &taskServer{Tasks: tasks, Users: users, Live: broker})
+	// Reflection lets clients like grpcurl discover the services without the .proto files.
+	reflection.Register(server)
+	return server
+}
+
+// logUnary logs unary calls like the REST router logs requests.
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	log.Printf("gRPC %s", info.FullMethod)
+	resp, err := handler(ctx, req)
+	if err != nil {
+		log.Printf("gRPC %s failed: %v", info.FullMethod, err)
+	}
+	return resp, err
+}
+
+// logStream logs streaming calls.
+func logStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	log.Printf("gRPC %s", info.FullMethod)
+	err := handler(srv, stream)
+	if err != nil {
+		log.Printf("gRPC %s ended: %v", info.FullMethod, err)
+	}
+	return err
+}
+
+// toStatus maps an error returned by a service to a gRPC status error.
+func toStatus(err error) error {
+	var invalidErr *services.InvalidError
+	var transitionErr *models.TransitionError
+	var runningErr *models.RunningTaskError
+	var lockedErr *timesheets.LockedError
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &invalidErr):
+		return status.Error(codes.InvalidArgument, invalidErr.Message)
+	case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrTaskNotFound), errors.Is(err, services.ErrDeletedTaskNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrDeleteRunning):
+		return conflict(err.Error(), models.ReasonTaskRunning, map[string]string{"status": string(models.TaskStatusRunning)})
+	case errors.Is(err, services.ErrTaskInvoiced):
+		return conflict(err.Error(), models.ReasonTaskInvoiced, nil)
+	case errors.As(err, &transitionErr):
+		return conflict(err.Error(), transitionErr.Reason, map[string]string{
+			"status": string(transitionErr.Status),
+			"action": string(transitionErr.Action),
+		})
+	case errors.As(err, &runningErr):
+		return conflict(err.Error(), models.ReasonAnotherTaskRunning, map[string]string{
+			"runningTaskID": strconv.FormatUint(uint64(runningErr.TaskID), 10),
+		})
+	case errors.As(err, &lockedErr):
+		return conflict(err.Error(), models.ReasonPeriodLocked, map[string]string{
+			"weekStart": lockedErr.WeekStart.Format("2006-01-02"),
+		})
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		// The database index guarantees a single running task even if the row lock was bypassed.
+		return conflict("another task is already running", models.ReasonAnotherTaskRunning, nil)
+	case errors.Is(err, services.ErrExternalAPI):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// conflict returns a FAILED_PRECONDITION status error with the machine-readable reason as ErrorInfo.
+func conflict(message, reason string, metadata map[string]string) error {
+	st := status.New(codes.FailedPrecondition, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain, Metadata: metadata})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
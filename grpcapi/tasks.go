@@ -0,0 +1,240 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+	"time-tracker-go/grpcapi/timetrackerpb"
+	"time-tracker-go/live"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"time-tracker-go/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// taskServer implements TaskService with the task service.
+type taskServer struct {
+	timetrackerpb.UnimplementedTaskServiceServer
+	Tasks *services.Tasks
+	Users *services.Users
+	Live  *live.Broker
+}
+
+// ListTasks lists tasks of a user, newest first, a page at a time.
+func (s *taskServer) ListTasks(ctx context.Context, req *timetrackerpb.ListTasksRequest) (*timetrackerpb.ListTasksResponse, error) {
+	query := services.TaskQuery{
+		Filter:  taskFilter(req.Filter),
+		Deleted: req.Deleted,
+	}
+
+	for _, value := range req.Statuses {
+		taskStatus := models.TaskStatus(value)
+		if !taskStatus.IsValid() {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid status %q", value)
+		}
+		query.Statuses = append(query.Statuses, taskStatus)
+	}
+
+	if req.CreatedFrom != nil {
+		createdFrom := req.CreatedFrom.AsTime()
+		query.CreatedFrom = &createdFrom
+	}
+	if req.CreatedTo != nil {
+		createdTo := req.CreatedTo.AsTime()
+		query.CreatedTo = &createdTo
+	}
+
+	tasks, err := s.Tasks.List(uint(req.UserId), query, services.Page{Number: int(req.Page), Size: int(req.PageSize)})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &timetrackerpb.ListTasksResponse{Tasks: toTasks(tasks)}, nil
+}
+
+// GetTask returns a task of a user with its intervals.
+func (s *taskServer) GetTask(ctx context.Context, req *timetrackerpb.TaskRequest) (*timetrackerpb.Task, error) {
+	task, err := s.Tasks.Get(uint(req.UserId), uint(req.TaskId))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(task), nil
+}
+
+// AddTask adds a task for a user.
+func (s *taskServer) AddTask(ctx context.Context, req *timetrackerpb.AddTaskRequest) (*timetrackerpb.Task, error) {
+	task := models.Task{
+		Description: req.Description,
+		Billable:    req.Billable,
+		StartTime:   timeOf(req.StartTime),
+		EndTime:     timeOf(req.EndTime),
+	}
+
+	if req.ProjectId != nil {
+		projectID := uint(*req.ProjectId)
+		task.ProjectID = &projectID
+	}
+
+	if req.EstimatedDuration != nil {
+		estimatedDuration := int(*req.EstimatedDuration)
+		task.EstimatedDuration = &estimatedDuration
+	}
+
+	for _, tagID := range req.TagIds {
+		var tag models.Tag
+		tag.ID = uint(tagID)
+		task.Tags = append(task.Tags, tag)
+	}
+
+	task, err := s.Tasks.Add(uint(req.UserId), task)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(task), nil
+}
+
+// UpdateTask updates the editable fields of a task.
+func (s *taskServer) UpdateTask(ctx context.Context, req *timetrackerpb.UpdateTaskRequest) (*timetrackerpb.Task, error) {
+	changes := services.TaskChanges{
+		Description: req.Description,
+		Billable:    req.Billable,
+	}
+
+	if req.ProjectId != nil {
+		projectID := uint(*req.ProjectId)
+		changes.ProjectID = &projectID
+	}
+
+	if req.ReplaceTags {
+		tagIDs := ids(req.TagIds)
+		changes.TagIDs = &tagIDs
+	}
+
+	if req.EstimatedDuration != nil {
+		estimatedDuration := int(*req.EstimatedDuration)
+		changes.EstimatedDuration = &estimatedDuration
+	}
+
+	task, err := s.Tasks.Update(uint(req.UserId), uint(req.TaskId), changes)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(task), nil
+}
+
+// DeleteTask soft-deletes a task.
+func (s *taskServer) DeleteTask(ctx context.Context, req *timetrackerpb.TaskRequest) (*timetrackerpb.DeleteTaskResponse, error) {
+	if err := s.Tasks.Delete(uint(req.UserId), uint(req.TaskId)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &timetrackerpb.DeleteTaskResponse{}, nil
+}
+
+// RestoreTask restores a soft-deleted task.
+func (s *taskServer) RestoreTask(ctx context.Context, req *timetrackerpb.TaskRequest) (*timetrackerpb.Task, error) {
+	task, err := s.Tasks.Restore(uint(req.UserId), uint(req.TaskId))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(task), nil
+}
+
+// StartTask starts a created task.
+func (s *taskServer) StartTask(ctx context.Context, req *timetrackerpb.TaskActionRequest) (*timetrackerpb.Task, error) {
+	return s.apply(req, models.TaskActionStart)
+}
+
+// PauseTask pauses a running task.
+func (s *taskServer) PauseTask(ctx context.Context, req *timetrackerpb.TaskActionRequest) (*timetrackerpb.Task, error) {
+	return s.apply(req, models.TaskActionPause)
+}
+
+// ResumeTask resumes a paused task.
+func (s *taskServer) ResumeTask(ctx context.Context, req *timetrackerpb.TaskActionRequest) (*timetrackerpb.Task, error) {
+	return s.apply(req, models.TaskActionResume)
+}
+
+// EndTask ends a running or paused task.
+func (s *taskServer) EndTask(ctx context.Context, req *timetrackerpb.TaskActionRequest) (*timetrackerpb.Task, error) {
+	return s.apply(req, models.TaskActionEnd)
+}
+
+// CancelTask cancels a task that is not finished yet.
+func (s *taskServer) CancelTask(ctx context.Context, req *timetrackerpb.TaskActionRequest) (*timetrackerpb.Task, error) {
+	return s.apply(req, models.TaskActionCancel)
+}
+
+// apply moves the task through the lifecycle action.
+func (s *taskServer) apply(req *timetrackerpb.TaskActionRequest, action models.TaskAction) (*timetrackerpb.Task, error) {
+	task, err := s.Tasks.Apply(uint(req.UserId), uint(req.TaskId), action, req.Switch)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(task), nil
+}
+
+// GetTimeEntries returns the time a user spent on tasks within a period.
+func (s *taskServer) GetTimeEntries(ctx context.Context, req *timetrackerpb.GetTimeEntriesRequest) (*timetrackerpb.TimeEntries, error) {
+	period := reports.Period{From: timeOf(req.StartTime), To: timeOf(req.EndTime)}
+	if req.StartTime == nil || req.EndTime == nil || !period.To.After(period.From) {
+		return nil, status.Error(codes.InvalidArgument, "end_time must be after start_time")
+	}
+
+	entries, err := s.Tasks.TimeEntries(uint(req.UserId), period, taskFilter(req.Filter))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTimeEntries(entries), nil
+}
+
+// WatchTimers streams timer changes until the client cancels the call. A stream that falls too far
+// behind is ended with UNAVAILABLE; the client resumes it with the ID of the last event it received.
+func (s *taskServer) WatchTimers(req *timetrackerpb.WatchTimersRequest, stream timetrackerpb.TaskService_WatchTimersServer) error {
+	userID := uint(req.UserId)
+	if userID != 0 {
+		if _, err := s.Users.Get(userID); err != nil {
+			return toStatus(err)
+		}
+	}
+
+	subscription, missed, complete, lastID := s.Live.Subscribe(userID, req.LastEventId)
+	defer subscription.Close()
+
+	if req.LastEventId == 0 || !complete {
+		running, err := s.Tasks.Running(userID)
+		if err != nil {
+			return toStatus(err)
+		}
+		snapshot := &timetrackerpb.TimerEvent{
+			Id:      lastID,
+			Type:    "snapshot",
+			UserId:  uint64(userID),
+			Time:    timestamp(time.Now()),
+			Running: toTasks(running),
+		}
+		if err := stream.Send(snapshot); err != nil {
+			return err
+		}
+		missed = nil
+	}
+
+	for _, event := range missed {
+		if err := stream.Send(toTimerEvent(event)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return status.Error(codes.Unavailable, "the stream fell behind; resume it with last_event_id")
+			}
+			if err := stream.Send(toTimerEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package grpcapi
+
+import (
+	"context"
+	"time-tracker-go/grpcapi/timetrackerpb"
+	"time-tracker-go/models"
+	"time-tracker-go/services"
+)
+
+// userServer implements UserService with the user service.
+type userServer struct {
+	timetrackerpb.UnimplementedUserServiceServer
+	Users *services.Users
+}
+
+// ListUsers lists users matching the filters, a page at a time.
+func (s *userServer) ListUsers(ctx context.Context, req *timetrackerpb.ListUsersRequest) (*timetrackerpb.ListUsersResponse, error) {
+	filter := services.UserFilter{
+		IDs:            ids(req.Ids),
+		PassportNumber: req.PassportNumber,
+		Surname:        req.Surname,
+		Name:           req.Name,
+		Patronymic:     req.Patronymic,
+		Address:        req.Address,
+		City:           req.City,
+	}
+
+	users, err := s.Users.List(filter, services.Page{Number: int(req.Page), Size: int(req.PageSize)})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &timetrackerpb.ListUsersResponse{}
+	for _, user := range users {
+		response.Users = append(response.Users, toUser(user))
+	}
+	return response, nil
+}
+
+// GetUser returns a user by ID.
+func (s *userServer) GetUser(ctx context.Context, req *timetrackerpb.GetUserRequest) (*timetrackerpb.User, error) {
+	user, err := s.Users.Get(uint(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUser(user), nil
+}
+
+// AddUser adds a user by passport number.
+func (s *userServer) AddUser(ctx context.Context, req *timetrackerpb.AddUserRequest) (*timetrackerpb.User, error) {
+	user, err := s.Users.Add(req.PassportNumber)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUser(user), nil
+}
+
+// UpdateUser replaces the personal details of a user.
+func (s *userServer) UpdateUser(ctx context.Context, req *timetrackerpb.UpdateUserRequest) (*timetrackerpb.User, error) {
+	user, err := s.Users.Update(uint(req.Id), models.User{
+		PassportNumber: req.PassportNumber,
+		Surname:        req.Surname,
+		Name:           req.Name,
+		Patronymic:     req.Patronymic,
+		Address:        req.Address,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUser(user), nil
+}
+
+// DeleteUser deletes a user.
+func (s *userServer) DeleteUser(ctx context.Context, req *timetrackerpb.DeleteUserRequest) (*timetrackerpb.DeleteUserResponse, error) {
+	if _, err := s.Users.Delete(uint(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &timetrackerpb.DeleteUserResponse{}, nil
+}
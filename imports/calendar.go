@@ -0,0 +1,284 @@
+package imports
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"gorm.io/gorm"
+)
+
+// Reasons for which calendar events are not imported.
+const (
+	SkipCancelled             = "cancelled"
+	SkipAllDay                = "all_day"
+	SkipNoEnd                 = "no_end"
+	SkipInvalidInterval       = "invalid_interval"
+	SkipNotFinished           = "not_finished"
+	SkipOutsidePeriod         = "outside_period"
+	SkipOwnEvent              = "exported_by_tracker"
+	SkipDuplicate             = "duplicate"
+	SkipAlreadyImported       = "already_imported"
+	SkipUnsupportedRecurrence = "unsupported_recurrence"
+)
+
+// calendarExternalIDPrefix prefixes the UIDs of imported events in Task.ExternalID.
+const calendarExternalIDPrefix = "ics:"
+
+// recurrenceIDLayout formats the original start of an occurrence in Task.ExternalID.
+const recurrenceIDLayout = "20060102T150405Z"
+
+// CalendarOptions control how calendar events are imported.
+type CalendarOptions struct {
+	UserID    uint            // User the tasks are created for
+	ProjectID *uint           // Project the tasks are assigned to (nil to leave them unassigned)
+	Period    *reports.Period // Only events starting within the period are imported (nil for all events)
+	DryRun    bool            // Report what would be imported without creating any tasks
+	OwnDomain string          // Domain of the UIDs of events exported by this service, which are never imported
+}
+
+// SkippedEvent is a calendar event that was not imported.
+type SkippedEvent struct {
+	UID     string    `json:"uid"`     // UID of the event
+	Summary string    `json:"summary"` // Title of the event
+	Start   time.Time `json:"start"`   // Start of the event
+	Reason  string    `json:"reason"`  // Machine-readable reason the event was skipped
+}
+
+// CalendarResult is the outcome of a calendar import.
+type CalendarResult struct {
+	DryRun  bool           `json:"dryRun"`  // Whether the import was a preview only
+	Created int            `json:"created"` // Number of tasks created (or that would be created in a dry run)
+	Tasks   []models.Task  `json:"tasks"`   // Created tasks; in a dry run they have no IDs
+	Skipped []SkippedEvent `json:"skipped"` // Events that were not imported
+}
+
+// ImportCalendar turns calendar events into done tasks of the user with a single closed interval each.
+//
+// Events are identified by their UID (and the original start for occurrences of recurring events), so
+// importing the same calendar again creates only the tasks for new events. Recurring events are imported
+// as their occurrences within the period. Event categories are matched to existing tags by name.
+// Events in weeks whose timesheets are approved are skipped.
+// The events are checked against the database and the tasks are created in one transaction.
+func ImportCalendar(db *gorm.DB, events []CalendarEvent, options CalendarOptions, now time.Time) (CalendarResult, error) {
+	result := CalendarResult{DryRun: options.DryRun, Tasks: []models.Task{}, Skipped: []SkippedEvent{}}
+
+	events, result.Skipped = expandRecurrences(events, options.Period, now)
+	candidates := make([]CalendarEvent, 0, len(events))
+	externalIDs := make([]string, 0, len(events))
+	seen := map[string]bool{}
+	for _, event := range events {
+		externalID := calendarExternalID(event)
+		reason := ""
+		switch {
+		case event.Cancelled:
+			reason = SkipCancelled
+		case event.AllDay:
+			reason = SkipAllDay
+		case event.End.IsZero():
+			reason = SkipNoEnd
+		case !event.End.After(event.Start):
+			reason = SkipInvalidInterval
+		case event.End.After(now):
+			reason = SkipNotFinished
+		case options.Period != nil && (event.Start.Before(options.Period.From) || !event.Start.Before(options.Period.To)):
+			reason = SkipOutsidePeriod
+		case options.OwnDomain != "" && strings.HasSuffix(event.UID, "@"+options.OwnDomain):
+			reason = SkipOwnEvent
+		case seen[externalID]:
+			reason = SkipDuplicate
+		}
+		if reason != "" {
+			result.Skipped = append(result.Skipped, skippedEvent(event, reason))
+			continue
+		}
+
+		seen[externalID] = true
+		candidates = append(candidates, event)
+		externalIDs = append(externalIDs, externalID)
+	}
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	// Locking the user keeps their weeks from being approved and other imports of the same calendar
+	// from committing until the checks below are committed.
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := lockUsers(tx, []uint{options.UserID}); err != nil {
+			return err
+		}
+
+		// Soft-deleted tasks count as imported, so that deleting an imported meeting is not undone by the next import.
+		var imported []string
+		err := tx.Unscoped().Model(&models.Task{}).
+			Where("user_id = ? AND external_id IN ?", options.UserID, externalIDs).
+			Pluck("external_id", &imported).Error
+		if err != nil {
+			return err
+		}
+		alreadyImported := make(map[string]bool, len(imported))
+		for _, externalID := range imported {
+			alreadyImported[externalID] = true
+		}
+
+		tags, err := tagsByName(tx, candidates)
+		if err != nil {
+			return err
+		}
+
+		var tasks []models.Task
+		var sources []CalendarEvent
+		for i, event := range candidates {
+			if alreadyImported[externalIDs[i]] {
+				result.Skipped = append(result.Skipped, skippedEvent(event, SkipAlreadyImported))
+				continue
+			}
+
+			externalID := externalIDs[i]
+			end := event.End
+			task := models.Task{
+				UserID:      options.UserID,
+				ProjectID:   options.ProjectID,
+				Description: eventSummary(event),
+				ExternalID:  &externalID,
+				Status:      models.TaskStatusDone,
+				Tags:        []models.Tag{},
+				Intervals:   []models.TaskInterval{{StartTime: event.Start, EndTime: &end}},
+			}
+			for _, category := range event.Categories {
+				if tag, ok := tags[strings.ToLower(strings.TrimSpace(category))]; ok {
+					task.Tags = append(task.Tags, tag)
+				}
+			}
+			task.RecalculateDuration()
+			tasks = append(tasks, task)
+			sources = append(sources, event)
+		}
+
+		// Time cannot be imported into weeks whose timesheets are approved.
+		locks, err := loadLocks(tx, tasks)
+		if err != nil {
+			return err
+		}
+		for i, task := range tasks {
+			if _, locked := locks.Locked(task.UserID, taskPeriod(task)); locked {
+				result.Skipped = append(result.Skipped, skippedEvent(sources[i], SkipPeriodLocked))
+				continue
+			}
+			result.Tasks = append(result.Tasks, task)
+		}
+		result.Created = len(result.Tasks)
+
+		if options.DryRun {
+			return nil
+		}
+		for i := range result.Tasks {
+			if err := tx.Create(&result.Tasks[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// calendarExternalID returns the Task.ExternalID of an event. Events without a UID are identified
+// by their start, end and summary.
+func calendarExternalID(event CalendarEvent) string {
+	uid := event.UID
+	if uid == "" {
+		sum := sha1.Sum([]byte(event.Start.UTC().String() + "|" + event.End.UTC().String() + "|" + event.Summary))
+		uid = hex.EncodeToString(sum[:])
+	}
+	if !event.RecurrenceID.IsZero() {
+		uid += "/" + event.RecurrenceID.UTC().Format(recurrenceIDLayout)
+	}
+	return calendarExternalIDPrefix + uid
+}
+
+// expandRecurrences replaces recurring events with their occurrences, each identified by the UID of the
+// event and its original start. Only occurrences that start within the period, or before now without
+// a period, are returned; occurrences overridden by events with a RECURRENCE-ID are left out, because the
+// overriding events are imported in their place. Recurring events whose rules are not supported are skipped.
+// Events that would be skipped anyway, e.g. cancelled or all-day ones, are returned as they are.
+func expandRecurrences(events []CalendarEvent, period *reports.Period, now time.Time) ([]CalendarEvent, []SkippedEvent) {
+	overridden := map[string]bool{}
+	for _, event := range events {
+		if !event.RecurrenceID.IsZero() {
+			overridden[calendarExternalID(event)] = true
+		}
+	}
+
+	from, limit := time.Time{}, now
+	if period != nil {
+		from = period.From
+		if period.To.Before(limit) {
+			limit = period.To
+		}
+	}
+
+	expanded := make([]CalendarEvent, 0, len(events))
+	skipped := []SkippedEvent{}
+	for _, event := range events {
+		if !event.Recurring() || event.Cancelled || event.AllDay || !event.End.After(event.Start) {
+			expanded = append(expanded, event)
+			continue
+		}
+
+		starts, err := occurrences(event, limit)
+		if err != nil {
+			skipped = append(skipped, skippedEvent(event, SkipUnsupportedRecurrence))
+			continue
+		}
+		for _, start := range starts {
+			occurrence := event
+			occurrence.RecurrenceID = start
+			occurrence.Start, occurrence.End = start, start.Add(event.End.Sub(event.Start))
+			occurrence.Rule, occurrence.RDates, occurrence.ExDates = "", nil, nil
+			if !start.Before(from) && !overridden[calendarExternalID(occurrence)] {
+				expanded = append(expanded, occurrence)
+			}
+		}
+	}
+	return expanded, skipped
+}
+
+// eventSummary returns the title of the event, used as the task description.
+func eventSummary(event CalendarEvent) string {
+	if summary := strings.TrimSpace(event.Summary); summary != "" {
+		return summary
+	}
+	return "Calendar event"
+}
+
+// skippedEvent describes an event that was not imported.
+func skippedEvent(event CalendarEvent, reason string) SkippedEvent {
+	return SkippedEvent{UID: event.UID, Summary: event.Summary, Start: event.Start, Reason: reason}
+}
+
+// tagsByName loads the existing tags named after the categories of the events, keyed by name.
+func tagsByName(db *gorm.DB, events []CalendarEvent) (map[string]models.Tag, error) {
+	var names []string
+	for _, event := range events {
+		for _, category := range event.Categories {
+			names = append(names, strings.ToLower(strings.TrimSpace(category)))
+		}
+	}
+
+	tagsByName := make(map[string]models.Tag, len(names))
+	if len(names) == 0 {
+		return tagsByName, nil
+	}
+
+	var tags []models.Tag
+	if err := db.Where("name IN ?", names).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		tagsByName[tag.Name] = tag
+	}
+	return tagsByName, nil
+}
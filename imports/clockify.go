@@ -0,0 +1,75 @@
+package imports
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// clockifyEntry is a time entry of the detailed report of Clockify exported as JSON.
+type clockifyEntry struct {
+	ID          string `json:"_id"`
+	AltID       string `json:"id"`
+	Description string `json:"description"`
+	UserName    string `json:"userName"`
+	ClientName  string `json:"clientName"`
+	ProjectName string `json:"projectName"`
+	TaskName    string `json:"taskName"`
+	Billable    bool   `json:"billable"`
+	Tags        []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+	TimeInterval struct {
+		Start time.Time  `json:"start"`
+		End   *time.Time `json:"end"`
+	} `json:"timeInterval"`
+}
+
+// ParseClockify reads the detailed report of Clockify exported as CSV or JSON.
+func ParseClockify(r io.Reader, options TrackerParseOptions) ([]TrackerEntry, error) {
+	options = options.withDefaults()
+	input := bufio.NewReader(r)
+	if ok, err := isJSON(input); err != nil || !ok {
+		if err != nil {
+			return nil, err
+		}
+		return readTrackerCSV(input, options)
+	}
+
+	var report struct {
+		TimeEntries []clockifyEntry `json:"timeentries"`
+	}
+	var err error
+	if head, _ := input.Peek(1); string(head) == "[" {
+		err = json.NewDecoder(input).Decode(&report.TimeEntries)
+	} else {
+		err = json.NewDecoder(input).Decode(&report)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+
+	entries := make([]TrackerEntry, len(report.TimeEntries))
+	for i, item := range report.TimeEntries {
+		entry := TrackerEntry{
+			Row:         i + 1,
+			ID:          firstNonEmpty(item.ID, item.AltID),
+			User:        item.UserName,
+			Client:      item.ClientName,
+			Project:     item.ProjectName,
+			Description: firstNonEmpty(item.Description, item.TaskName),
+			Billable:    item.Billable,
+			Start:       item.TimeInterval.Start,
+		}
+		for _, tag := range item.Tags {
+			entry.Tags = append(entry.Tags, tag.Name)
+		}
+		if item.TimeInterval.End != nil {
+			entry.End = *item.TimeInterval.End
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
@@ -0,0 +1,39 @@
+package imports
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseDelimiter parses a CSV field delimiter: a single character, "tab" or "semicolon".
+// A literal semicolon must be escaped in query strings, so it has a readable alias.
+func ParseDelimiter(value string) (rune, error) {
+	switch value {
+	case "":
+		return ',', nil
+	case "tab":
+		value = "\t"
+	case "semicolon":
+		value = ";"
+	}
+	comma, _ := utf8.DecodeRuneInString(value)
+	if utf8.RuneCountInString(value) != 1 || comma == '"' || comma == '\r' || comma == '\n' || comma == utf8.RuneError {
+		return 0, fmt.Errorf("invalid delimiter %q", value)
+	}
+	return comma, nil
+}
+
+// ParseColumnMapping parses column mappings of the form "field=Column name".
+func ParseColumnMapping(mappings []string) (map[string]string, error) {
+	columns := make(map[string]string, len(mappings))
+	for _, mapping := range mappings {
+		field, column, ok := strings.Cut(mapping, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !ok || field == "" || strings.TrimSpace(column) == "" {
+			return nil, fmt.Errorf("invalid column mapping %q, expected field=column", mapping)
+		}
+		columns[field] = column
+	}
+	return columns, nil
+}
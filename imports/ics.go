@@ -0,0 +1,291 @@
+package imports
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalendarEvent is a VEVENT read from an iCalendar file.
+type CalendarEvent struct {
+	UID          string      // Unique identifier of the event
+	RecurrenceID time.Time   // Original start of the occurrence of a recurring event that this event overrides (zero for other events)
+	Summary      string      // Title of the event
+	Start        time.Time   // Start of the event
+	End          time.Time   // End of the event (zero if the event has neither DTEND nor DURATION)
+	AllDay       bool        // Whether the event spans whole days rather than a time range
+	Rule         string      // Recurrence rule of a recurring event (RRULE)
+	RDates       []time.Time // Additional occurrences of a recurring event (RDATE)
+	ExDates      []time.Time // Occurrences excluded from a recurring event (EXDATE)
+	Cancelled    bool        // Whether the event has STATUS:CANCELLED
+	Categories   []string    // Categories of the event
+}
+
+// Recurring reports whether the event repeats.
+func (e CalendarEvent) Recurring() bool {
+	return e.Rule != "" || len(e.RDates) > 0
+}
+
+// ErrNotCalendar is returned by ParseCalendar when the input is not an iCalendar file.
+var ErrNotCalendar = errors.New("not an iCalendar file: missing BEGIN:VCALENDAR")
+
+// contentLine is an unfolded iCalendar content line: NAME;PARAM=VALUE:value.
+type contentLine struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseCalendar reads the VEVENT components of an iCalendar (RFC 5545) file.
+//
+// Dates in UTC and with a TZID parameter are converted to absolute times. Floating dates
+// and dates with a TZID that is not in the time zone database are interpreted in loc.
+// Properties of nested components such as VALARM are ignored.
+func ParseCalendar(r io.Reader, loc *time.Location) ([]CalendarEvent, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events   []CalendarEvent
+		event    *CalendarEvent
+		duration time.Duration
+		calendar bool
+		nested   int
+	)
+	for number, raw := range lines {
+		if raw == "" {
+			continue
+		}
+		line, err := parseContentLine(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+
+		switch {
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VCALENDAR"):
+			calendar = true
+		case !calendar:
+			return nil, ErrNotCalendar
+		case line.name == "BEGIN" && event == nil && strings.EqualFold(line.value, "VEVENT"):
+			event, duration = &CalendarEvent{}, 0
+		case line.name == "BEGIN" && event != nil:
+			nested++
+		case line.name == "END" && event != nil && nested > 0:
+			nested--
+		case line.name == "END" && event != nil:
+			if event.End.IsZero() && duration > 0 {
+				event.End = event.Start.Add(duration)
+			}
+			events = append(events, *event)
+			event = nil
+		case event != nil && nested == 0:
+			if duration, err = event.set(line, loc, duration); err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", number+1, line.name, err)
+			}
+		}
+	}
+
+	if !calendar {
+		return nil, ErrNotCalendar
+	}
+	return events, nil
+}
+
+// set applies a property of the event. The DURATION property is returned rather than applied,
+// because it may precede DTSTART.
+func (e *CalendarEvent) set(line contentLine, loc *time.Location, duration time.Duration) (time.Duration, error) {
+	var err error
+	switch line.name {
+	case "UID":
+		e.UID = line.value
+	case "RECURRENCE-ID":
+		e.RecurrenceID, _, err = parseDateTime(line, loc)
+	case "SUMMARY":
+		e.Summary = unescapeText(line.value)
+	case "DTSTART":
+		e.Start, e.AllDay, err = parseDateTime(line, loc)
+	case "DTEND":
+		e.End, _, err = parseDateTime(line, loc)
+	case "DURATION":
+		duration, err = parseDuration(line.value)
+	case "RRULE":
+		e.Rule = strings.TrimSpace(line.value)
+	case "RDATE":
+		var dates []time.Time
+		dates, err = parseDateTimes(line, loc)
+		e.RDates = append(e.RDates, dates...)
+	case "EXDATE":
+		var dates []time.Time
+		dates, err = parseDateTimes(line, loc)
+		e.ExDates = append(e.ExDates, dates...)
+	case "STATUS":
+		e.Cancelled = strings.EqualFold(line.value, "CANCELLED")
+	case "CATEGORIES":
+		for _, category := range splitText(line.value) {
+			if category = strings.TrimSpace(category); category != "" {
+				e.Categories = append(e.Categories, category)
+			}
+		}
+	}
+	return duration, err
+}
+
+// unfoldLines reads the content lines of the input, joining folded continuation lines.
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseContentLine splits a content line into its name, parameters and value.
+// Parameter values may be quoted and contain ";" and ":".
+func parseContentLine(raw string) (contentLine, error) {
+	line := contentLine{params: map[string]string{}}
+
+	quoted := false
+	colon := -1
+	var parts []string
+	start := 0
+	for i, c := range raw {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';':
+			parts = append(parts, raw[start:i])
+			start = i + 1
+		case c == ':':
+			colon = i
+		}
+		if colon >= 0 {
+			break
+		}
+	}
+	if colon < 0 {
+		return line, fmt.Errorf("invalid content line %q", raw)
+	}
+	parts = append(parts, raw[start:colon])
+
+	line.name = strings.ToUpper(strings.TrimSpace(parts[0]))
+	for _, param := range parts[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		line.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	line.value = raw[colon+1:]
+
+	return line, nil
+}
+
+// parseDateTime parses a DATE or DATE-TIME property value and reports whether it is a DATE.
+func parseDateTime(line contentLine, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(line.value)
+	if strings.EqualFold(line.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	if tzid := line.params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc = zone
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// parseDateTimes parses a list of DATE, DATE-TIME or PERIOD values, such as those of RDATE and EXDATE.
+// Only the starts of periods are returned.
+func parseDateTimes(line contentLine, loc *time.Location) ([]time.Time, error) {
+	var dates []time.Time
+	for _, value := range strings.Split(line.value, ",") {
+		value, _, _ = strings.Cut(value, "/")
+		t, _, err := parseDateTime(contentLine{params: line.params, value: value}, loc)
+		if err != nil {
+			return nil, err
+		}
+		dates = append(dates, t)
+	}
+	return dates, nil
+}
+
+// parseDuration parses an iCalendar duration such as "PT1H30M", "P1D" or "P2W".
+func parseDuration(value string) (time.Duration, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(value), "+")
+	if strings.HasPrefix(rest, "-") {
+		return 0, fmt.Errorf("negative duration %q", value)
+	}
+	if !strings.HasPrefix(rest, "P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	rest = rest[1:]
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour, 'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+	var total time.Duration
+	number := ""
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case c == 'T':
+		case c >= '0' && c <= '9':
+			number += string(c)
+		case units[c] != 0 && number != "":
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			total += time.Duration(n) * units[c]
+			number = ""
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return total, nil
+}
+
+// unescapeText reverses the escaping of an iCalendar TEXT value.
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(value)
+}
+
+// splitText splits a list of TEXT values on unescaped commas and unescapes each of them.
+func splitText(value string) []string {
+	var values []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, unescapeText(value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(values, unescapeText(value[start:]))
+}
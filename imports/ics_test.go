@@ -0,0 +1,189 @@
+package imports
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCalendar(t *testing.T) {
+	input := strings.Join([]string{
+		"\ufeffBEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:planning@example.com",
+		// Folded lines continue after a single space or tab, which is dropped.
+		"SUMMARY:Sprint planning\\, backlog",
+		"  review\\; estimates",
+		"DTSTART:20240304T060000Z",
+		"DTEND:20240304T073000Z",
+		"CATEGORIES:Meetings,R\\,D",
+		"\t,Planning",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"DURATION:PT5M",
+		"SUMMARY:Reminder",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:floating@example.com",
+		// DURATION may precede DTSTART; the time without a zone is read in the given location.
+		"DURATION:PT1H30M",
+		"DTSTART:20240305T090000",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:unknown-zone@example.com",
+		`DTSTART;TZID="GMT+03:00 Moscow; St. Petersburg":20240306T090000`,
+		"DTEND;TZID=/Unknown/Zone:20240306T100000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:day-off@example.com",
+		"DTSTART;VALUE=DATE:20240308",
+		"DTEND;VALUE=DATE:20240309",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"RECURRENCE-ID:20240307T070000Z",
+		"DTSTART:20240307T090000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	events, err := ParseCalendar(strings.NewReader(input), moscow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CalendarEvent{
+		{
+			UID: "planning@example.com", Summary: "Sprint planning, backlog review; estimates",
+			Start: time.Date(2024, 3, 4, 6, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 4, 7, 30, 0, 0, time.UTC),
+			Categories: []string{"Meetings", "R,D", "Planning"},
+		},
+		{
+			UID:   "floating@example.com",
+			Start: time.Date(2024, 3, 5, 9, 0, 0, 0, moscow), End: time.Date(2024, 3, 5, 10, 30, 0, 0, moscow),
+			Cancelled: true,
+		},
+		{
+			// Zones that are not in the time zone database fall back to the given location.
+			UID:   "unknown-zone@example.com",
+			Start: time.Date(2024, 3, 6, 9, 0, 0, 0, moscow), End: time.Date(2024, 3, 6, 10, 0, 0, 0, moscow),
+		},
+		{
+			UID:   "day-off@example.com",
+			Start: time.Date(2024, 3, 8, 0, 0, 0, 0, moscow), End: time.Date(2024, 3, 9, 0, 0, 0, 0, moscow),
+			AllDay: true,
+		},
+		{
+			UID: "standup@example.com", RecurrenceID: time.Date(2024, 3, 7, 7, 0, 0, 0, time.UTC),
+			Start: time.Date(2024, 3, 7, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	assertEvents(t, events, want)
+}
+
+func TestParseCalendarTimeZones(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Moscow"); err != nil {
+		t.Skip("time zone database is not available:", err)
+	}
+
+	input := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\n" +
+		"DTSTART;TZID=Europe/Moscow:20240304T090000\n" +
+		"DTEND:20240304T070000Z\n" +
+		"RDATE;TZID=Europe/Moscow:20240305T090000,20240306T090000\n" +
+		"RDATE;VALUE=PERIOD:20240307T060000Z/PT1H\n" +
+		"EXDATE;TZID=Europe/Moscow:20240311T090000\n" +
+		"RRULE:FREQ=DAILY;COUNT=10\n" +
+		"END:VEVENT\n" +
+		"END:VCALENDAR\n"
+	events, err := ParseCalendar(strings.NewReader(input), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day int) time.Time { return time.Date(2024, 3, day, 6, 0, 0, 0, time.UTC) }
+	assertEvents(t, events, []CalendarEvent{{
+		Start: at(4), End: time.Date(2024, 3, 4, 7, 0, 0, 0, time.UTC),
+		Rule: "FREQ=DAILY;COUNT=10", RDates: []time.Time{at(5), at(6), at(7)}, ExDates: []time.Time{at(11)},
+	}})
+	if !events[0].Recurring() {
+		t.Error("event with a rule is not recurring")
+	}
+}
+
+func TestParseCalendarInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty file", "", ErrNotCalendar.Error()},
+		{"not a calendar", "BEGIN:VCARD\nFN:Ivan Petrov\nEND:VCARD\n", ErrNotCalendar.Error()},
+		{"line without a value", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY\n", `line 3: invalid content line "SUMMARY"`},
+		{"invalid start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:tomorrow\n", "line 3: DTSTART:"},
+		{"negative duration", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDURATION:-PT1H\n", `line 3: DURATION: negative duration "-PT1H"`},
+		{"invalid duration", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDURATION:PT1X\n", `line 3: DURATION: invalid duration "PT1X"`},
+		{"invalid recurrence date", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEXDATE:20240304T0900,20240305\n", "line 3: EXDATE:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCalendar(strings.NewReader(tt.input), time.UTC)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.want)
+			}
+			if tt.want == ErrNotCalendar.Error() && !errors.Is(err, ErrNotCalendar) {
+				t.Errorf("got error %v, want ErrNotCalendar", err)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"PT1H30M", 90 * time.Minute},
+		{"P1D", 24 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"+P1DT2H3M4S", 26*time.Hour + 3*time.Minute + 4*time.Second},
+		{"PT0S", 0},
+	}
+	for _, tt := range tests {
+		if got, err := parseDuration(tt.value); err != nil || got != tt.want {
+			t.Errorf("parseDuration(%q) = %s, %v; want %s", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "1H", "-PT1H", "PTH", "PT1H30", "P1Y"} {
+		if got, err := parseDuration(value); err == nil {
+			t.Errorf("parseDuration(%q) = %s, want an error", value, got)
+		}
+	}
+}
+
+// assertEvents compares parsed events with the expected ones, comparing times as instants.
+func assertEvents(t *testing.T, got, want []CalendarEvent) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if !g.Start.Equal(w.Start) || !g.End.Equal(w.End) || !g.RecurrenceID.Equal(w.RecurrenceID) {
+			t.Errorf("event %d lasts from %s to %s (recurrence %s), want %s to %s (recurrence %s)",
+				i, g.Start, g.End, g.RecurrenceID, w.Start, w.End, w.RecurrenceID)
+		}
+		assertTimes(t, g.RDates, w.RDates)
+		assertTimes(t, g.ExDates, w.ExDates)
+		g.Start, g.End, g.RecurrenceID, g.RDates, g.ExDates = time.Time{}, time.Time{}, time.Time{}, nil, nil
+		w.Start, w.End, w.RecurrenceID, w.RDates, w.ExDates = time.Time{}, time.Time{}, time.Time{}, nil, nil
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("event %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package imports
+
+import (
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"time-tracker-go/timesheets"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SkipPeriodLocked is the reason entries whose time falls into an approved week are not imported.
+const SkipPeriodLocked = "period_locked"
+
+// loadLocks loads the approved weeks of the users of the tasks that the tasks could fall into.
+func loadLocks(db *gorm.DB, tasks []models.Task) (timesheets.Locks, error) {
+	if len(tasks) == 0 {
+		return timesheets.Locks{}, nil
+	}
+
+	var userIDs []uint
+	seen := map[uint]bool{}
+	period := taskPeriod(tasks[0])
+	for _, task := range tasks {
+		if !seen[task.UserID] {
+			seen[task.UserID] = true
+			userIDs = append(userIDs, task.UserID)
+		}
+		taskPeriod := taskPeriod(task)
+		if taskPeriod.From.Before(period.From) {
+			period.From = taskPeriod.From
+		}
+		if taskPeriod.To.After(period.To) {
+			period.To = taskPeriod.To
+		}
+	}
+	return timesheets.LoadLocks(db, userIDs, period.From, period.To)
+}
+
+// lockUsers locks the rows of the users until the end of the transaction, in the order of their IDs
+// so that concurrent imports cannot deadlock. This keeps the weeks of the users from being approved
+// and their timers from starting until the imported time is committed.
+func lockUsers(tx *gorm.DB, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id IN ?", userIDs).Order("id").Find(&[]models.User{}).Error
+}
+
+// taskPeriod returns the period covered by the single closed interval of an imported task.
+func taskPeriod(task models.Task) reports.Period {
+	interval := task.Intervals[0]
+	return reports.Period{From: interval.StartTime, To: *interval.EndTime}
+}
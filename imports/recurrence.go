@@ -0,0 +1,290 @@
+package imports
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recurrenceRule is a parsed RRULE. Only the rule parts that calendars commonly use are supported:
+// FREQ (DAILY, WEEKLY, MONTHLY or YEARLY), INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and WKST.
+type recurrenceRule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time // Last moment an occurrence may start at (zero for no limit)
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+	weekStart  time.Weekday
+}
+
+// weekdayNum is a BYDAY value such as MO, 1MO or -1FR.
+type weekdayNum struct {
+	ordinal int // Occurrence of the weekday within the month (0 for every occurrence, negative from the end)
+	weekday time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// parseRecurrenceRule parses the value of an RRULE property. Times of UNTIL without a zone are read in loc.
+func parseRecurrenceRule(value string, loc *time.Location) (recurrenceRule, error) {
+	rule := recurrenceRule{interval: 1, weekStart: time.Monday}
+	for _, part := range strings.Split(value, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		name, value = strings.ToUpper(strings.TrimSpace(name)), strings.ToUpper(strings.TrimSpace(value))
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.freq = value
+		case "INTERVAL":
+			rule.interval, err = strconv.Atoi(value)
+			if err == nil && rule.interval < 1 {
+				err = fmt.Errorf("invalid interval %q", value)
+			}
+		case "COUNT":
+			rule.count, err = strconv.Atoi(value)
+			if err == nil && rule.count < 1 {
+				err = fmt.Errorf("invalid count %q", value)
+			}
+		case "UNTIL":
+			var date bool
+			rule.until, date, err = parseDateTime(contentLine{value: value}, loc)
+			if date {
+				rule.until = rule.until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[day[max(len(day)-2, 0):]]
+				if !ok {
+					return rule, fmt.Errorf("invalid weekday %q", day)
+				}
+				ordinal := 0
+				if number := day[:len(day)-2]; number != "" {
+					if ordinal, err = strconv.Atoi(number); err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+						return rule, fmt.Errorf("invalid weekday %q", day)
+					}
+				}
+				rule.byDay = append(rule.byDay, weekdayNum{ordinal: ordinal, weekday: weekday})
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return rule, fmt.Errorf("invalid day of month %q", day)
+				}
+				rule.byMonthDay = append(rule.byMonthDay, monthDay)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(value, ",") {
+				number, err := strconv.Atoi(month)
+				if err != nil || number < 1 || number > 12 {
+					return rule, fmt.Errorf("invalid month %q", month)
+				}
+				rule.byMonth = append(rule.byMonth, time.Month(number))
+			}
+		case "WKST":
+			weekday, ok := weekdays[value]
+			if !ok {
+				return rule, fmt.Errorf("invalid week start %q", value)
+			}
+			rule.weekStart = weekday
+		default:
+			return rule, fmt.Errorf("unsupported rule part %s", name)
+		}
+		if err != nil {
+			return rule, err
+		}
+	}
+
+	switch rule.freq {
+	case "DAILY", "WEEKLY":
+		if len(rule.byMonthDay) > 0 {
+			return rule, fmt.Errorf("BYMONTHDAY is not supported with FREQ=%s", rule.freq)
+		}
+		for _, day := range rule.byDay {
+			if day.ordinal != 0 {
+				return rule, fmt.Errorf("numbered BYDAY is not supported with FREQ=%s", rule.freq)
+			}
+		}
+	case "MONTHLY", "YEARLY":
+		if len(rule.byDay) > 0 && len(rule.byMonthDay) > 0 {
+			return rule, fmt.Errorf("BYDAY together with BYMONTHDAY is not supported")
+		}
+		if rule.freq == "YEARLY" && len(rule.byMonth) == 0 && (len(rule.byDay) > 0 || len(rule.byMonthDay) > 0) {
+			return rule, fmt.Errorf("BYDAY and BYMONTHDAY are only supported with BYMONTH for FREQ=YEARLY")
+		}
+	case "":
+		return rule, fmt.Errorf("missing FREQ")
+	default:
+		return rule, fmt.Errorf("unsupported frequency %s", rule.freq)
+	}
+	return rule, nil
+}
+
+// expand returns the starts of the occurrences of a rule beginning at start that begin before limit, in order.
+// The start itself is always the first occurrence. Occurrences have the time of day of start in its time zone.
+func (r recurrenceRule) expand(start, limit time.Time) []time.Time {
+	if !start.Before(limit) {
+		return nil
+	}
+	starts := []time.Time{start}
+	for period := 0; r.count == 0 || len(starts) < r.count; period++ {
+		periodStart, candidates := r.period(start, period)
+		if !periodStart.Before(limit) || (!r.until.IsZero() && periodStart.After(r.until)) {
+			break
+		}
+		for _, candidate := range candidates {
+			if !candidate.After(start) {
+				continue
+			}
+			if !candidate.Before(limit) || (!r.until.IsZero() && candidate.After(r.until)) || (r.count > 0 && len(starts) == r.count) {
+				return starts
+			}
+			starts = append(starts, candidate)
+		}
+	}
+	return starts
+}
+
+// period returns the beginning of the n-th period of the rule (the day, week, month or year) and the
+// occurrences that fall into it, in order.
+func (r recurrenceRule) period(start time.Time, n int) (time.Time, []time.Time) {
+	loc := start.Location()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), loc)
+	}
+	year, month, day := start.Date()
+
+	var periodStart time.Time
+	var candidates []time.Time
+	switch r.freq {
+	case "DAILY":
+		periodStart = time.Date(year, month, day+n*r.interval, 0, 0, 0, 0, loc)
+		candidates = []time.Time{at(periodStart.Date())}
+	case "WEEKLY":
+		offset := (int(start.Weekday()) - int(r.weekStart) + 7) % 7
+		periodStart = time.Date(year, month, day-offset+7*n*r.interval, 0, 0, 0, 0, loc)
+		byDay := r.byDay
+		if len(byDay) == 0 {
+			byDay = []weekdayNum{{weekday: start.Weekday()}}
+		}
+		for i := 0; i < 7; i++ {
+			candidate := at(periodStart.Year(), periodStart.Month(), periodStart.Day()+i)
+			if slices.ContainsFunc(byDay, func(day weekdayNum) bool { return day.weekday == candidate.Weekday() }) {
+				candidates = append(candidates, candidate)
+			}
+		}
+	case "MONTHLY":
+		periodStart = time.Date(year, month+time.Month(n*r.interval), 1, 0, 0, 0, 0, loc)
+		candidates = r.monthDays(start, periodStart.Year(), periodStart.Month())
+	case "YEARLY":
+		periodStart = time.Date(year+n*r.interval, time.January, 1, 0, 0, 0, 0, loc)
+		months := slices.Clone(r.byMonth)
+		if len(months) == 0 {
+			months = []time.Month{month}
+		}
+		slices.Sort(months)
+		for _, month := range months {
+			candidates = append(candidates, r.monthDays(start, periodStart.Year(), month)...)
+		}
+		return periodStart, candidates
+	}
+
+	var filtered []time.Time
+	for _, candidate := range candidates {
+		if r.matches(candidate) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return periodStart, filtered
+}
+
+// matches reports whether an occurrence of a daily, weekly or monthly rule falls into the months of
+// BYMONTH and, for a daily rule, on the weekdays of BYDAY.
+func (r recurrenceRule) matches(t time.Time) bool {
+	if len(r.byMonth) > 0 && !slices.Contains(r.byMonth, t.Month()) {
+		return false
+	}
+	if r.freq != "DAILY" || len(r.byDay) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(r.byDay, func(day weekdayNum) bool { return day.weekday == t.Weekday() })
+}
+
+// monthDays returns the occurrences of a monthly or yearly rule in the month, in order.
+// Without BYDAY and BYMONTHDAY the occurrence falls on the day of the month of start;
+// days that the month does not have are skipped.
+func (r recurrenceRule) monthDays(start time.Time, year int, month time.Month) []time.Time {
+	daysIn := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	var days []int
+	switch {
+	case len(r.byMonthDay) > 0:
+		for _, day := range r.byMonthDay {
+			if day < 0 {
+				day += daysIn + 1
+			}
+			days = append(days, day)
+		}
+	case len(r.byDay) > 0:
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		for _, weekday := range r.byDay {
+			var matching []int
+			for day := 1 + (int(weekday.weekday)-int(first)+7)%7; day <= daysIn; day += 7 {
+				matching = append(matching, day)
+			}
+			switch {
+			case weekday.ordinal == 0:
+				days = append(days, matching...)
+			case weekday.ordinal > 0 && weekday.ordinal <= len(matching):
+				days = append(days, matching[weekday.ordinal-1])
+			case weekday.ordinal < 0 && -weekday.ordinal <= len(matching):
+				days = append(days, matching[len(matching)+weekday.ordinal])
+			}
+		}
+	default:
+		days = []int{start.Day()}
+	}
+	sort.Ints(days)
+
+	var candidates []time.Time
+	for i, day := range days {
+		if day < 1 || day > daysIn || (i > 0 && day == days[i-1]) {
+			continue
+		}
+		candidates = append(candidates, time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location()))
+	}
+	return candidates
+}
+
+// occurrences returns the starts of the occurrences of a recurring event that begin before limit, in order:
+// those of its rule and its additional dates, without the excluded ones.
+func occurrences(event CalendarEvent, limit time.Time) ([]time.Time, error) {
+	starts := []time.Time{event.Start}
+	if event.Rule != "" {
+		rule, err := parseRecurrenceRule(event.Rule, event.Start.Location())
+		if err != nil {
+			return nil, err
+		}
+		starts = rule.expand(event.Start, limit)
+	}
+	starts = append(starts, event.RDates...)
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	var result []time.Time
+	for i, start := range starts {
+		excluded := slices.ContainsFunc(event.ExDates, start.Equal)
+		if !excluded && start.Before(limit) && (i == 0 || !start.Equal(starts[i-1])) {
+			result = append(result, start)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,236 @@
+package imports
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"time-tracker-go/reports"
+)
+
+func TestRecurrenceRuleExpand(t *testing.T) {
+	at := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, moscow)
+	}
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		limit time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "weekly on several days with a count",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5",
+			start: at(2024, 3, 4, 9),
+			limit: at(2025, 1, 1, 0),
+			want:  []time.Time{at(2024, 3, 4, 9), at(2024, 3, 6, 9), at(2024, 3, 8, 9), at(2024, 3, 11, 9), at(2024, 3, 13, 9)},
+		},
+		{
+			// The examples of RFC 5545 for the week start: 1997-08-05 is a Tuesday.
+			name:  "every other week starting on monday",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			start: at(1997, 8, 5, 9),
+			limit: at(2025, 1, 1, 0),
+			want:  []time.Time{at(1997, 8, 5, 9), at(1997, 8, 10, 9), at(1997, 8, 19, 9), at(1997, 8, 24, 9)},
+		},
+		{
+			name:  "every other week starting on sunday",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			start: at(1997, 8, 5, 9),
+			limit: at(2025, 1, 1, 0),
+			want:  []time.Time{at(1997, 8, 5, 9), at(1997, 8, 17, 9), at(1997, 8, 19, 9), at(1997, 8, 31, 9)},
+		},
+		{
+			name:  "every other day until a time in utc",
+			rule:  "FREQ=DAILY;INTERVAL=2;UNTIL=20240308T060000Z",
+			start: at(2024, 3, 4, 9),
+			limit: at(2025, 1, 1, 0),
+			want:  []time.Time{at(2024, 3, 4, 9), at(2024, 3, 6, 9), at(2024, 3, 8, 9)},
+		},
+		{
+			name:  "working days until a date",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20240312",
+			start: at(2024, 3, 7, 9),
+			limit: at(2025, 1, 1, 0),
+			want:  []time.Time{at(2024, 3, 7, 9), at(2024, 3, 8, 9), at(2024, 3, 11, 9), at(2024, 3, 12, 9)},
+		},
+		{
+			name:  "last friday of the month up to the limit",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: at(2024, 1, 26, 10),
+			limit: at(2024, 4, 26, 10),
+			want:  []time.Time{at(2024, 1, 26, 10), at(2024, 2, 23, 10), at(2024, 3, 29, 10)},
+		},
+		{
+			name:  "monthly on a day that some months do not have",
+			rule:  "FREQ=MONTHLY",
+			start: at(2024, 1, 31, 10),
+			limit: at(2024, 6, 1, 0),
+			want:  []time.Time{at(2024, 1, 31, 10), at(2024, 3, 31, 10), at(2024, 5, 31, 10)},
+		},
+		{
+			name:  "monthly on days counted from the end",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=4",
+			start: at(2024, 2, 1, 10),
+			limit: at(2025, 1, 1, 0),
+			want:  []time.Time{at(2024, 2, 1, 10), at(2024, 2, 29, 10), at(2024, 3, 1, 10), at(2024, 3, 31, 10)},
+		},
+		{
+			name:  "yearly on february 29",
+			rule:  "FREQ=YEARLY",
+			start: at(2020, 2, 29, 12),
+			limit: at(2025, 1, 1, 0),
+			want:  []time.Time{at(2020, 2, 29, 12), at(2024, 2, 29, 12)},
+		},
+		{
+			name:  "fourth thursday of november",
+			rule:  "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=3",
+			start: at(2021, 11, 25, 15),
+			limit: at(2030, 1, 1, 0),
+			want:  []time.Time{at(2021, 11, 25, 15), at(2022, 11, 24, 15), at(2023, 11, 23, 15)},
+		},
+		{
+			name:  "start at the limit",
+			rule:  "FREQ=DAILY",
+			start: at(2024, 3, 4, 9),
+			limit: at(2024, 3, 4, 9),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRecurrenceRule(tt.rule, moscow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertTimes(t, rule.expand(tt.start, tt.limit), tt.want)
+		})
+	}
+}
+
+func TestRecurrenceRuleKeepsLocalTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is not available:", err)
+	}
+
+	// Clocks in Berlin go forward on 2024-03-31; the meeting stays at 09:00 local time.
+	rule, err := parseRecurrenceRule("FREQ=DAILY;COUNT=3", berlin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := rule.expand(time.Date(2024, 3, 30, 9, 0, 0, 0, berlin), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assertTimes(t, got, []time.Time{
+		time.Date(2024, 3, 30, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 7, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 7, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestParseRecurrenceRuleUnsupported(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{"FREQ=HOURLY;INTERVAL=2", "unsupported frequency HOURLY"},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "unsupported rule part BYSETPOS"},
+		{"COUNT=3", "missing FREQ"},
+		{"FREQ=WEEKLY;BYDAY=1MO", "numbered BYDAY is not supported with FREQ=WEEKLY"},
+		{"FREQ=YEARLY;BYDAY=20MO", `invalid weekday "20MO"`},
+		{"FREQ=YEARLY;BYMONTHDAY=1", "only supported with BYMONTH"},
+		{"FREQ=DAILY;INTERVAL=0", `invalid interval "0"`},
+		{"FREQ=DAILY;UNTIL=tomorrow", "cannot parse"},
+	}
+	for _, tt := range tests {
+		if _, err := parseRecurrenceRule(tt.rule, time.UTC); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseRecurrenceRule(%q) = %v, want an error containing %q", tt.rule, err, tt.want)
+		}
+	}
+}
+
+func TestExpandRecurrences(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"SUMMARY:Standup",
+		"DTSTART;TZID=Europe/Moscow:20240304T100000",
+		"DURATION:PT15M",
+		"RRULE:FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+		"EXDATE;TZID=Europe/Moscow:20240306T100000",
+		"RDATE:20240309T070000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"RECURRENCE-ID;TZID=Europe/Moscow:20240307T100000",
+		"SUMMARY:Standup (moved)",
+		"DTSTART;TZID=Europe/Moscow:20240307T120000",
+		"DTEND;TZID=Europe/Moscow:20240307T121500",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:review@example.com",
+		"DTSTART:20240304T120000Z",
+		"DTEND:20240304T130000Z",
+		"RRULE:FREQ=MONTHLY;BYDAY=MO;BYSETPOS=1",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:holiday@example.com",
+		"DTSTART;VALUE=DATE:20240308",
+		"RRULE:FREQ=YEARLY",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	events, err := ParseCalendar(strings.NewReader(input), moscow)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The occurrences of the week of 2024-03-04 from Tuesday, when the period starts, up to now on Monday.
+	period := &reports.Period{From: time.Date(2024, 3, 5, 0, 0, 0, 0, moscow), To: time.Date(2024, 3, 18, 0, 0, 0, 0, moscow)}
+	now := time.Date(2024, 3, 11, 10, 5, 0, 0, moscow)
+	expanded, skipped := expandRecurrences(events, period, now)
+
+	want := []struct {
+		externalID string
+		summary    string
+		start      time.Time
+	}{
+		{"ics:standup@example.com/20240305T070000Z", "Standup", time.Date(2024, 3, 5, 10, 0, 0, 0, moscow)},
+		{"ics:standup@example.com/20240308T070000Z", "Standup", time.Date(2024, 3, 8, 10, 0, 0, 0, moscow)},
+		{"ics:standup@example.com/20240309T070000Z", "Standup", time.Date(2024, 3, 9, 10, 0, 0, 0, moscow)},
+		{"ics:standup@example.com/20240311T070000Z", "Standup", time.Date(2024, 3, 11, 10, 0, 0, 0, moscow)},
+		{"ics:standup@example.com/20240307T070000Z", "Standup (moved)", time.Date(2024, 3, 7, 12, 0, 0, 0, moscow)},
+		{"ics:holiday@example.com", "", time.Date(2024, 3, 8, 0, 0, 0, 0, moscow)},
+	}
+	if len(expanded) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(expanded), len(want), expanded)
+	}
+	for i, w := range want {
+		event := expanded[i]
+		if id := calendarExternalID(event); id != w.externalID || event.Summary != w.summary || !event.Start.Equal(w.start) {
+			t.Errorf("event %d is %s %q at %s, want %s %q at %s", i, id, event.Summary, event.Start, w.externalID, w.summary, w.start)
+		}
+		if event.Recurring() || event.AllDay {
+			continue
+		}
+		if got := event.End.Sub(event.Start); got != 15*time.Minute {
+			t.Errorf("event %d lasts %s, want 15m", i, got)
+		}
+	}
+
+	if len(skipped) != 1 || skipped[0].UID != "review@example.com" || skipped[0].Reason != SkipUnsupportedRecurrence {
+		t.Errorf("skipped %+v, want review@example.com as %s", skipped, SkipUnsupportedRecurrence)
+	}
+}
+
+// assertTimes compares times as instants.
+func assertTimes(t *testing.T, got, want []time.Time) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d times %v, want %d %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("time %d is %s, want %s", i, got[i], want[i])
+		}
+	}
+}
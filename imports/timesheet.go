@@ -0,0 +1,665 @@
+package imports
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"time-tracker-go/models"
+
+	"gorm.io/gorm"
+)
+
+// Timesheet fields that CSV columns can be mapped to.
+const (
+	FieldPassport    = "passport_number" // Passport number of the user
+	FieldUser        = "user"            // Full name of the user: surname, name and optionally patronymic
+	FieldSurname     = "surname"         // Surname of the user
+	FieldName        = "name"            // Name of the user
+	FieldPatronymic  = "patronymic"      // Patronymic of the user
+	FieldDescription = "description"     // Task description
+	FieldProject     = "project"         // Project name
+	FieldTags        = "tags"            // Tag names separated by commas or semicolons
+	FieldBillable    = "billable"        // Billable flag
+	FieldDate        = "date"            // Date of the entry, if start and end are times of day
+	FieldStart       = "start"           // Start of the entry
+	FieldEnd         = "end"             // End of the entry
+	FieldDuration    = "duration"        // Duration of the entry, if it has no end
+)
+
+// TimesheetFields lists the fields in the order in which they are documented.
+var TimesheetFields = []string{
+	FieldPassport, FieldUser, FieldSurname, FieldName, FieldPatronymic, FieldDescription,
+	FieldProject, FieldTags, FieldBillable, FieldDate, FieldStart, FieldEnd, FieldDuration,
+}
+
+// Units of durations without a colon.
+const (
+	DurationMinutes = "minutes"
+	DurationHours   = "hours"
+)
+
+// timesheetBatchSize is the number of tasks inserted per statement.
+const timesheetBatchSize = 500
+
+// ErrInvalidTimesheet is returned when a timesheet cannot be read at all, as opposed to having invalid rows.
+var ErrInvalidTimesheet = errors.New("invalid timesheet")
+
+// dateTimeLayouts are the layouts tried for start and end times when no layout is configured.
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+}
+
+// dateLayouts are the layouts tried for the date column.
+var dateLayouts = []string{"2006-01-02", "02.01.2006"}
+
+// clockLayouts are the layouts tried for start and end times when the timesheet has a date column.
+var clockLayouts = []string{"15:04:05", "15:04"}
+
+// TimesheetOptions control how a CSV timesheet is read.
+type TimesheetOptions struct {
+	Comma        rune              // Field delimiter (default ",")
+	Columns      map[string]string // Column names of fields that differ from the field names
+	TimeLayout   string            // Layout of start and end times (empty to try common layouts)
+	DurationUnit string            // Unit of durations without a colon: minutes (default) or hours
+	Location     *time.Location    // Time zone of times without an offset (default: UTC)
+	DryRun       bool              // Validate the timesheet without creating any tasks
+}
+
+// RowError is a problem with a row of a timesheet.
+type RowError struct {
+	Row    int    `json:"row"`              // Line of the row in the file, the header being line 1
+	Column string `json:"column,omitempty"` // Column the problem was found in, if any
+	Error  string `json:"error"`            // Description of the problem
+}
+
+// TimesheetResult is the outcome of a timesheet import.
+type TimesheetResult struct {
+	DryRun  bool       `json:"dryRun"`  // Whether the import was a validation only
+	Rows    int        `json:"rows"`    // Number of data rows read
+	Created int        `json:"created"` // Number of tasks created (or that would be created in a dry run)
+	Users   int        `json:"users"`   // Number of users the tasks belong to
+	Errors  []RowError `json:"errors"`  // Problems found; if there are any, nothing is imported
+}
+
+// timesheetEntry is a valid row of a timesheet.
+type timesheetEntry struct {
+	row  int
+	task models.Task
+}
+
+// timesheetReader turns timesheet rows into tasks.
+type timesheetReader struct {
+	options  TimesheetOptions
+	columns  map[string]int // Column index of each mapped field
+	names    map[string]string
+	users    userDirectory
+	projects map[string]uint
+	tags     map[string]models.Tag
+}
+
+// ImportTimesheet imports the rows of a CSV timesheet as done tasks with a single closed interval each.
+//
+// The first row is the header. Columns are matched to fields by name, case-insensitively; by default
+// a column is named after its field. Users are matched by passport number or, failing that, by name.
+// Every row is validated: the user, project and tags must exist, times must be valid and in the past,
+// durations non-negative, and entries of a user must not overlap each other or already tracked time,
+// nor fall into weeks whose timesheets are approved.
+// All tasks are created in one transaction, and only if no row has errors.
+func ImportTimesheet(db *gorm.DB, r io.Reader, options TimesheetOptions, now time.Time) (TimesheetResult, error) {
+	result := TimesheetResult{DryRun: options.DryRun, Errors: []RowError{}}
+	if options.Comma == 0 {
+		options.Comma = ','
+	}
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+	if options.DurationUnit == "" {
+		options.DurationUnit = DurationMinutes
+	}
+	if options.DurationUnit != DurationMinutes && options.DurationUnit != DurationHours {
+		return result, fmt.Errorf("%w: unknown duration unit %q", ErrInvalidTimesheet, options.DurationUnit)
+	}
+
+	csvReader := csv.NewReader(r)
+	csvReader.Comma = options.Comma
+	csvReader.FieldsPerRecord = -1
+	csvReader.ReuseRecord = true
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return result, fmt.Errorf("%w: the file is empty", ErrInvalidTimesheet)
+	}
+	if err != nil {
+		return result, fmt.Errorf("%w: %v", ErrInvalidTimesheet, err)
+	}
+
+	reader := timesheetReader{options: options}
+	if err := reader.mapColumns(header); err != nil {
+		return result, err
+	}
+	if err := reader.loadCatalogues(db); err != nil {
+		return result, err
+	}
+
+	var entries []timesheetEntry
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("%w: %v", ErrInvalidTimesheet, err)
+		}
+		line, _ := csvReader.FieldPos(0)
+		if isBlank(record) {
+			continue
+		}
+
+		result.Rows++
+		entry, rowErrors := reader.parseRow(line, record, now)
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		userIDs := distinctUsers(entries)
+		if len(userIDs) == 0 {
+			return nil
+		}
+
+		// Locking the users keeps their timers from starting until the overlap check is committed.
+		if err := lockUsers(tx, userIDs); err != nil {
+			return err
+		}
+		overlaps, err := findOverlaps(tx, entries, now)
+		if err != nil {
+			return err
+		}
+		result.Errors = append(result.Errors, overlaps...)
+
+		tasks := make([]models.Task, len(entries))
+		for i, entry := range entries {
+			tasks[i] = entry.task
+		}
+		locks, err := loadLocks(tx, tasks)
+		if err != nil {
+			return err
+		}
+		for i, task := range tasks {
+			if week, locked := locks.Locked(task.UserID, taskPeriod(task)); locked {
+				result.Errors = append(result.Errors, RowError{Row: entries[i].row, Error: fmt.Sprintf("the week of %s is approved and locked", week.Format("2006-01-02"))})
+			}
+		}
+
+		if len(result.Errors) > 0 || options.DryRun {
+			return nil
+		}
+
+		return tx.CreateInBatches(&tasks, timesheetBatchSize).Error
+	})
+	if err != nil {
+		return result, err
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Row < result.Errors[j].Row
+	})
+	if len(result.Errors) == 0 {
+		result.Created = len(entries)
+		result.Users = len(distinctUsers(entries))
+	}
+	return result, nil
+}
+
+// mapColumns finds the columns of the mapped fields in the header and checks that the timesheet
+// identifies users and has enough columns to determine the start and end of every entry.
+func (t *timesheetReader) mapColumns(header []string) error {
+	t.columns = map[string]int{}
+	t.names = map[string]string{}
+
+	for field, column := range t.options.Columns {
+		if !isTimesheetField(field) {
+			return fmt.Errorf("%w: unknown field %q in the column mapping", ErrInvalidTimesheet, field)
+		}
+		if strings.TrimSpace(column) == "" {
+			return fmt.Errorf("%w: empty column name for field %q", ErrInvalidTimesheet, field)
+		}
+	}
+
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	positions := map[string]int{}
+	for i, column := range header {
+		positions[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, field := range TimesheetFields {
+		column, mapped := t.options.Columns[field]
+		if !mapped {
+			column = field
+		}
+		if i, ok := positions[strings.ToLower(strings.TrimSpace(column))]; ok {
+			t.columns[field] = i
+			t.names[field] = strings.TrimSpace(header[i])
+		} else if mapped {
+			return fmt.Errorf("%w: column %q mapped to %s is missing", ErrInvalidTimesheet, column, field)
+		}
+	}
+
+	switch {
+	case !t.has(FieldPassport) && !t.has(FieldUser) && !(t.has(FieldSurname) && t.has(FieldName)):
+		return fmt.Errorf("%w: a %s, %s or %s and %s column is required", ErrInvalidTimesheet, FieldPassport, FieldUser, FieldSurname, FieldName)
+	case !t.has(FieldStart):
+		return fmt.Errorf("%w: a %s column is required", ErrInvalidTimesheet, FieldStart)
+	case !t.has(FieldEnd) && !t.has(FieldDuration):
+		return fmt.Errorf("%w: an %s or %s column is required", ErrInvalidTimesheet, FieldEnd, FieldDuration)
+	}
+	return nil
+}
+
+// loadCatalogues loads the users, projects and tags that rows are matched against.
+func (t *timesheetReader) loadCatalogues(db *gorm.DB) error {
+	var err error
+	if t.users, err = loadUsers(db); err != nil {
+		return err
+	}
+
+	var projects []models.Project
+	if err := db.Select("id", "name").Find(&projects).Error; err != nil {
+		return err
+	}
+	t.projects = make(map[string]uint, len(projects))
+	for _, project := range projects {
+		t.projects[strings.ToLower(strings.TrimSpace(project.Name))] = project.ID
+	}
+
+	var tags []models.Tag
+	if err := db.Find(&tags).Error; err != nil {
+		return err
+	}
+	t.tags = make(map[string]models.Tag, len(tags))
+	for _, tag := range tags {
+		t.tags[tag.Name] = tag
+	}
+	return nil
+}
+
+// parseRow validates a row and turns it into a task. line is the line of the row in the file.
+func (t *timesheetReader) parseRow(line int, record []string, now time.Time) (timesheetEntry, []RowError) {
+	entry := timesheetEntry{row: line, task: models.Task{Status: models.TaskStatusDone, Tags: []models.Tag{}}}
+	var rowErrors []RowError
+	fail := func(field string, format string, args ...interface{}) {
+		rowErrors = append(rowErrors, RowError{Row: line, Column: t.names[field], Error: fmt.Sprintf(format, args...)})
+	}
+
+	// User
+	userID, field, err := t.users.match(t.value(record, FieldPassport), t.userName(record))
+	if err != nil {
+		fail(field, "%v", err)
+	}
+	entry.task.UserID = userID
+
+	entry.task.Description = t.value(record, FieldDescription)
+
+	if project := t.value(record, FieldProject); project != "" {
+		if projectID, ok := t.projects[strings.ToLower(project)]; ok {
+			entry.task.ProjectID = &projectID
+		} else {
+			fail(FieldProject, "unknown project %q", project)
+		}
+	}
+
+	for _, name := range strings.FieldsFunc(t.value(record, FieldTags), func(r rune) bool { return r == ',' || r == ';' }) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if tag, ok := t.tags[name]; ok {
+			entry.task.Tags = append(entry.task.Tags, tag)
+		} else {
+			fail(FieldTags, "unknown tag %q", name)
+		}
+	}
+
+	if billable := t.value(record, FieldBillable); billable != "" {
+		if entry.task.Billable, err = parseBool(billable); err != nil {
+			fail(FieldBillable, "%v", err)
+		}
+	}
+
+	// Interval
+	var date time.Time
+	if t.has(FieldDate) {
+		if date, err = parseTime(t.value(record, FieldDate), dateLayouts, t.options.Location); err != nil {
+			fail(FieldDate, "invalid date %q", t.value(record, FieldDate))
+			return entry, rowErrors
+		}
+	}
+
+	start, err := t.parseTime(record, FieldStart, date)
+	if err != nil {
+		fail(FieldStart, "%v", err)
+		return entry, rowErrors
+	}
+
+	var end time.Time
+	if value := t.value(record, FieldEnd); value != "" {
+		if end, err = t.parseTime(record, FieldEnd, date); err != nil {
+			fail(FieldEnd, "%v", err)
+			return entry, rowErrors
+		}
+		if end.Before(start) {
+			fail(FieldEnd, "end %s is before start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+			return entry, rowErrors
+		}
+	} else if value := t.value(record, FieldDuration); value != "" {
+		duration, err := parseTimesheetDuration(value, t.options.DurationUnit)
+		if err != nil {
+			fail(FieldDuration, "%v", err)
+			return entry, rowErrors
+		}
+		end = start.Add(duration)
+	} else {
+		fail(FieldEnd, "missing end or duration")
+		return entry, rowErrors
+	}
+
+	if end.After(now) {
+		fail(FieldEnd, "end %s is in the future", end.Format(time.RFC3339))
+		return entry, rowErrors
+	}
+
+	entry.task.Intervals = []models.TaskInterval{{StartTime: start, EndTime: &end}}
+	entry.task.RecalculateDuration()
+	return entry, rowErrors
+}
+
+// has reports whether the field is mapped to a column.
+func (t *timesheetReader) has(field string) bool {
+	_, ok := t.columns[field]
+	return ok
+}
+
+// value returns the trimmed value of the field in the record, or an empty string if it is not mapped.
+func (t *timesheetReader) value(record []string, field string) string {
+	i, ok := t.columns[field]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// userName returns the full name of the user in the record.
+func (t *timesheetReader) userName(record []string) string {
+	if name := t.value(record, FieldUser); name != "" {
+		return name
+	}
+	return strings.TrimSpace(t.value(record, FieldSurname) + " " + t.value(record, FieldName) + " " + t.value(record, FieldPatronymic))
+}
+
+// parseTime parses the start or end of an entry. If the timesheet has a date column,
+// the value may be a time of day on that date.
+func (t *timesheetReader) parseTime(record []string, field string, date time.Time) (time.Time, error) {
+	value := t.value(record, field)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("missing %s", field)
+	}
+
+	if !date.IsZero() {
+		if clock, err := parseTime(value, clockLayouts, time.UTC); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, t.options.Location), nil
+		}
+	}
+
+	layouts := dateTimeLayouts
+	if t.options.TimeLayout != "" {
+		layouts = []string{t.options.TimeLayout}
+	}
+	parsed, err := parseTime(value, layouts, t.options.Location)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid %s time %q", field, value)
+	}
+	return parsed, nil
+}
+
+// userDirectory matches timesheet rows to users.
+type userDirectory struct {
+	byPassport map[string]uint
+	byName     map[string][]uint // Users by "surname name patronymic", "surname name" and "name surname"
+}
+
+// loadUsers loads all users into a directory.
+func loadUsers(db *gorm.DB) (userDirectory, error) {
+	directory := userDirectory{byPassport: map[string]uint{}, byName: map[string][]uint{}}
+
+	var users []models.User
+	if err := db.Select("id", "passport_number", "surname", "name", "patronymic").Find(&users).Error; err != nil {
+		return directory, err
+	}
+	for _, user := range users {
+		directory.byPassport[normalizeSpace(user.PassportNumber)] = user.ID
+		// Names are also matched in the "Name Surname" order used by most other services.
+		names := map[string]bool{
+			normalizeName(user.Surname + " " + user.Name):                         true,
+			normalizeName(user.Surname + " " + user.Name + " " + user.Patronymic): true,
+			normalizeName(user.Name + " " + user.Surname):                         true,
+		}
+		for name := range names {
+			directory.byName[name] = append(directory.byName[name], user.ID)
+		}
+	}
+	return directory, nil
+}
+
+// match finds a user by passport number or, if it is empty, by name. On failure it returns
+// the field that could not be matched.
+func (d userDirectory) match(passport, name string) (uint, string, error) {
+	if passport != "" {
+		if id, ok := d.byPassport[normalizeSpace(passport)]; ok {
+			return id, FieldPassport, nil
+		}
+		return 0, FieldPassport, fmt.Errorf("no user with passport number %q", passport)
+	}
+
+	if name == "" {
+		return 0, FieldUser, errors.New("missing user: passport number or name is required")
+	}
+	switch ids := d.byName[normalizeName(name)]; len(ids) {
+	case 0:
+		return 0, FieldUser, fmt.Errorf("no user named %q", name)
+	case 1:
+		return ids[0], FieldUser, nil
+	default:
+		return 0, FieldUser, fmt.Errorf("%d users are named %q, use the passport number", len(ids), name)
+	}
+}
+
+// findOverlaps reports entries that overlap other entries of the same user or intervals already tracked for them.
+// Running intervals are considered to last until now.
+func findOverlaps(db *gorm.DB, entries []timesheetEntry, now time.Time) ([]RowError, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	// An item is either an entry (row > 0) or an existing interval (taskID > 0).
+	type item struct {
+		userID     uint
+		start, end time.Time
+		row        int
+		taskID     uint
+	}
+
+	items := make([]item, 0, len(entries))
+	from, to := entries[0].task.StartTime, entries[0].task.EndTime
+	for _, entry := range entries {
+		items = append(items, item{userID: entry.task.UserID, start: entry.task.StartTime, end: entry.task.EndTime, row: entry.row})
+		if entry.task.StartTime.Before(from) {
+			from = entry.task.StartTime
+		}
+		if entry.task.EndTime.After(to) {
+			to = entry.task.EndTime
+		}
+	}
+
+	var existing []struct {
+		UserID    uint
+		TaskID    uint
+		StartTime time.Time
+		EndTime   time.Time
+	}
+	err := db.Raw(`
+		SELECT t.user_id, i.task_id, i.start_time, COALESCE(i.end_time, @now) AS end_time
+		FROM task_intervals i
+		JOIN tasks t ON t.id = i.task_id AND t.deleted_at IS NULL
+		WHERE i.deleted_at IS NULL AND t.user_id IN @userIDs AND i.start_time < @to AND COALESCE(i.end_time, @now) > @from
+	`, map[string]interface{}{"now": now, "userIDs": distinctUsers(entries), "from": from, "to": to}).Scan(&existing).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, interval := range existing {
+		items = append(items, item{userID: interval.UserID, start: interval.StartTime, end: interval.EndTime, taskID: interval.TaskID})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].userID != items[j].userID {
+			return items[i].userID < items[j].userID
+		}
+		return items[i].start.Before(items[j].start)
+	})
+
+	// Sweeping the items of each user in order of start, every item that starts before the latest end so far
+	// overlaps the item with that end.
+	var overlaps []RowError
+	var latest *item
+	for i := range items {
+		current := &items[i]
+		if latest != nil && latest.userID == current.userID && current.start.Before(latest.end) {
+			switch {
+			case current.row > 0 && latest.row > 0:
+				overlaps = append(overlaps, RowError{Row: current.row, Error: fmt.Sprintf("overlaps row %d", latest.row)})
+			case current.row > 0:
+				overlaps = append(overlaps, RowError{Row: current.row, Error: fmt.Sprintf("overlaps tracked time of task %d", latest.taskID)})
+			case latest.row > 0:
+				overlaps = append(overlaps, RowError{Row: latest.row, Error: fmt.Sprintf("overlaps tracked time of task %d", current.taskID)})
+			}
+		}
+		if latest == nil || latest.userID != current.userID || current.end.After(latest.end) {
+			latest = current
+		}
+	}
+	return overlaps, nil
+}
+
+// distinctUsers returns the IDs of the users of the entries.
+func distinctUsers(entries []timesheetEntry) []uint {
+	seen := map[uint]bool{}
+	var ids []uint
+	for _, entry := range entries {
+		if !seen[entry.task.UserID] {
+			seen[entry.task.UserID] = true
+			ids = append(ids, entry.task.UserID)
+		}
+	}
+	return ids
+}
+
+// parseTimesheetDuration parses a duration given as "h:mm", "h:mm:ss" or a number in the given unit.
+// A decimal comma is accepted.
+func parseTimesheetDuration(value, unit string) (time.Duration, error) {
+	if strings.Contains(value, ":") {
+		parts := strings.Split(value, ":")
+		if len(parts) > 3 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		var total time.Duration
+		units := []time.Duration{time.Hour, time.Minute, time.Second}
+		for i, part := range parts {
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 0 || (i > 0 && n >= 60) {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			total += time.Duration(n) * units[i]
+		}
+		return total, nil
+	}
+
+	number, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	if number < 0 {
+		return 0, fmt.Errorf("negative duration %q", value)
+	}
+	if unit == DurationHours {
+		return time.Duration(number * float64(time.Hour)), nil
+	}
+	return time.Duration(number * float64(time.Minute)), nil
+}
+
+// parseTime parses a value with the first matching layout.
+func parseTime(value string, layouts []string, loc *time.Location) (time.Time, error) {
+	var err error
+	for _, layout := range layouts {
+		var parsed time.Time
+		if parsed, err = time.ParseInLocation(layout, value, loc); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// parseBool parses a billable flag, accepting the usual English and Russian spellings.
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "1", "true", "yes", "y", "да", "billable":
+		return true, nil
+	case "0", "false", "no", "n", "нет", "non-billable":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid billable flag %q", value)
+}
+
+// isTimesheetField reports whether name is a known timesheet field.
+func isTimesheetField(name string) bool {
+	for _, field := range TimesheetFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlank reports whether all values of the record are empty.
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeSpace collapses runs of white space into single spaces.
+func normalizeSpace(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// normalizeName normalizes a person's name for case-insensitive matching.
+func normalizeName(name string) string {
+	return strings.ToLower(normalizeSpace(name))
+}
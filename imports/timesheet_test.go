@@ -0,0 +1,226 @@
+package imports
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// moscow is a fixed zone, so that tests do not depend on the time zone of the machine.
+var moscow = time.FixedZone("MSK", 3*60*60)
+
+func TestParseTimesheetDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		unit  string
+		want  time.Duration
+	}{
+		{"90", DurationMinutes, 90 * time.Minute},
+		{"1.5", DurationHours, 90 * time.Minute},
+		{"1,5", DurationHours, 90 * time.Minute},
+		{"0,25", DurationMinutes, 15 * time.Second},
+		{"0", DurationMinutes, 0},
+		{"1:30", DurationMinutes, 90 * time.Minute},
+		{"1:30", DurationHours, 90 * time.Minute},
+		{"01:30:15", DurationMinutes, time.Hour + 30*time.Minute + 15*time.Second},
+		{"25:00", DurationMinutes, 25 * time.Hour},
+		{"0:00:59", DurationMinutes, 59 * time.Second},
+	}
+	for _, tt := range tests {
+		got, err := parseTimesheetDuration(tt.value, tt.unit)
+		if err != nil || got != tt.want {
+			t.Errorf("parseTimesheetDuration(%q, %s) = %s, %v; want %s", tt.value, tt.unit, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "abc", "1h30m", "-5", "1:60", "1:-5", "1:30:60", "1:2:3:4", ":30", "1.5:00"} {
+		if got, err := parseTimesheetDuration(value, DurationMinutes); err == nil {
+			t.Errorf("parseTimesheetDuration(%q) = %s, want an error", value, got)
+		}
+	}
+}
+
+func TestImportTimesheetInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		options TimesheetOptions
+		want    string
+	}{
+		{"empty file", "", TimesheetOptions{}, "the file is empty"},
+		{"unknown duration unit", "user,start,end\n", TimesheetOptions{DurationUnit: "days"}, `unknown duration unit "days"`},
+		{"no user column", "description,start,end\n", TimesheetOptions{}, "a passport_number, user or surname and name column is required"},
+		{"only a surname column", "surname,start,end\n", TimesheetOptions{}, "a passport_number, user or surname and name column is required"},
+		{"no start column", "user,end\n", TimesheetOptions{}, "a start column is required"},
+		{"no end or duration column", "user,start\n", TimesheetOptions{}, "an end or duration column is required"},
+		{"missing mapped column", "user,start,end\n", TimesheetOptions{Columns: map[string]string{FieldDescription: "Comment"}}, `column "Comment" mapped to description is missing`},
+		{"unknown field", "user,start,end\n", TimesheetOptions{Columns: map[string]string{"hours": "Hours"}}, `unknown field "hours"`},
+		{"unterminated quote", "\"user,start,end\n", TimesheetOptions{}, "extraneous or missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// All of these fail before the database is used.
+			_, err := ImportTimesheet(nil, strings.NewReader(tt.input), tt.options, time.Now())
+			if !errors.Is(err, ErrInvalidTimesheet) {
+				t.Fatalf("got error %v, want ErrInvalidTimesheet", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimesheetParseRow(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		header     string
+		record     string
+		options    TimesheetOptions
+		start, end time.Time
+		errors     []RowError
+	}{
+		{
+			name:   "start and end with the default layouts",
+			header: "passport_number,start,end",
+			record: "1234 567890,2024-03-04 09:00,04.03.2024 10:30",
+			start:  time.Date(2024, 3, 4, 9, 0, 0, 0, moscow),
+			end:    time.Date(2024, 3, 4, 10, 30, 0, 0, moscow),
+		},
+		{
+			name:   "offset in the time",
+			header: "passport_number,start,end",
+			record: "1234 567890,2024-03-04T09:00:00Z,2024-03-04T10:00:00+01:00",
+			start:  time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+			end:    time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "date and times of day",
+			header: "user,date,start,end",
+			record: "Petrov Ivan,04.03.2024,9:00,18:15:30",
+			start:  time.Date(2024, 3, 4, 9, 0, 0, 0, moscow),
+			end:    time.Date(2024, 3, 4, 18, 15, 30, 0, moscow),
+		},
+		{
+			name:   "duration in minutes",
+			header: "surname,name,start,duration",
+			record: "petrov,IVAN,2024-03-04 23:30,90",
+			start:  time.Date(2024, 3, 4, 23, 30, 0, 0, moscow),
+			end:    time.Date(2024, 3, 5, 1, 0, 0, 0, moscow),
+		},
+		{
+			name:    "duration in hours and a custom layout",
+			header:  "user,start,duration",
+			record:  "Ivan Petrov,04/03/2024 9am,\"1,5\"",
+			options: TimesheetOptions{TimeLayout: "02/01/2006 3pm", DurationUnit: DurationHours},
+			start:   time.Date(2024, 3, 4, 9, 0, 0, 0, moscow),
+			end:     time.Date(2024, 3, 4, 10, 30, 0, 0, moscow),
+		},
+		{
+			name:    "end takes precedence over duration",
+			header:  "user,start,end,duration",
+			record:  "Ivan Petrov,2024-03-04 09:00,2024-03-04 09:45,120",
+			options: TimesheetOptions{Location: time.UTC},
+			start:   time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+			end:     time.Date(2024, 3, 4, 9, 45, 0, 0, time.UTC),
+		},
+		{
+			name:   "unknown user and invalid billable flag",
+			header: "User,Billable,Start,End",
+			record: "Sidorov Petr,sometimes,2024-03-04 09:00,2024-03-04 10:00",
+			errors: []RowError{
+				{Row: 2, Column: "User", Error: `no user named "Sidorov Petr"`},
+				{Row: 2, Column: "Billable", Error: `invalid billable flag "sometimes"`},
+			},
+		},
+		{
+			name:   "invalid date",
+			header: "user,date,start,end",
+			record: "Ivan Petrov,2024-02-30,09:00,10:00",
+			errors: []RowError{{Row: 2, Column: "date", Error: `invalid date "2024-02-30"`}},
+		},
+		{
+			name:   "invalid start",
+			header: "user,start,end",
+			record: "Ivan Petrov,yesterday,2024-03-04 10:00",
+			errors: []RowError{{Row: 2, Column: "start", Error: `invalid start time "yesterday"`}},
+		},
+		{
+			name:   "end before start",
+			header: "user,start,end",
+			record: "Ivan Petrov,2024-03-04 10:00,2024-03-04 09:00",
+			errors: []RowError{{Row: 2, Column: "end", Error: "end 2024-03-04T09:00:00+03:00 is before start 2024-03-04T10:00:00+03:00"}},
+		},
+		{
+			name:   "invalid duration",
+			header: "user,start,duration",
+			record: "Ivan Petrov,2024-03-04 10:00,1:90",
+			errors: []RowError{{Row: 2, Column: "duration", Error: `invalid duration "1:90"`}},
+		},
+		{
+			name:   "missing end and duration",
+			header: "user,start,end,duration",
+			record: "Ivan Petrov,2024-03-04 10:00,,",
+			errors: []RowError{{Row: 2, Column: "end", Error: "missing end or duration"}},
+		},
+		{
+			name:   "end in the future",
+			header: "user,start,end",
+			record: "Ivan Petrov,2024-03-10 14:00,2024-03-10 15:30",
+			errors: []RowError{{Row: 2, Column: "end", Error: "end 2024-03-10T15:30:00+03:00 is in the future"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := tt.options
+			options.Comma = ','
+			if options.Location == nil {
+				options.Location = moscow
+			}
+			if options.DurationUnit == "" {
+				options.DurationUnit = DurationMinutes
+			}
+			reader := timesheetReader{options: options, users: userDirectory{
+				byPassport: map[string]uint{"1234 567890": 7},
+				byName:     map[string][]uint{"petrov ivan": {7}, "ivan petrov": {7}},
+			}}
+			if err := reader.mapColumns(strings.Split(tt.header, ",")); err != nil {
+				t.Fatal(err)
+			}
+			record, err := csv.NewReader(strings.NewReader(tt.record)).Read()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry, rowErrors := reader.parseRow(2, record, now)
+			if len(rowErrors) != len(tt.errors) {
+				t.Fatalf("got errors %+v, want %+v", rowErrors, tt.errors)
+			}
+			for i := range tt.errors {
+				if rowErrors[i] != tt.errors[i] {
+					t.Errorf("error %d = %+v, want %+v", i, rowErrors[i], tt.errors[i])
+				}
+			}
+			if len(tt.errors) > 0 {
+				return
+			}
+
+			if entry.task.UserID != 7 {
+				t.Errorf("task belongs to user %d, want 7", entry.task.UserID)
+			}
+			if len(entry.task.Intervals) != 1 {
+				t.Fatalf("task has %d intervals, want 1", len(entry.task.Intervals))
+			}
+			interval := entry.task.Intervals[0]
+			if !interval.StartTime.Equal(tt.start) || interval.EndTime == nil || !interval.EndTime.Equal(tt.end) {
+				t.Errorf("interval lasts from %s to %v, want %s to %s", interval.StartTime, interval.EndTime, tt.start, tt.end)
+			}
+			if want := int(tt.end.Sub(tt.start).Minutes()); entry.task.Duration != want {
+				t.Errorf("task duration is %d, want %d", entry.task.Duration, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package imports
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// togglEntry is a time entry of a Toggl Track JSON export. It covers the detailed report
+// of the Reports API v2 and the time entries of the API v9 requested with meta=true.
+type togglEntry struct {
+	ID          json.Number     `json:"id"`
+	User        string          `json:"user"`         // Reports API v2
+	UserName    string          `json:"user_name"`    // API v9
+	Client      string          `json:"client"`       // Reports API v2
+	ClientName  string          `json:"client_name"`  // API v9
+	Project     string          `json:"project"`      // Reports API v2
+	ProjectName string          `json:"project_name"` // API v9
+	Task        string          `json:"task"`
+	Description string          `json:"description"`
+	Start       time.Time       `json:"start"`
+	End         *time.Time      `json:"end"`  // Reports API v2
+	Stop        *time.Time      `json:"stop"` // API v9
+	IsBillable  *bool           `json:"is_billable"`
+	Billable    json.RawMessage `json:"billable"` // The billable amount in the Reports API v2, the billable flag in the API v9
+	Tags        []string        `json:"tags"`
+}
+
+// ParseToggl reads a Toggl Track export: the detailed report as CSV or JSON, or a list of time entries as JSON.
+func ParseToggl(r io.Reader, options TrackerParseOptions) ([]TrackerEntry, error) {
+	options = options.withDefaults()
+	input := bufio.NewReader(r)
+	if ok, err := isJSON(input); err != nil || !ok {
+		if err != nil {
+			return nil, err
+		}
+		return readTrackerCSV(input, options)
+	}
+
+	var report struct {
+		Data []togglEntry `json:"data"`
+	}
+	var err error
+	if head, _ := input.Peek(1); string(head) == "[" {
+		err = json.NewDecoder(input).Decode(&report.Data)
+	} else {
+		err = json.NewDecoder(input).Decode(&report)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+
+	entries := make([]TrackerEntry, len(report.Data))
+	for i, item := range report.Data {
+		entry := TrackerEntry{
+			Row:         i + 1,
+			ID:          item.ID.String(),
+			User:        firstNonEmpty(item.User, item.UserName),
+			Client:      firstNonEmpty(item.Client, item.ClientName),
+			Project:     firstNonEmpty(item.Project, item.ProjectName),
+			Description: firstNonEmpty(item.Description, item.Task),
+			Tags:        item.Tags,
+			Start:       item.Start,
+		}
+		if item.IsBillable != nil {
+			entry.Billable = *item.IsBillable
+		} else {
+			entry.Billable = strings.TrimSpace(string(item.Billable)) == "true"
+		}
+		switch {
+		case item.End != nil:
+			entry.End = *item.End
+		case item.Stop != nil:
+			entry.End = *item.Stop
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// firstNonEmpty returns the first of the values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
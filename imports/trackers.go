@@ -0,0 +1,676 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"time-tracker-go/models"
+
+	"gorm.io/gorm"
+)
+
+// Time trackers whose exports can be imported.
+const (
+	SourceToggl    = "toggl"
+	SourceClockify = "clockify"
+)
+
+// Reasons for which time tracker entries are not imported.
+const (
+	SkipUnknownUser = "unknown_user"
+	SkipOverlap     = "overlap"
+)
+
+// trackerLookupSize is the number of external IDs looked up per query.
+const trackerLookupSize = 1000
+
+// ErrInvalidExport is returned when a time tracker export cannot be read.
+var ErrInvalidExport = errors.New("invalid export")
+
+// trackerDateLayouts are the layouts tried for dates in CSV exports when no layout is configured.
+var trackerDateLayouts = []string{"2006-01-02", "01/02/2006", "02.01.2006"}
+
+// trackerClockLayouts are the layouts tried for times of day in CSV exports.
+var trackerClockLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "3:04:05 PM", "03:04 PM", "3:04 PM"}
+
+// TrackerEntry is a time entry read from the export of another time tracker.
+type TrackerEntry struct {
+	Row         int       // Line in a CSV export or position in a JSON export
+	ID          string    // ID of the entry in the time tracker (empty if the export has none)
+	User        string    // Display name of the user
+	Client      string    // Client name
+	Project     string    // Project name
+	Description string    // Description of the entry, or its task name if it has none
+	Tags        []string  // Tag names
+	Billable    bool      // Whether the entry is billable
+	Start       time.Time // Start of the entry
+	End         time.Time // End of the entry (zero for running entries)
+}
+
+// TrackerParseOptions control how time tracker exports are read.
+type TrackerParseOptions struct {
+	Comma      rune           // Field delimiter of CSV exports (default ",")
+	DateLayout string         // Layout of dates in CSV exports (empty to try common layouts)
+	Location   *time.Location // Time zone of CSV exports, which have no offsets (default: UTC)
+}
+
+// TrackerOptions control how time tracker entries are imported.
+type TrackerOptions struct {
+	Source        string // Time tracker the entries come from
+	UserID        *uint  // User all entries are imported for (nil to match users by name)
+	CreateMissing bool   // Create the clients, projects and tags that do not exist yet
+	DryRun        bool   // Report what would be imported without changing anything
+}
+
+// MappingItem is a user, client, project or tag of the export that was not found in this service.
+type MappingItem struct {
+	Name    string `json:"name"`             // Name in the export
+	Client  string `json:"client,omitempty"` // Client of a project
+	Entries int    `json:"entries"`          // Number of entries referring to the item
+}
+
+// Mapping lists users, clients, projects and tags of an export.
+type Mapping struct {
+	Users    []MappingItem `json:"users"`
+	Clients  []MappingItem `json:"clients"`
+	Projects []MappingItem `json:"projects"`
+	Tags     []MappingItem `json:"tags"`
+}
+
+// SkippedEntry is a time tracker entry that was not imported.
+type SkippedEntry struct {
+	Row    int    `json:"row"`          // Line in a CSV export or position in a JSON export
+	ID     string `json:"id,omitempty"` // ID of the entry in the time tracker
+	Reason string `json:"reason"`       // Machine-readable reason the entry was skipped
+}
+
+// TrackerResult is the outcome of an import from another time tracker.
+type TrackerResult struct {
+	Source    string         `json:"source"`    // Time tracker the entries come from
+	DryRun    bool           `json:"dryRun"`    // Whether the import was a preview only
+	Entries   int            `json:"entries"`   // Number of entries read
+	Created   int            `json:"created"`   // Number of tasks created (or that would be created in a dry run)
+	Skipped   []SkippedEntry `json:"skipped"`   // Entries that were not imported
+	Unmatched Mapping        `json:"unmatched"` // Items that were not found; entries of unknown users are skipped, other items are left out of the tasks
+	Added     Mapping        `json:"added"`     // Clients, projects and tags created (or that would be created) with CreateMissing
+}
+
+// trackerCatalogue resolves names of an export to clients, projects and tags, creating the missing ones if requested.
+type trackerCatalogue struct {
+	createMissing bool
+	clients       map[string]*models.Client
+	projects      map[[2]string]*models.Project // By project and client name
+	projectNames  map[string][]*models.Project  // By project name
+	tags          map[string]*models.Tag
+	newClients    []*models.Client
+	newProjects   []*models.Project
+	newTags       []*models.Tag
+	created       map[interface{}]bool         // Clients, projects and tags that do not exist yet
+	unmatched     map[string]map[[2]string]int // Entry counts of unmatched items by kind, name and client
+	added         map[string]map[[2]string]int // Entry counts of created items by kind, name and client
+}
+
+// trackerTask is a task to be created, referring to catalogue items that may not have IDs yet.
+type trackerTask struct {
+	entry   TrackerEntry
+	task    models.Task
+	project *models.Project
+	tags    []*models.Tag
+}
+
+// ImportTrackerEntries imports time tracker entries as done tasks with a single closed interval each.
+//
+// Entries are assigned to options.UserID or to the user whose name matches the display name in either order
+// ("Ivan Ivanov" or "Ivanov Ivan"). Projects are matched by name and client, clients and tags by name.
+// Entries of unknown users are skipped; unknown projects and tags are left out unless CreateMissing is set.
+// Entries are identified by their ID in the time tracker (or by their contents if the export has no IDs),
+// so importing the same export again creates only the new entries. Running entries, entries that overlap
+// other entries or already tracked time of the user, and entries in weeks whose timesheets are approved
+// are skipped.
+// Everything is checked and created in one transaction holding the locks of the users' rows, so that
+// their weeks cannot be approved and the same entries cannot be imported concurrently.
+func ImportTrackerEntries(db *gorm.DB, entries []TrackerEntry, options TrackerOptions, now time.Time) (TrackerResult, error) {
+	result := TrackerResult{Source: options.Source, DryRun: options.DryRun, Entries: len(entries), Skipped: []SkippedEntry{}}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		users, err := loadUsers(tx)
+		if err != nil {
+			return err
+		}
+		catalogue, err := loadTrackerCatalogue(tx, options.CreateMissing)
+		if err != nil {
+			return err
+		}
+
+		var tasks []trackerTask
+		unknownUsers := map[[2]string]int{}
+		seen := map[string]bool{}
+		for _, entry := range entries {
+			externalID := trackerExternalID(options.Source, entry)
+			reason := ""
+			switch {
+			case entry.End.IsZero(), entry.End.After(now):
+				reason = SkipNotFinished
+			case !entry.End.After(entry.Start):
+				reason = SkipInvalidInterval
+			case seen[externalID]:
+				reason = SkipDuplicate
+			}
+			seen[externalID] = true
+
+			userID := uint(0)
+			if reason == "" && options.UserID != nil {
+				userID = *options.UserID
+			} else if reason == "" {
+				if userID, _, err = users.match("", entry.User); err != nil {
+					unknownUsers[[2]string{normalizeSpace(entry.User)}]++
+					reason = SkipUnknownUser
+				}
+			}
+			if reason != "" {
+				result.Skipped = append(result.Skipped, SkippedEntry{Row: entry.Row, ID: entry.ID, Reason: reason})
+				continue
+			}
+
+			end := entry.End
+			task := trackerTask{entry: entry, task: models.Task{
+				UserID:      userID,
+				Description: strings.TrimSpace(entry.Description),
+				ExternalID:  &externalID,
+				Billable:    entry.Billable,
+				Status:      models.TaskStatusDone,
+				Tags:        []models.Tag{},
+				Intervals:   []models.TaskInterval{{StartTime: entry.Start, EndTime: &end}},
+			}}
+			task.task.RecalculateDuration()
+			tasks = append(tasks, task)
+		}
+
+		// Locking the users keeps their weeks from being approved and other imports of their entries
+		// from committing until the checks below are committed.
+		if tasks, err = checkTrackerTasks(tx, tasks, now, &result); err != nil {
+			return err
+		}
+
+		// Entries are matched to the catalogue only once they are known to be imported,
+		// so the mapping does not list names of skipped entries.
+		for i := range tasks {
+			tasks[i].project = catalogue.project(tasks[i].entry.Project, tasks[i].entry.Client)
+			for _, name := range tasks[i].entry.Tags {
+				if tag := catalogue.tag(name); tag != nil {
+					tasks[i].tags = append(tasks[i].tags, tag)
+				}
+			}
+		}
+		sort.SliceStable(result.Skipped, func(i, j int) bool {
+			return result.Skipped[i].Row < result.Skipped[j].Row
+		})
+
+		result.Created = len(tasks)
+		result.Unmatched = catalogue.mapping(catalogue.unmatched)
+		result.Unmatched.Users = mappingItems(unknownUsers)
+		result.Added = catalogue.mapping(catalogue.added)
+		if options.DryRun {
+			return nil
+		}
+
+		if err := catalogue.save(tx); err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+
+		created := make([]models.Task, len(tasks))
+		for i, task := range tasks {
+			created[i] = task.task
+			if task.project != nil {
+				created[i].ProjectID = &task.project.ID
+			}
+			for _, tag := range task.tags {
+				created[i].Tags = append(created[i].Tags, *tag)
+			}
+		}
+		return tx.CreateInBatches(&created, timesheetBatchSize).Error
+	})
+	return result, err
+}
+
+// checkTrackerTasks locks the users of the tasks and skips the tasks that were already imported,
+// overlap other tasks of the import or tracked time of their user, or fall into approved weeks.
+// It returns the tasks that can be imported.
+func checkTrackerTasks(tx *gorm.DB, tasks []trackerTask, now time.Time, result *TrackerResult) ([]trackerTask, error) {
+	if len(tasks) == 0 {
+		return tasks, nil
+	}
+
+	entries := make([]timesheetEntry, len(tasks))
+	externalIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		entries[i] = timesheetEntry{row: task.entry.Row, task: task.task}
+		externalIDs[i] = *task.task.ExternalID
+	}
+	if err := lockUsers(tx, distinctUsers(entries)); err != nil {
+		return nil, err
+	}
+
+	skip := func(tasks []trackerTask, reason string, skipped func(trackerTask) bool) []trackerTask {
+		kept := tasks[:0]
+		for _, task := range tasks {
+			if skipped(task) {
+				result.Skipped = append(result.Skipped, SkippedEntry{Row: task.entry.Row, ID: task.entry.ID, Reason: reason})
+				continue
+			}
+			kept = append(kept, task)
+		}
+		return kept
+	}
+
+	imported, err := importedExternalIDs(tx, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	tasks = skip(tasks, SkipAlreadyImported, func(task trackerTask) bool { return imported[*task.task.ExternalID] })
+
+	// Like rows of a CSV timesheet, entries must not overlap each other or time the user already tracked.
+	entries = entries[:0]
+	for _, task := range tasks {
+		entries = append(entries, timesheetEntry{row: task.entry.Row, task: task.task})
+	}
+	overlaps, err := findOverlaps(tx, entries, now)
+	if err != nil {
+		return nil, err
+	}
+	overlapping := map[int]bool{}
+	for _, overlap := range overlaps {
+		overlapping[overlap.Row] = true
+	}
+	tasks = skip(tasks, SkipOverlap, func(task trackerTask) bool { return overlapping[task.entry.Row] })
+
+	// Time cannot be imported into weeks whose timesheets are approved.
+	candidates := make([]models.Task, len(tasks))
+	for i, task := range tasks {
+		candidates[i] = task.task
+	}
+	locks, err := loadLocks(tx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	return skip(tasks, SkipPeriodLocked, func(task trackerTask) bool {
+		_, locked := locks.Locked(task.task.UserID, taskPeriod(task.task))
+		return locked
+	}), nil
+}
+
+// loadTrackerCatalogue loads the existing clients, projects and tags.
+func loadTrackerCatalogue(db *gorm.DB, createMissing bool) (*trackerCatalogue, error) {
+	c := &trackerCatalogue{
+		createMissing: createMissing,
+		clients:       map[string]*models.Client{},
+		projects:      map[[2]string]*models.Project{},
+		projectNames:  map[string][]*models.Project{},
+		tags:          map[string]*models.Tag{},
+		created:       map[interface{}]bool{},
+		unmatched:     map[string]map[[2]string]int{},
+		added:         map[string]map[[2]string]int{},
+	}
+
+	var clients []models.Client
+	if err := db.Find(&clients).Error; err != nil {
+		return nil, err
+	}
+	clientNames := map[uint]string{}
+	for i := range clients {
+		c.clients[catalogueKey(clients[i].Name)] = &clients[i]
+		clientNames[clients[i].ID] = catalogueKey(clients[i].Name)
+	}
+
+	var projects []models.Project
+	if err := db.Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		project := &projects[i]
+		clientName := ""
+		if project.ClientID != nil {
+			clientName = clientNames[*project.ClientID]
+		}
+		name := catalogueKey(project.Name)
+		c.projects[[2]string{name, clientName}] = project
+		c.projectNames[name] = append(c.projectNames[name], project)
+	}
+
+	var tags []models.Tag
+	if err := db.Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	for i := range tags {
+		c.tags[tags[i].Name] = &tags[i]
+	}
+	return c, nil
+}
+
+// project resolves a project of the export. A project without a client in the export
+// matches an existing project with the same name if it is the only one.
+func (c *trackerCatalogue) project(name, client string) *models.Project {
+	name, client = normalizeSpace(name), normalizeSpace(client)
+	if name == "" {
+		return nil
+	}
+
+	key := [2]string{catalogueKey(name), catalogueKey(client)}
+	project, ok := c.projects[key]
+	if !ok && client == "" && len(c.projectNames[key[0]]) == 1 {
+		project, ok = c.projectNames[key[0]][0], true
+	}
+
+	switch {
+	case ok:
+	case !c.createMissing:
+		c.count(c.unmatched, "projects", name, client)
+		if _, ok := c.clients[key[1]]; client != "" && !ok {
+			c.count(c.unmatched, "clients", client, "")
+		}
+		return nil
+	default:
+		project = &models.Project{Name: name}
+		if client != "" {
+			if c.clients[key[1]] == nil {
+				c.clients[key[1]] = &models.Client{Name: client}
+				c.newClients = append(c.newClients, c.clients[key[1]])
+				c.created[c.clients[key[1]]] = true
+			}
+			project.Client = c.clients[key[1]]
+		}
+		c.projects[key] = project
+		c.newProjects = append(c.newProjects, project)
+		c.created[project] = true
+	}
+
+	if c.created[project] {
+		clientName := ""
+		if project.Client != nil {
+			clientName = project.Client.Name
+			if c.created[project.Client] {
+				c.count(c.added, "clients", clientName, "")
+			}
+		}
+		c.count(c.added, "projects", project.Name, clientName)
+	}
+	return project
+}
+
+// tag resolves a tag of the export.
+func (c *trackerCatalogue) tag(name string) *models.Tag {
+	key := catalogueKey(name)
+	if key == "" {
+		return nil
+	}
+
+	tag, ok := c.tags[key]
+	switch {
+	case ok:
+	case !c.createMissing:
+		c.count(c.unmatched, "tags", key, "")
+		return nil
+	default:
+		tag = &models.Tag{Name: key}
+		c.tags[key] = tag
+		c.newTags = append(c.newTags, tag)
+		c.created[tag] = true
+	}
+
+	if c.created[tag] {
+		c.count(c.added, "tags", tag.Name, "")
+	}
+	return tag
+}
+
+// count counts an entry referring to an item of the given kind.
+func (c *trackerCatalogue) count(counts map[string]map[[2]string]int, kind, name, client string) {
+	if counts[kind] == nil {
+		counts[kind] = map[[2]string]int{}
+	}
+	counts[kind][[2]string{name, client}]++
+}
+
+// mapping lists the counted items by kind.
+func (c *trackerCatalogue) mapping(counts map[string]map[[2]string]int) Mapping {
+	return Mapping{
+		Users:    []MappingItem{},
+		Clients:  mappingItems(counts["clients"]),
+		Projects: mappingItems(counts["projects"]),
+		Tags:     mappingItems(counts["tags"]),
+	}
+}
+
+// save creates the missing clients, projects and tags.
+func (c *trackerCatalogue) save(tx *gorm.DB) error {
+	for _, client := range c.newClients {
+		if err := tx.Create(client).Error; err != nil {
+			return err
+		}
+	}
+	for _, project := range c.newProjects {
+		if project.Client != nil {
+			project.ClientID = &project.Client.ID
+		}
+		if err := tx.Omit("Client").Create(project).Error; err != nil {
+			return err
+		}
+	}
+	for _, tag := range c.newTags {
+		if err := tx.Create(tag).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mappingItems turns counts by name and client into a list ordered by name.
+func mappingItems(counts map[[2]string]int) []MappingItem {
+	items := make([]MappingItem, 0, len(counts))
+	for key, entries := range counts {
+		items = append(items, MappingItem{Name: key[0], Client: key[1], Entries: entries})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Name != items[j].Name {
+			return items[i].Name < items[j].Name
+		}
+		return items[i].Client < items[j].Client
+	})
+	return items
+}
+
+// importedExternalIDs returns which of the external IDs belong to existing tasks, including deleted ones.
+func importedExternalIDs(db *gorm.DB, externalIDs []string) (map[string]bool, error) {
+	imported := map[string]bool{}
+	for start := 0; start < len(externalIDs); start += trackerLookupSize {
+		end := start + trackerLookupSize
+		if end > len(externalIDs) {
+			end = len(externalIDs)
+		}
+		var found []string
+		if err := db.Unscoped().Model(&models.Task{}).Where("external_id IN ?", externalIDs[start:end]).Pluck("external_id", &found).Error; err != nil {
+			return nil, err
+		}
+		for _, externalID := range found {
+			imported[externalID] = true
+		}
+	}
+	return imported, nil
+}
+
+// trackerExternalID returns the Task.ExternalID of an entry. Entries without an ID are identified by their contents.
+func trackerExternalID(source string, entry TrackerEntry) string {
+	id := entry.ID
+	if id == "" {
+		sum := sha1.Sum([]byte(strings.Join([]string{
+			entry.User, entry.Project, entry.Client, entry.Description,
+			entry.Start.UTC().Format(time.RFC3339), entry.End.UTC().Format(time.RFC3339),
+		}, "|")))
+		id = hex.EncodeToString(sum[:])
+	}
+	return source + ":" + id
+}
+
+// catalogueKey normalizes a client, project or tag name for case-insensitive matching.
+func catalogueKey(name string) string {
+	return strings.ToLower(normalizeSpace(name))
+}
+
+// isJSON reports whether the buffered input starts with a JSON object or array, skipping a byte order mark and white space.
+func isJSON(r *bufio.Reader) (bool, error) {
+	for {
+		head, err := r.Peek(1)
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case bytes.HasPrefix(head, []byte{0xEF}):
+			if bom, _ := r.Peek(3); bytes.Equal(bom, []byte("\xEF\xBB\xBF")) {
+				r.Discard(3)
+				continue
+			}
+			return false, nil
+		case head[0] == ' ' || head[0] == '\t' || head[0] == '\r' || head[0] == '\n':
+			r.Discard(1)
+		default:
+			return head[0] == '{' || head[0] == '[', nil
+		}
+	}
+}
+
+// readTrackerCSV reads a detailed report exported as CSV. Toggl Track and Clockify use the same column names
+// for the fields that are imported: User, Client, Project, Task, Description, Billable, Tags,
+// Start date, Start time, End date, End time and Duration.
+func readTrackerCSV(r io.Reader, options TrackerParseOptions) ([]TrackerEntry, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.Comma = options.Comma
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidExport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		// Clockify names the duration column after its format, e.g. "Duration (h)".
+		if strings.HasPrefix(column, "duration (h") {
+			column = "duration"
+		}
+		if _, ok := columns[column]; !ok {
+			columns[column] = i
+		}
+	}
+	for _, required := range []string{"start date", "start time"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing %q column", ErrInvalidExport, required)
+		}
+	}
+
+	dateLayouts := trackerDateLayouts
+	if options.DateLayout != "" {
+		dateLayouts = []string{options.DateLayout}
+	}
+	dateTime := func(record []string, date, clock string) (time.Time, error) {
+		day, err := parseTime(csvValue(record, columns, date), dateLayouts, options.Location)
+		if err != nil {
+			return day, fmt.Errorf("invalid %s %q", date, csvValue(record, columns, date))
+		}
+		t, err := parseTime(strings.ToUpper(csvValue(record, columns, clock)), trackerClockLayouts, time.UTC)
+		if err != nil {
+			return t, fmt.Errorf("invalid %s %q", clock, csvValue(record, columns, clock))
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), t.Second(), 0, options.Location), nil
+	}
+
+	var entries []TrackerEntry
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+		}
+		line, _ := csvReader.FieldPos(0)
+		if isBlank(record) {
+			continue
+		}
+
+		entry := TrackerEntry{
+			Row:         line,
+			User:        csvValue(record, columns, "user"),
+			Client:      csvValue(record, columns, "client"),
+			Project:     csvValue(record, columns, "project"),
+			Description: csvValue(record, columns, "description"),
+		}
+		if entry.Description == "" {
+			entry.Description = csvValue(record, columns, "task")
+		}
+		if billable := csvValue(record, columns, "billable"); billable != "" {
+			if entry.Billable, err = parseBool(billable); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidExport, line, err)
+			}
+		}
+		for _, tag := range strings.Split(csvValue(record, columns, "tags"), ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		}
+
+		if entry.Start, err = dateTime(record, "start date", "start time"); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidExport, line, err)
+		}
+		if csvValue(record, columns, "end date") != "" && csvValue(record, columns, "end time") != "" {
+			if entry.End, err = dateTime(record, "end date", "end time"); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidExport, line, err)
+			}
+		} else if duration := csvValue(record, columns, "duration"); duration != "" {
+			length, err := parseTimesheetDuration(duration, DurationHours)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidExport, line, err)
+			}
+			entry.End = entry.Start.Add(length)
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// csvValue returns the trimmed value of the named column, or an empty string if the export has no such column.
+func csvValue(record []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// withDefaults fills in the defaults of the parse options.
+func (o TrackerParseOptions) withDefaults() TrackerParseOptions {
+	if o.Comma == 0 {
+		o.Comma = ','
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	return o
+}
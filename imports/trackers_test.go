@@ -0,0 +1,195 @@
+package imports
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTogglCSV(t *testing.T) {
+	input := "\ufeffUser,Client,Project,Task,Description,Billable,Tags,Start date,Start time,End date,End time,Duration\n" +
+		"Ivan Petrov,Acme,Website,Layout,,Yes,\"design, frontend\",2024-03-04,09:00:00,2024-03-04,10:30:00,01:30:00\n" +
+		"\n" +
+		"Ivan Petrov,,,,Call,No,,03/05/2024,1:15 pm,,,00:45:00\n"
+
+	entries, err := ParseToggl(strings.NewReader(input), TrackerParseOptions{Location: moscow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TrackerEntry{
+		{
+			Row: 2, User: "Ivan Petrov", Client: "Acme", Project: "Website", Description: "Layout", Billable: true,
+			Tags:  []string{"design", "frontend"},
+			Start: time.Date(2024, 3, 4, 9, 0, 0, 0, moscow), End: time.Date(2024, 3, 4, 10, 30, 0, 0, moscow),
+		},
+		{
+			// The end is computed from the duration, and the blank line is skipped.
+			Row: 4, User: "Ivan Petrov", Description: "Call",
+			Start: time.Date(2024, 3, 5, 13, 15, 0, 0, moscow), End: time.Date(2024, 3, 5, 14, 0, 0, 0, moscow),
+		},
+	}
+	assertEntries(t, entries, want)
+}
+
+func TestParseTogglJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  TrackerEntry
+	}{
+		{
+			name: "reports v2",
+			input: `{"data": [{"id": 101, "user": "Ivan Petrov", "client": "Acme", "project": "Website", "description": "Layout",
+				"start": "2024-03-04T09:00:00+03:00", "end": "2024-03-04T10:30:00+03:00", "billable": 150.5, "tags": ["design"]}]}`,
+			want: TrackerEntry{
+				Row: 1, ID: "101", User: "Ivan Petrov", Client: "Acme", Project: "Website", Description: "Layout", Tags: []string{"design"},
+				Start: time.Date(2024, 3, 4, 6, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 4, 7, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "api v9",
+			input: ` [{"id": 202, "user_name": "Ivan Petrov", "client_name": "Acme", "project_name": "Website", "task": "Review",
+				"start": "2024-03-04T06:00:00Z", "stop": "2024-03-04T06:45:00Z", "billable": true}]`,
+			want: TrackerEntry{
+				Row: 1, ID: "202", User: "Ivan Petrov", Client: "Acme", Project: "Website", Description: "Review", Billable: true,
+				Start: time.Date(2024, 3, 4, 6, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 4, 6, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "running entry",
+			input: `[{"id": 303, "start": "2024-03-04T06:00:00Z", "stop": null, "is_billable": true, "billable": false}]`,
+			want:  TrackerEntry{Row: 1, ID: "303", Billable: true, Start: time.Date(2024, 3, 4, 6, 0, 0, 0, time.UTC)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ParseToggl(strings.NewReader(tt.input), TrackerParseOptions{Location: moscow})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertEntries(t, entries, []TrackerEntry{tt.want})
+		})
+	}
+}
+
+func TestParseClockify(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		input := `{"timeentries": [{"_id": "65e5a1", "userName": "Ivan Petrov", "projectName": "Website", "taskName": "Layout",
+			"billable": true, "tags": [{"name": "design"}, {"name": "frontend"}],
+			"timeInterval": {"start": "2024-03-04T09:00:00+03:00", "end": "2024-03-04T10:00:00+03:00"}}]}`
+		entries, err := ParseClockify(strings.NewReader(input), TrackerParseOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertEntries(t, entries, []TrackerEntry{{
+			Row: 1, ID: "65e5a1", User: "Ivan Petrov", Project: "Website", Description: "Layout", Billable: true,
+			Tags:  []string{"design", "frontend"},
+			Start: time.Date(2024, 3, 4, 6, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 4, 7, 0, 0, 0, time.UTC),
+		}})
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		// Clockify names the duration column after its format and exports decimal hours.
+		input := "Project;Description;User;Billable;Start Date;Start Time;Duration (h);Duration (decimal)\n" +
+			"Website;Layout;Ivan Petrov;No;04.03.2024;23:30;1,25;1,25\n" +
+			"Website;Review;Ivan Petrov;No;04.03.2024;08:00;2:00;2,00\n"
+		entries, err := ParseClockify(strings.NewReader(input), TrackerParseOptions{Comma: ';', Location: moscow})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertEntries(t, entries, []TrackerEntry{
+			{
+				// The entry crosses midnight of the configured time zone.
+				Row: 2, User: "Ivan Petrov", Project: "Website", Description: "Layout",
+				Start: time.Date(2024, 3, 4, 23, 30, 0, 0, moscow), End: time.Date(2024, 3, 5, 0, 45, 0, 0, moscow),
+			},
+			{
+				Row: 3, User: "Ivan Petrov", Project: "Website", Description: "Review",
+				Start: time.Date(2024, 3, 4, 8, 0, 0, 0, moscow), End: time.Date(2024, 3, 4, 10, 0, 0, 0, moscow),
+			},
+		})
+	})
+}
+
+func TestParseTrackerDateLayout(t *testing.T) {
+	// 03/04/2024 is March 4 by default; a configured layout reads it as April 3.
+	input := "Start date,Start time,Duration\n03/04/2024,09:00,1\n"
+	entries, err := ParseToggl(strings.NewReader(input), TrackerParseOptions{DateLayout: "02/01/2006", Location: time.UTC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEntries(t, entries, []TrackerEntry{{
+		Row: 2, Start: time.Date(2024, 4, 3, 9, 0, 0, 0, time.UTC), End: time.Date(2024, 4, 3, 10, 0, 0, 0, time.UTC),
+	}})
+}
+
+func TestParseTrackerDefaultLocation(t *testing.T) {
+	// CSV exports have no offsets; without a configured time zone they are read in UTC, whatever the server zone.
+	input := "Start date,Start time,End date,End time\n2024-03-04,09:00,2024-03-04,10:00\n"
+	entries, err := ParseClockify(strings.NewReader(input), TrackerParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEntries(t, entries, []TrackerEntry{{
+		Row: 2, Start: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC),
+	}})
+	if entries[0].Start.Location() != time.UTC {
+		t.Errorf("entry is in %s, want UTC", entries[0].Start.Location())
+	}
+}
+
+func TestParseTrackerInvalidExports(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty file", "", "the file is empty"},
+		{"only a byte order mark", "\ufeff", "the file is empty"},
+		{"missing start time column", "Start date,Duration\n2024-03-04,1\n", `missing "start time" column`},
+		{"invalid date", "Start date,Start time\n2024-13-04,09:00\n", `line 2: invalid start date "2024-13-04"`},
+		{"invalid time", "Start date,Start time\n2024-03-04,25:00\n", `line 2: invalid start time "25:00"`},
+		{"invalid end", "Start date,Start time,End date,End time\n2024-03-04,09:00,2024-03-04,noon\n", `line 2: invalid end time "noon"`},
+		{"invalid duration", "Start date,Start time,Duration\n2024-03-04,09:00,1:75:00\n", `line 2: invalid duration "1:75:00"`},
+		{"negative duration", "Start date,Start time,Duration\n2024-03-04,09:00,-1\n", `line 2: negative duration "-1"`},
+		{"invalid billable", "Start date,Start time,Billable\n2024-03-04,09:00,maybe\n", `line 2: invalid billable flag "maybe"`},
+		{"unterminated quote", "Start date,Start time\n\"2024-03-04,09:00\n", "extraneous or missing"},
+		{"invalid json time", `[{"start": "yesterday", "timeInterval": {"start": "yesterday"}}]`, "cannot parse"},
+		{"truncated json", `[{"id": 1`, "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsers := map[string]func(io.Reader, TrackerParseOptions) ([]TrackerEntry, error){"toggl": ParseToggl, "clockify": ParseClockify}
+			for name, parse := range parsers {
+				_, err := parse(strings.NewReader(tt.input), TrackerParseOptions{})
+				if !errors.Is(err, ErrInvalidExport) {
+					t.Fatalf("%s: got error %v, want ErrInvalidExport", name, err)
+				}
+				if !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("%s: got error %q, want it to contain %q", name, err, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// assertEntries compares parsed entries with the expected ones, comparing times as instants.
+func assertEntries(t *testing.T, got, want []TrackerEntry) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if !g.Start.Equal(w.Start) || !g.End.Equal(w.End) {
+			t.Errorf("entry %d lasts from %s to %s, want %s to %s", i, g.Start, g.End, w.Start, w.End)
+		}
+		g.Start, g.End, w.Start, w.End = time.Time{}, time.Time{}, time.Time{}, time.Time{}
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("entry %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package invoices generates invoices from billable tracked time and renders them as documents.
+package invoices
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"gorm.io/gorm"
+)
+
+// Errors returned when an invoice cannot be generated.
+var (
+	ErrNothingToInvoice = errors.New("no uninvoiced billable time in the period")
+	ErrAlreadyInvoiced  = errors.New("some of the time in the period has just been invoiced")
+)
+
+// Params selects the time billed on a new invoice.
+type Params struct {
+	ClientID *uint          // Only time on projects of this client (nil for any client)
+	UserID   *uint          // Only time of this user (nil for all users)
+	Period   reports.Period // Intervals starting within the period are billed
+	Currency string         // ISO 4217 currency code
+	VATRate  float64        // VAT rate in percent
+}
+
+// Generate creates a draft invoice for the uninvoiced billable time selected by the params and marks
+// the billed intervals with the invoice, so they cannot be billed twice. It must run inside a transaction.
+// Time is aggregated into one line per task and hourly rate.
+func Generate(tx *gorm.DB, params Params) (models.Invoice, error) {
+	invoice := models.Invoice{
+		ClientID:  params.ClientID,
+		UserID:    params.UserID,
+		StartDate: params.Period.From,
+		EndDate:   params.Period.To,
+		Status:    models.InvoiceStatusDraft,
+		Currency:  params.Currency,
+		VATRate:   params.VATRate,
+	}
+
+	intervals, err := reports.UninvoicedIntervals(tx, params.Period, params.ClientID, params.UserID)
+	if err != nil {
+		return invoice, err
+	}
+	if len(intervals) == 0 {
+		return invoice, ErrNothingToInvoice
+	}
+
+	type lineKey struct {
+		taskID     uint
+		hourlyRate float64
+	}
+	seconds := make(map[lineKey]float64)
+	var keys []lineKey
+	intervalIDs := make([]uint, len(intervals))
+	for i, interval := range intervals {
+		key := lineKey{interval.TaskID, interval.HourlyRate}
+		if _, ok := seconds[key]; !ok {
+			keys = append(keys, key)
+			description := interval.Description
+			if interval.ProjectName != "" {
+				description = interval.ProjectName + ": " + description
+			}
+			invoice.Lines = append(invoice.Lines, models.InvoiceLine{
+				TaskID:      interval.TaskID,
+				ProjectID:   interval.ProjectID,
+				Description: description,
+				HourlyRate:  interval.HourlyRate,
+			})
+		}
+		seconds[key] += interval.Seconds
+		intervalIDs[i] = interval.IntervalID
+	}
+
+	for i, key := range keys {
+		line := &invoice.Lines[i]
+		line.Hours = round(seconds[key] / 3600)
+		line.Amount = round(line.Hours * line.HourlyRate)
+		invoice.Subtotal += line.Amount
+	}
+	invoice.Subtotal = round(invoice.Subtotal)
+	invoice.VATAmount = round(invoice.Subtotal * invoice.VATRate / 100)
+	invoice.Total = round(invoice.Subtotal + invoice.VATAmount)
+
+	if err := tx.Create(&invoice).Error; err != nil {
+		return invoice, err
+	}
+
+	// Intervals invoiced by a concurrent request since they were selected are skipped by the update.
+	result := tx.Model(&models.TaskInterval{}).
+		Where("id IN ? AND invoice_id IS NULL", intervalIDs).
+		Update("invoice_id", invoice.ID)
+	if result.Error != nil {
+		return invoice, result.Error
+	}
+	if result.RowsAffected != int64(len(intervalIDs)) {
+		return invoice, ErrAlreadyInvoiced
+	}
+
+	return invoice, nil
+}
+
+// Send marks a draft invoice as sent and assigns it the next number of the current year.
+// It must run inside a transaction.
+func Send(tx *gorm.DB, invoice *models.Invoice, now time.Time) error {
+	if err := invoice.Transition(models.InvoiceActionSend); err != nil {
+		return err
+	}
+
+	number, err := nextNumber(tx, now.Year())
+	if err != nil {
+		return err
+	}
+	invoice.Number = &number
+	invoice.SentAt = &now
+
+	return tx.Model(invoice).Select("Number", "Status", "SentAt").Updates(invoice).Error
+}
+
+// Pay marks a sent invoice as paid.
+func Pay(tx *gorm.DB, invoice *models.Invoice, now time.Time) error {
+	if err := invoice.Transition(models.InvoiceActionPay); err != nil {
+		return err
+	}
+	invoice.PaidAt = &now
+
+	return tx.Model(invoice).Select("Status", "PaidAt").Updates(invoice).Error
+}
+
+// Discard deletes a draft invoice and releases its intervals so they can be invoiced again.
+// It must run inside a transaction.
+func Discard(tx *gorm.DB, invoice *models.Invoice) error {
+	if invoice.Status != models.InvoiceStatusDraft {
+		return &models.InvoiceTransitionError{Status: invoice.Status, Action: models.InvoiceActionDiscard, Reason: models.ReasonInvoiceNotDraft}
+	}
+
+	if err := tx.Model(&models.TaskInterval{}).Where("invoice_id = ?", invoice.ID).Update("invoice_id", nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("invoice_id = ?", invoice.ID).Delete(&models.InvoiceLine{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(invoice).Error
+}
+
+// nextNumber issues the next invoice number of the year. Numbers are gapless within a year.
+func nextNumber(tx *gorm.DB, year int) (string, error) {
+	var last int
+	err := tx.Raw(`
+		INSERT INTO invoice_sequences (year, last_number) VALUES (?, 1)
+		ON CONFLICT (year) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING last_number
+	`, year).Scan(&last).Error
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d-%04d", year, last), nil
+}
+
+// round rounds a number to hundredths.
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,163 @@
+package invoices
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+	"time-tracker-go/models"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// document is the view of an invoice shared by the HTML and PDF renderings.
+type document struct {
+	Title     string
+	Period    string
+	Recipient string
+	Invoice   models.Invoice
+}
+
+// newDocument prepares the view of an invoice. The invoice is expected to have its client, user and lines loaded.
+func newDocument(invoice models.Invoice) document {
+	doc := document{
+		Title:   "Черновик счёта",
+		Period:  formatDate(invoice.StartDate) + " — " + formatDate(invoice.EndDate.Add(-time.Second)),
+		Invoice: invoice,
+	}
+	if invoice.Number != nil && invoice.SentAt != nil {
+		doc.Title = fmt.Sprintf("Счёт № %s от %s", *invoice.Number, formatDate(*invoice.SentAt))
+	}
+
+	if invoice.Client != nil {
+		doc.Recipient = invoice.Client.Name
+		if invoice.Client.Email != "" {
+			doc.Recipient += " <" + invoice.Client.Email + ">"
+		}
+	}
+	if invoice.User != nil {
+		user := invoice.User.Surname + " " + invoice.User.Name + " " + invoice.User.Patronymic
+		if doc.Recipient != "" {
+			doc.Recipient += ", исполнитель: " + user
+		} else {
+			doc.Recipient = "Исполнитель: " + user
+		}
+	}
+
+	return doc
+}
+
+var htmlTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money": formatMoney,
+	"inc":   func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #999; padding: 4px 8px; }
+td.number { text-align: right; white-space: nowrap; }
+tfoot td { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Период: {{.Period}}</p>
+{{if .Recipient}}<p>Плательщик: {{.Recipient}}</p>{{end}}
+<table>
+<thead><tr><th>№</th><th>Описание</th><th>Часы</th><th>Ставка</th><th>Сумма</th></tr></thead>
+<tbody>
+{{range $i, $line := .Invoice.Lines}}<tr><td class="number">{{inc $i}}</td><td>{{$line.Description}}</td><td class="number">{{money $line.Hours}}</td><td class="number">{{money $line.HourlyRate}}</td><td class="number">{{money $line.Amount}}</td></tr>
+{{end}}</tbody>
+<tfoot>
+<tr><td colspan="4">Итого</td><td class="number">{{money .Invoice.Subtotal}}</td></tr>
+<tr><td colspan="4">НДС {{money .Invoice.VATRate}}%</td><td class="number">{{money .Invoice.VATAmount}}</td></tr>
+<tr><td colspan="4">Всего к оплате, {{.Invoice.Currency}}</td><td class="number">{{money .Invoice.Total}}</td></tr>
+</tfoot>
+</table>
+</body>
+</html>
+`))
+
+// RenderHTML writes the invoice as an HTML document.
+func RenderHTML(w io.Writer, invoice models.Invoice) error {
+	return htmlTemplate.Execute(w, newDocument(invoice))
+}
+
+// RenderPDF writes the invoice as a PDF document. Fonts are embedded, so no files are read at runtime.
+func RenderPDF(w io.Writer, invoice models.Invoice) error {
+	doc := newDocument(invoice)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes("go", "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes("go", "B", gobold.TTF)
+	pdf.SetMargins(15, 15, 15)
+	pdf.AddPage()
+
+	pdf.SetFont("go", "B", 16)
+	pdf.CellFormat(0, 10, doc.Title, "", 1, "L", false, 0, "")
+	pdf.SetFont("go", "", 10)
+	pdf.CellFormat(0, 6, "Период: "+doc.Period, "", 1, "L", false, 0, "")
+	if doc.Recipient != "" {
+		pdf.MultiCell(0, 6, "Плательщик: "+doc.Recipient, "", "L", false)
+	}
+	pdf.Ln(4)
+
+	widths := []float64{10, 95, 20, 25, 30}
+	const lineHeight = 6
+
+	pdf.SetFont("go", "B", 10)
+	for i, header := range []string{"№", "Описание", "Часы", "Ставка", "Сумма"} {
+		pdf.CellFormat(widths[i], lineHeight, header, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("go", "", 10)
+	for i, line := range invoice.Lines {
+		// Long descriptions wrap, so the row is as high as the wrapped description.
+		description := pdf.SplitText(line.Description, widths[1]-2)
+		height := float64(len(description)) * lineHeight
+		if pdf.GetY()+height > 280 {
+			pdf.AddPage()
+		}
+
+		x, y := pdf.GetXY()
+		pdf.CellFormat(widths[0], height, fmt.Sprint(i+1), "1", 0, "R", false, 0, "")
+		pdf.MultiCell(widths[1], lineHeight, line.Description, "1", "L", false)
+		pdf.SetXY(x+widths[0]+widths[1], y)
+		pdf.CellFormat(widths[2], height, formatMoney(line.Hours), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], height, formatMoney(line.HourlyRate), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], height, formatMoney(line.Amount), "1", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont("go", "B", 10)
+	labelWidth := widths[0] + widths[1] + widths[2] + widths[3]
+	for _, total := range []struct {
+		label  string
+		amount float64
+	}{
+		{"Итого", invoice.Subtotal},
+		{"НДС " + formatMoney(invoice.VATRate) + "%", invoice.VATAmount},
+		{"Всего к оплате, " + invoice.Currency, invoice.Total},
+	} {
+		pdf.CellFormat(labelWidth, lineHeight, total.label, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], lineHeight, formatMoney(total.amount), "1", 1, "R", false, 0, "")
+	}
+
+	return pdf.Output(w)
+}
+
+// formatDate formats a date the way it is written in Russian documents.
+func formatDate(t time.Time) string {
+	return t.Format("02.01.2006")
+}
+
+// formatMoney formats an amount with two decimals.
+func formatMoney(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
@@ -0,0 +1,129 @@
+// Package live broadcasts timer changes to connected clients as they happen.
+package live
+
+import (
+	"sync"
+	"time"
+)
+
+// Types of timer events.
+const (
+	EventTaskCreated   = "task.created"   // A task was added
+	EventTaskStarted   = "task.started"   // A task was started or resumed
+	EventTaskPaused    = "task.paused"    // A running task was paused
+	EventTaskEnded     = "task.ended"     // A task was ended, also when the user switched to another task
+	EventTaskCancelled = "task.cancelled" // A task was cancelled
+)
+
+const subscriberBuffer = 64 // Number of events a subscriber may fall behind before it is disconnected
+
+// Event is a timer change of a user.
+type Event struct {
+	ID     uint64      `json:"id"`     // Position of the event in the stream; IDs increase across restarts
+	Type   string      `json:"type"`   // Type of the event
+	UserID uint        `json:"userID"` // User whose timer changed
+	Time   time.Time   `json:"time"`   // Time the event occurred
+	Data   interface{} `json:"data"`   // The task the event is about
+}
+
+// Broker fans published events out to subscribers and keeps the most recent ones,
+// so that clients that reconnect can catch up on what they missed.
+type Broker struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event // Most recent events, oldest first
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the events of one user or of all users.
+type Subscription struct {
+	Events <-chan Event // Closed when the subscription is closed, or when it falls too far behind
+	userID uint
+	events chan Event
+	broker *Broker
+}
+
+// NewBroker creates a broker that keeps the given number of recent events for resuming.
+// Event IDs start from the current time, so IDs received before a restart are older than any new ones.
+func NewBroker(historySize int) *Broker {
+	return &Broker{
+		lastID:      uint64(time.Now().UnixNano()),
+		historySize: historySize,
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish sends an event to the subscribers of the user and of all users. It must be called only after
+// the change the event reports has been committed. Publishing on a nil broker does nothing.
+func (b *Broker) Publish(eventType string, userID uint, data interface{}) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := Event{ID: b.lastID, Type: eventType, UserID: userID, Time: time.Now(), Data: data}
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for subscription := range b.subscribers {
+		if subscription.userID != 0 && subscription.userID != userID {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			// A subscriber that cannot keep up is disconnected rather than slowing everyone down;
+			// it can reconnect and resume from the history.
+			b.remove(subscription)
+		}
+	}
+}
+
+// Subscribe subscribes to the events of the user, or of all users if userID is 0.
+//
+// With a non-zero lastEventID the events published after it are returned to be sent before the
+// subscription's own. complete is false if some of them are no longer kept, for instance after a
+// restart: the client then has to reload the state instead of resuming. The returned ID is the last
+// event published so far, to which a reloaded state corresponds.
+func (b *Broker) Subscribe(userID uint, lastEventID uint64) (subscription *Subscription, missed []Event, complete bool, lastID uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan Event, subscriberBuffer)
+	subscription = &Subscription{Events: events, userID: userID, events: events, broker: b}
+	b.subscribers[subscription] = struct{}{}
+
+	complete = true
+	if lastEventID != 0 && lastEventID != b.lastID {
+		complete = lastEventID < b.lastID && len(b.history) > 0 && lastEventID >= b.history[0].ID-1
+		if complete {
+			for _, event := range b.history {
+				if event.ID > lastEventID && (userID == 0 || event.UserID == userID) {
+					missed = append(missed, event)
+				}
+			}
+		}
+	}
+	return subscription, missed, complete, b.lastID
+}
+
+// Close unsubscribes. It is safe to close a subscription more than once.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+// remove unsubscribes the subscription. The caller must hold the lock.
+func (b *Broker) remove(subscription *Subscription) {
+	if _, ok := b.subscribers[subscription]; ok {
+		delete(b.subscribers, subscription)
+		close(subscription.events)
+	}
+}
@@ -0,0 +1,101 @@
+package live
+
+import "testing"
+
+// publish publishes events of the users in order and returns them.
+func publish(b *Broker, userIDs ...uint) []Event {
+	events := make([]Event, len(userIDs))
+	for i, userID := range userIDs {
+		b.Publish(EventTaskStarted, userID, nil)
+		events[i] = b.history[len(b.history)-1]
+	}
+	return events
+}
+
+func TestSubscribeResumesWithinHistory(t *testing.T) {
+	b := NewBroker(10)
+	events := publish(b, 1, 2, 1)
+
+	subscription, missed, complete, lastID := b.Subscribe(1, events[0].ID)
+	defer subscription.Close()
+	if !complete {
+		t.Fatal("complete = false, want true for an event still in the history")
+	}
+	if len(missed) != 1 || missed[0].ID != events[2].ID {
+		t.Errorf("missed = %v, want only the later event of user 1 (%d)", missed, events[2].ID)
+	}
+	if lastID != events[2].ID {
+		t.Errorf("lastID = %d, want %d", lastID, events[2].ID)
+	}
+
+	all, missed, complete, _ := b.Subscribe(0, events[0].ID)
+	defer all.Close()
+	if !complete || len(missed) != 2 {
+		t.Errorf("Subscribe(all) = %d missed, complete %v; want 2 missed, complete", len(missed), complete)
+	}
+
+	// A client that missed nothing, or resumes from just before the oldest kept event, is complete too.
+	if _, missed, complete, _ := b.Subscribe(0, lastID); !complete || len(missed) != 0 {
+		t.Errorf("Subscribe(lastID) = %d missed, complete %v; want none, complete", len(missed), complete)
+	}
+	if _, missed, complete, _ := b.Subscribe(0, events[0].ID-1); !complete || len(missed) != 3 {
+		t.Errorf("Subscribe(before oldest) = %d missed, complete %v; want 3 missed, complete", len(missed), complete)
+	}
+}
+
+func TestSubscribeResumesPastHistory(t *testing.T) {
+	b := NewBroker(2)
+	first := publish(b, 1)[0]
+	publish(b, 1, 1, 1)
+
+	tests := map[string]uint64{
+		"evicted event":     first.ID,
+		"before a restart":  first.ID - 1000,
+		"from another node": b.lastID + 1,
+	}
+	for name, lastEventID := range tests {
+		subscription, missed, complete, _ := b.Subscribe(1, lastEventID)
+		subscription.Close()
+		if complete {
+			t.Errorf("%s: complete = true, want false", name)
+		}
+		if len(missed) != 0 {
+			t.Errorf("%s: missed = %v, want none when incomplete", name, missed)
+		}
+	}
+}
+
+func TestSlowSubscriberIsDisconnected(t *testing.T) {
+	b := NewBroker(1000)
+	slow, _, _, _ := b.Subscribe(1, 0)
+	other, _, _, _ := b.Subscribe(2, 0)
+	defer other.Close()
+
+	users := make([]uint, subscriberBuffer+1)
+	for i := range users {
+		users[i] = 1
+	}
+	publish(b, users...)
+
+	received := 0
+	for range slow.Events {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("received %d events before the channel was closed, want %d", received, subscriberBuffer)
+	}
+	if _, ok := b.subscribers[slow]; ok {
+		t.Error("slow subscriber is still subscribed")
+	}
+	if _, ok := b.subscribers[other]; !ok {
+		t.Error("subscriber of another user was disconnected")
+	}
+
+	// Closing a subscription that was already disconnected must not panic.
+	slow.Close()
+}
+
+func TestPublishOnNilBroker(t *testing.T) {
+	var b *Broker
+	b.Publish(EventTaskCreated, 1, nil)
+}
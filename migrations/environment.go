@@ -0,0 +1,27 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// Environment returns the environment recorded for the database, or "" if none is.
+func Environment(db *gorm.DB) (string, error) {
+	if !db.Migrator().HasTable("app_environment") {
+		return "", nil
+	}
+	var names []string
+	if err := db.Table("app_environment").Pluck("name", &names).Error; err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	return names[0], nil
+}
+
+// RecordEnvironment records the environment of the database unless one is recorded already,
+// and returns the recorded environment.
+func RecordEnvironment(db *gorm.DB, env string) (string, error) {
+	if err := db.Exec(`INSERT INTO app_environment (name) VALUES (?) ON CONFLICT DO NOTHING`, env).Error; err != nil {
+		return "", err
+	}
+	return Environment(db)
+}
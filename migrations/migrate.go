@@ -1,19 +1,253 @@
 package migrations
 
 import (
+	"fmt"
 	"log"
-	// "time"
-	"time-tracker-go/models"
+	"time"
 
-	// "gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-// Migrate performs database schema migration for User, Task, and People models.
+// lockKey identifies the PostgreSQL advisory lock held while migrating, so that instances
+// starting at the same time apply migrations one after another.
+const lockKey = 727_001_974
+
+// AppliedMigration is a row of the schema_migrations table, the history of applied migrations.
+type AppliedMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	Checksum  string    `gorm:"not null"` // SHA-256 of the up file when the migration was applied
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the name of the schema history table.
+func (AppliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migration states reported by Status.
+const (
+	StateApplied  = "applied"  // Applied and unchanged since
+	StatePending  = "pending"  // Not applied yet
+	StateModified = "modified" // Applied, but its up file has changed since
+	StateUnknown  = "unknown"  // Applied, but missing from this binary
+)
+
+// MigrationStatus describes whether a migration is applied to the database.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+// Migrate applies the pending migrations. It is called on server startup.
 func Migrate(db *gorm.DB) {
-	err := db.AutoMigrate(&models.User{}, &models.Task{}, &models.People{})
+	applied, err := Up(db)
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
+	for _, migration := range applied {
+		log.Printf("Applied migration %s", migration)
+	}
 	log.Println("Database migration completed successfully")
 }
+
+// Up applies the pending migrations in order and returns them.
+func Up(db *gorm.DB) ([]Migration, error) {
+	var result []Migration
+	err := withLock(db, func(conn *gorm.DB, migrations []Migration, applied map[int64]AppliedMigration) error {
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := apply(conn, migration); err != nil {
+				return err
+			}
+			result = append(result, migration)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Down reverts the given number of most recently applied migrations and returns them.
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	var result []Migration
+	err := withLock(db, func(conn *gorm.DB, migrations []Migration, applied map[int64]AppliedMigration) error {
+		var history []AppliedMigration
+		if err := conn.Order("version DESC").Limit(steps).Find(&history).Error; err != nil {
+			return err
+		}
+
+		for _, row := range history {
+			migration, err := find(migrations, row)
+			if err != nil {
+				return err
+			}
+			if err := revert(conn, migration); err != nil {
+				return err
+			}
+			result = append(result, migration)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Redo reverts and applies again the most recently applied migration, and returns it.
+func Redo(db *gorm.DB) (*Migration, error) {
+	var result *Migration
+	err := withLock(db, func(conn *gorm.DB, migrations []Migration, applied map[int64]AppliedMigration) error {
+		var last AppliedMigration
+		err := conn.Order("version DESC").Take(&last).Error
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("no migration has been applied")
+		}
+		if err != nil {
+			return err
+		}
+
+		migration, err := find(migrations, last)
+		if err != nil {
+			return err
+		}
+		if err := revert(conn, migration); err != nil {
+			return err
+		}
+		if err := apply(conn, migration); err != nil {
+			return err
+		}
+		result = &migration
+		return nil
+	})
+	return result, err
+}
+
+// Status lists the migrations of the binary and of the schema history, ordered by version.
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := createHistoryTable(db); err != nil {
+		return nil, err
+	}
+	var history []AppliedMigration
+	if err := db.Order("version").Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	applied := map[int64]AppliedMigration{}
+	for _, row := range history {
+		applied[row.Version] = row
+	}
+
+	var result []MigrationStatus
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, State: StatePending}
+		if row, ok := applied[migration.Version]; ok {
+			status.State = StateApplied
+			if row.Checksum != migration.Checksum {
+				status.State = StateModified
+			}
+			status.AppliedAt = &row.AppliedAt
+			delete(applied, migration.Version)
+		}
+		result = append(result, status)
+	}
+	for _, row := range history {
+		if _, ok := applied[row.Version]; ok {
+			appliedAt := row.AppliedAt
+			result = append(result, MigrationStatus{Version: row.Version, Name: row.Name, State: StateUnknown, AppliedAt: &appliedAt})
+		}
+	}
+	return result, nil
+}
+
+// withLock runs fn on a single connection holding the migration lock, with the migrations of the binary
+// and the applied ones by version. It fails if an applied migration has changed since it was applied.
+func withLock(db *gorm.DB, fn func(conn *gorm.DB, migrations []Migration, applied map[int64]AppliedMigration) error) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+
+	// Session-level advisory locks belong to a connection, so everything runs on the one holding it.
+	return db.Connection(func(tx *gorm.DB) error {
+		conn := tx.Session(&gorm.Session{})
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", lockKey)
+
+		if err := createHistoryTable(conn); err != nil {
+			return err
+		}
+		var history []AppliedMigration
+		if err := conn.Find(&history).Error; err != nil {
+			return err
+		}
+
+		applied := map[int64]AppliedMigration{}
+		for _, row := range history {
+			applied[row.Version] = row
+		}
+		for _, migration := range migrations {
+			if row, ok := applied[migration.Version]; ok && row.Checksum != migration.Checksum {
+				return fmt.Errorf("migration %s was changed after it was applied (checksum %s, applied %s); add a new migration instead", migration, migration.Checksum, row.Checksum)
+			}
+		}
+		return fn(conn, migrations, applied)
+	})
+}
+
+// createHistoryTable creates the schema_migrations table if it does not exist.
+func createHistoryTable(db *gorm.DB) error {
+	return db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    bigint PRIMARY KEY,
+			name       text NOT NULL,
+			checksum   text NOT NULL,
+			applied_at timestamptz NOT NULL
+		)
+	`).Error
+}
+
+// apply runs the up file of the migration and records it in one transaction.
+func apply(db *gorm.DB, migration Migration) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&AppliedMigration{Version: migration.Version, Name: migration.Name, Checksum: migration.Checksum, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %s: %w", migration, err)
+	}
+	return nil
+}
+
+// revert runs the down file of the migration and removes it from the history in one transaction.
+func revert(db *gorm.DB, migration Migration) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&AppliedMigration{}, migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revert migration %s: %w", migration, err)
+	}
+	return nil
+}
+
+// find returns the migration of the binary recorded by a row of the history.
+func find(migrations []Migration, row AppliedMigration) (Migration, error) {
+	for _, migration := range migrations {
+		if migration.Version == row.Version {
+			return migration, nil
+		}
+	}
+	return Migration{}, fmt.Errorf("migration %04d_%s is not part of this binary and cannot be reverted", row.Version, row.Name)
+}
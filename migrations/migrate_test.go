@@ -0,0 +1,202 @@
+package migrations
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+	"time-tracker-go/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// baselineSchema is the schema GORM AutoMigrate created for the User, Task and People models
+// before versioned migrations were introduced.
+const baselineSchema = `
+CREATE TABLE "users" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"passport_number" text NOT NULL,"surname" text,"name" text,"patronymic" text,"address" text,PRIMARY KEY ("id"),CONSTRAINT "uni_users_passport_number" UNIQUE ("passport_number"));
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE TABLE "tasks" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"user_id" bigint,"description" text,"start_time" timestamptz,"end_time" timestamptz,"duration" bigint,PRIMARY KEY ("id"),CONSTRAINT "fk_users_tasks" FOREIGN KEY ("user_id") REFERENCES "users"("id"));
+CREATE INDEX IF NOT EXISTS "idx_tasks_deleted_at" ON "tasks" ("deleted_at");
+CREATE TABLE "peoples" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"passport_series" bigint NOT NULL,"passport_number" bigint NOT NULL,"surname" text,"name" text,"patronymic" text,"address" text,PRIMARY KEY ("id"),CONSTRAINT "uni_peoples_passport_series" UNIQUE ("passport_series"),CONSTRAINT "uni_peoples_passport_number" UNIQUE ("passport_number"));
+CREATE INDEX IF NOT EXISTS "idx_peoples_deleted_at" ON "peoples" ("deleted_at");
+`
+
+// testDB opens an empty schema of the PostgreSQL database in TEST_DATABASE_URL, or skips the test.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	config := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+
+	admin, err := gorm.Open(postgres.Open(dsn), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("TEST_DATABASE_URL must be a URL: %v", err)
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	db, err := gorm.Open(postgres.Open(u.String()), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestUpgradeFromBaseline(t *testing.T) {
+	db := testDB(t)
+	migrations, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Exec(baselineSchema).Error; err != nil {
+		t.Fatalf("creating baseline schema: %v", err)
+	}
+	now := time.Now()
+	zero := time.Time{}
+	legacy := []struct {
+		description string
+		start, end  time.Time
+		updated     time.Time
+		status      string
+		closed      bool // Whether the backfilled interval is closed
+		minutes     int  // Length of the closed interval in minutes
+	}{
+		{"finished", now.Add(-3 * time.Hour), now.Add(-2 * time.Hour), now.Add(-2 * time.Hour), "done", true, 60},
+		{"left running", now.Add(-90 * time.Minute), zero, now.Add(-90 * time.Minute), "paused", true, 90},
+		{"running", now.Add(-time.Hour), zero, now.Add(-time.Hour), "running", false, 0},
+		// Like "Task 3" of the old seed data: an end before the start must not become a running interval.
+		{"ended before start", now.Add(-2 * time.Hour), now.Add(-5 * time.Hour), now.Add(-3 * time.Hour), "done", true, 0},
+		{"ended at start", now.Add(-4 * time.Hour), now.Add(-4 * time.Hour), now.Add(-4 * time.Hour), "done", true, 0},
+		{"never started", zero, zero, now, "created", false, 0},
+	}
+	if err := db.Exec(`INSERT INTO users (created_at, updated_at, passport_number) VALUES (NOW(), NOW(), '1234 567890')`).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, task := range legacy {
+		err := db.Exec(`INSERT INTO tasks (created_at, updated_at, user_id, description, start_time, end_time, duration)
+			VALUES (?, ?, (SELECT id FROM users), ?, ?, ?, 0)`, task.updated, task.updated, task.description, task.start, task.end).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	applied, err := Up(db)
+	if err != nil {
+		t.Fatalf("Up from baseline: %v", err)
+	}
+	if len(applied) != len(migrations) {
+		t.Fatalf("Up applied %d migrations, want %d", len(applied), len(migrations))
+	}
+
+	for _, task := range legacy {
+		var row struct {
+			Status  string
+			Minutes int
+			Open    int
+			Closed  int
+		}
+		err := db.Raw(`SELECT status,
+				(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM i.end_time - i.start_time)), 0)::int / 60 FROM task_intervals i WHERE i.task_id = tasks.id) AS minutes,
+				(SELECT COUNT(*) FROM task_intervals i WHERE i.task_id = tasks.id AND i.end_time IS NULL) AS open,
+				(SELECT COUNT(*) FROM task_intervals i WHERE i.task_id = tasks.id AND i.end_time IS NOT NULL) AS closed
+			FROM tasks WHERE description = ?`, task.description).Scan(&row).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+		if row.Status != task.status {
+			t.Errorf("task %q has status %q, want %q", task.description, row.Status, task.status)
+		}
+		if task.start.IsZero() {
+			if row.Open+row.Closed != 0 {
+				t.Errorf("task %q has %d intervals, want none", task.description, row.Open+row.Closed)
+			}
+			continue
+		}
+		if task.closed && (row.Closed != 1 || row.Open != 0) {
+			t.Errorf("task %q has %d open and %d closed intervals, want one closed", task.description, row.Open, row.Closed)
+		}
+		if !task.closed && (row.Open != 1 || row.Closed != 0) {
+			t.Errorf("task %q has %d open and %d closed intervals, want one open", task.description, row.Open, row.Closed)
+		}
+		if task.closed && row.Minutes != task.minutes {
+			t.Errorf("task %q has a %d-minute interval, want %d", task.description, row.Minutes, task.minutes)
+		}
+	}
+
+	assertModelTables(t, db)
+
+	if applied, err := Up(db); err != nil || len(applied) != 0 {
+		t.Fatalf("second Up applied %v, %v; want nothing", applied, err)
+	}
+
+	// Every down file must revert its up file, leaving a database that can be migrated again.
+	reverted, err := Down(db, len(migrations))
+	if err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	if len(reverted) != len(migrations) {
+		t.Fatalf("Down reverted %d migrations, want %d", len(reverted), len(migrations))
+	}
+	if db.Migrator().HasTable("users") {
+		t.Error("users table exists after reverting all migrations")
+	}
+	if _, err := Up(db); err != nil {
+		t.Fatalf("Up on an empty database: %v", err)
+	}
+	assertModelTables(t, db)
+}
+
+// assertModelTables checks that the database has a column for every field of the models.
+func assertModelTables(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	all := []interface{}{&models.User{}, &models.Client{}, &models.Project{}, &models.Tag{}, &models.Task{}, &models.TaskInterval{}, &models.Rate{}, &models.Invoice{}, &models.InvoiceLine{}, &models.InvoiceSequence{}, &models.TimesheetApproval{}, &models.BudgetEvent{}, &models.Webhook{}, &models.WebhookDelivery{}, &models.People{}}
+	for _, model := range all {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !db.Migrator().HasColumn(model, field.DBName) {
+				t.Errorf("table %s has no column %s", stmt.Schema.Table, field.DBName)
+			}
+		}
+	}
+	if !db.Migrator().HasTable("task_tags") {
+		t.Error("task_tags table is missing")
+	}
+}
+
+func TestRecordEnvironment(t *testing.T) {
+	db := testDB(t)
+	if env, err := Environment(db); err != nil || env != "" {
+		t.Fatalf("Environment of an unmigrated database = %q, %v; want none", env, err)
+	}
+	if _, err := Up(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if env, err := RecordEnvironment(db, "production"); err != nil || env != "production" {
+		t.Fatalf("RecordEnvironment(production) = %q, %v", env, err)
+	}
+	// The first recorded environment is kept, so a later APP_ENV=dev cannot turn the database into a dev one.
+	if env, err := RecordEnvironment(db, "dev"); err != nil || env != "production" {
+		t.Fatalf("RecordEnvironment(dev) = %q, %v; want production", env, err)
+	}
+}
@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// files holds the migrations, named <version>_<name>.up.sql and <version>_<name>.down.sql.
+//
+//go:embed sql/*.sql
+var files embed.FS
+
+// fileName matches the name of a migration file.
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned change of the database schema.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string // SQL applying the migration
+	Down     string // SQL reverting the migration
+	Checksum string // SHA-256 of Up, recorded when the migration is applied
+}
+
+// Load returns the migrations embedded in the binary, ordered by version.
+func Load() ([]Migration, error) {
+	sqlFiles, err := fs.Sub(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return load(sqlFiles)
+}
+
+// load reads the migrations from the files of a directory.
+func load(dir fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(dir, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s: %w", entry.Name(), err)
+		}
+		data, err := fs.ReadFile(dir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration := byVersion[version]
+		if migration == nil {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(data)
+			sum := sha256.Sum256(data)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		result = append(result, *migration)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+// String returns the version and name of the migration, as in its file names.
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
@@ -0,0 +1,100 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// checksums are the checksums of the released migrations. Databases record them when a migration is applied
+// and refuse to migrate if a file changes afterwards, so a released migration must never be edited:
+// add a new one instead, and its checksum here.
+var checksums = map[int64]string{
+	1:  "ae3a8ce3072581abe0ba4380b2f4d09499c64a7dbca9a31d89c8874fd51cbefe", // 0001_baseline_schema
+	2:  "e16b9a456672c9982b540699553f2897b867e9b2c3e9612e2ff47742ed650ff1", // 0002_task_intervals
+	3:  "b2678a20018fd21c5b2a7379d6ddd0cbf602216ccbc449526c13a961d34f8b32", // 0003_task_status
+	4:  "7188df1f0d6496a6138315abfa7d4e15b8668309d4a13b9f97296ee0d28d5fb5", // 0004_one_running_task_per_user
+	5:  "7450024071129bcc1c9b881bece8d0c982b18a59e4722d2f3d4c8f044a0fc11d", // 0005_clients_and_projects
+	6:  "45bbc6b6f1c28ee44c90f31db2550dbc0e0ebb7eef5dd27630558f88db5bc501", // 0006_tags
+	7:  "5c9bfc1de441fac138e688ed07d434b2a7ba078659f18242d746b50576646605", // 0007_billable_tasks_and_rates
+	8:  "1dd07785aa0421e640dad7ab0fb5e2d28210ffbbdaa2fe5e8eb3058ecc4fe552", // 0008_invoices
+	9:  "513f21502a1567d0e771fd1994d8cef582a15b32f874d1d7b874df25a3f47ffb", // 0009_task_external_ids
+	10: "a0b0df7c71e38099687f088c5c652e30649cc098487d9af1fc42e8f82d2d0795", // 0010_timesheet_approvals
+	11: "6fa7ed0c09d1fee791ed8c54f7d81b20c1b275eb3e1df3a9f2fe9a287dc02964", // 0011_estimates_and_budgets
+	12: "4c2c6486b3dcff10d7a707151b19fbe7a94ebad688a37c5e74708620c6aadd31", // 0012_webhooks
+	13: "491720c5fafc99a333112606f7c5b6c112060c07449afc3b6b2f9483cbf745c3", // 0013_app_environment
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != len(checksums) {
+		t.Errorf("got %d migrations, want %d; add the checksum of new migrations to the test", len(migrations), len(checksums))
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != int64(i+1) {
+			t.Errorf("migration %d is %s, want versions numbered 1, 2, 3... without gaps or duplicates", i, migration)
+		}
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			t.Errorf("migration %s has an empty up or down file", migration)
+		}
+		if want, ok := checksums[migration.Version]; ok && migration.Checksum != want {
+			t.Errorf("migration %s was changed (checksum %s, released %s); add a new migration instead", migration, migration.Checksum, want)
+		}
+	}
+
+	again, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range again {
+		if again[i].Checksum != migrations[i].Checksum {
+			t.Errorf("checksum of %s differs between loads", migrations[i])
+		}
+	}
+}
+
+func TestLoadInvalidFiles(t *testing.T) {
+	sql := &fstest.MapFile{Data: []byte("SELECT 1;")}
+	tests := map[string]fstest.MapFS{
+		"missing down":   {"0001_a.up.sql": sql},
+		"missing up":     {"0001_a.down.sql": sql},
+		"two names":      {"0001_a.up.sql": sql, "0001_a.down.sql": sql, "0001_b.up.sql": sql, "0001_b.down.sql": sql},
+		"invalid name":   {"0001_a.up.sql": sql, "0001_a.down.sql": sql, "a.sql": sql},
+		"missing suffix": {"0001_a.sql": sql},
+	}
+	for name, dir := range tests {
+		if migrations, err := load(dir); err == nil {
+			t.Errorf("%s: load succeeded with %v, want an error", name, migrations)
+		}
+	}
+}
+
+func TestLoadOrdersByVersion(t *testing.T) {
+	dir := fstest.MapFS{
+		"0010_c.up.sql":   {Data: []byte("SELECT 10;")},
+		"0010_c.down.sql": {Data: []byte("SELECT -10;")},
+		"0002_b.up.sql":   {Data: []byte("SELECT 2;")},
+		"0002_b.down.sql": {Data: []byte("SELECT -2;")},
+		"0001_a.up.sql":   {Data: []byte("SELECT 1;")},
+		"0001_a.down.sql": {Data: []byte("SELECT -1;")},
+	}
+	migrations, err := load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, migration := range migrations {
+		names = append(names, migration.String())
+	}
+	if got := strings.Join(names, " "); got != "0001_a 0002_b 0010_c" {
+		t.Errorf("migrations = %s, want 0001_a 0002_b 0010_c", got)
+	}
+	if migrations[0].Up != "SELECT 1;" || migrations[0].Down != "SELECT -1;" {
+		t.Errorf("migration 0001_a = %+v, want its up and down files", migrations[0])
+	}
+}
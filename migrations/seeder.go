@@ -9,7 +9,20 @@ import (
 
 func clean(db *gorm.DB) {
 	// Удаление данных из таблиц
+	db.Exec("DELETE FROM webhook_deliveries;")
+	db.Exec("DELETE FROM webhooks;")
+	db.Exec("DELETE FROM budget_events;")
+	db.Exec("DELETE FROM timesheet_approvals;")
+	db.Exec("DELETE FROM invoice_lines;")
+	db.Exec("DELETE FROM invoices;")
+	db.Exec("DELETE FROM invoice_sequences;")
+	db.Exec("DELETE FROM rates;")
+	db.Exec("DELETE FROM task_intervals;")
+	db.Exec("DELETE FROM task_tags;")
 	db.Exec("DELETE FROM tasks;")
+	db.Exec("DELETE FROM tags;")
+	db.Exec("DELETE FROM projects;")
+	db.Exec("DELETE FROM clients;")
 	db.Exec("DELETE FROM users;")
 	db.Exec("DELETE FROM peoples;")
 }
@@ -41,17 +54,54 @@ func Seed(db *gorm.DB) {
 		{PassportNumber: "1010 101010", Surname: "Kiselev", Name: "Kisel", Patronymic: "Kiselich", Address: "г. Новокузнецк, ул. Ленина, д. 100, кв. 20"},
 	}
 
+	appBudgetHours := 20.0
+	clients := []models.Client{
+		{Name: "ООО Ромашка", Email: "office@romashka.example", Projects: []models.Project{
+			{Name: "Интернет-магазин", Description: "Разработка и поддержка интернет-магазина"},
+			{Name: "Мобильное приложение", Description: "Приложение для iOS и Android", BudgetHours: &appBudgetHours},
+		}},
+		{Name: "АО Вектор", Email: "info@vector.example", Projects: []models.Project{
+			{Name: "CRM", Description: "Внедрение CRM-системы"},
+		}},
+	}
+
+	// Save clients with their projects in the database
+	var projects []models.Project
+	for _, client := range clients {
+		db.Create(&client)
+		projects = append(projects, client.Projects...)
+	}
+
+	// Default rate for everyone and a higher rate for the first project
+	rates := []models.Rate{
+		{HourlyRate: 2500, EffectiveFrom: time.Now().AddDate(-1, 0, 0)},
+		{ProjectID: &projects[0].ID, HourlyRate: 3500, EffectiveFrom: time.Now().AddDate(-1, 0, 0)},
+	}
+	db.Create(&rates)
+
+	tags := []models.Tag{
+		{Name: "meeting", Color: "#4caf50"},
+		{Name: "review", Color: "#2196f3"},
+		{Name: "bugfix", Color: "#f44336"},
+	}
+	db.Create(&tags)
+
+	// Task 2 takes longer than estimated
+	taskEstimate := 90
+
 	// Save users in the database
-	for _, user := range users {
+	for n, user := range users {
 		db.Create(&user)
+		projectID := projects[n%len(projects)].ID
 
 		tasks := []models.Task{
-			{UserID: user.ID, Description: "Task 1", StartTime: time.Now().Add(-10 * time.Hour), EndTime: time.Now().Add(-9 * time.Hour), Duration: 60},
-			{UserID: user.ID, Description: "Task 2", StartTime: time.Now().Add(-9 * time.Hour), EndTime: time.Now().Add(-7 * time.Hour), Duration: 120},
-			{UserID: user.ID, Description: "Task 3", StartTime: time.Now().Add(-2 * time.Hour), EndTime: time.Now().Add(-5 * time.Hour), Duration: 180},
+			{UserID: user.ID, ProjectID: &projectID, Description: "Task 1", Billable: true, Status: models.TaskStatusDone, Tags: []models.Tag{tags[0]}, Intervals: seedIntervals(-10*time.Hour, -9*time.Hour)},
+			{UserID: user.ID, ProjectID: &projectID, Description: "Task 2", EstimatedDuration: &taskEstimate, Billable: true, Status: models.TaskStatusDone, Tags: []models.Tag{tags[1], tags[2]}, Intervals: seedIntervals(-9*time.Hour, -8*time.Hour, -7*time.Hour+30*time.Minute, -6*time.Hour+30*time.Minute)},
+			{UserID: user.ID, Description: "Task 3", Status: models.TaskStatusDone, Intervals: seedIntervals(-5*time.Hour, -2*time.Hour)},
 		}
 
 		for _, task := range tasks {
+			task.RecalculateDuration()
 			db.Create(&task)
 		}
 	}
@@ -79,3 +129,14 @@ func Seed(db *gorm.DB) {
 		db.Create(&person)
 	}
 }
+
+// seedIntervals builds closed task intervals from pairs of offsets relative to now.
+func seedIntervals(offsets ...time.Duration) []models.TaskInterval {
+	now := time.Now()
+	var intervals []models.TaskInterval
+	for i := 0; i+1 < len(offsets); i += 2 {
+		endTime := now.Add(offsets[i+1])
+		intervals = append(intervals, models.TaskInterval{StartTime: now.Add(offsets[i]), EndTime: &endTime})
+	}
+	return intervals
+}
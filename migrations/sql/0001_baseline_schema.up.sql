@@ -0,0 +1,42 @@
+-- Schema created by GORM AutoMigrate before versioned migrations were introduced.
+-- Databases created back then already have these tables and adopt this migration as their baseline.
+
+CREATE TABLE IF NOT EXISTS users (
+    id              bigserial PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    deleted_at      timestamptz,
+    passport_number text NOT NULL CONSTRAINT uni_users_passport_number UNIQUE,
+    surname         text,
+    name            text,
+    patronymic      text,
+    address         text
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id          bigserial PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    user_id     bigint CONSTRAINT fk_users_tasks REFERENCES users (id),
+    description text,
+    start_time  timestamptz,
+    end_time    timestamptz,
+    duration    bigint
+);
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS peoples (
+    id              bigserial PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    deleted_at      timestamptz,
+    passport_series bigint NOT NULL CONSTRAINT uni_peoples_passport_series UNIQUE,
+    passport_number bigint NOT NULL CONSTRAINT uni_peoples_passport_number UNIQUE,
+    surname         text,
+    name            text,
+    patronymic      text,
+    address         text
+);
+CREATE INDEX IF NOT EXISTS idx_peoples_deleted_at ON peoples (deleted_at);
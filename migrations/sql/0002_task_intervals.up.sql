@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS task_intervals (
+    id         bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    task_id    bigint NOT NULL CONSTRAINT fk_tasks_intervals REFERENCES tasks (id),
+    start_time timestamptz NOT NULL,
+    end_time   timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_task_intervals_task_id ON task_intervals (task_id);
+CREATE INDEX IF NOT EXISTS idx_task_intervals_deleted_at ON task_intervals (deleted_at);
+
+-- Tasks created before intervals were introduced keep their single start/end pair as one interval.
+-- A task that was never ended (zero end time) keeps an open interval. An end at or before the start
+-- becomes a zero-length interval, so that no time is booked that was never tracked.
+INSERT INTO task_intervals (created_at, updated_at, task_id, start_time, end_time)
+SELECT NOW(), NOW(), t.id, t.start_time,
+    CASE WHEN t.end_time IS NULL OR t.end_time < '0001-01-02 00:00:00+00' THEN NULL ELSE GREATEST(t.end_time, t.start_time) END
+FROM tasks t
+WHERE t.start_time >= '0001-01-02 00:00:00+00' AND NOT EXISTS (SELECT 1 FROM task_intervals i WHERE i.task_id = t.id);
@@ -0,0 +1,9 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status varchar(16) NOT NULL DEFAULT 'created';
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
+
+-- Tasks that already have intervals cannot be in the created state.
+UPDATE tasks SET status = CASE
+    WHEN EXISTS (SELECT 1 FROM task_intervals i WHERE i.task_id = tasks.id AND i.end_time IS NULL) THEN 'running'
+    ELSE 'done'
+END
+WHERE status = 'created' AND EXISTS (SELECT 1 FROM task_intervals i WHERE i.task_id = tasks.id);
@@ -0,0 +1,2 @@
+-- Tasks paused by the up migration stay paused.
+DROP INDEX IF EXISTS idx_tasks_one_running_per_user;
@@ -0,0 +1,17 @@
+-- A user may have only one running task: pause all but the most recently updated one
+-- and enforce the invariant with a partial unique index.
+WITH extra AS (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY updated_at DESC) AS rn
+        FROM tasks WHERE status = 'running' AND deleted_at IS NULL
+    ) ranked WHERE rn > 1
+), closed AS (
+    UPDATE task_intervals SET end_time = NOW() WHERE end_time IS NULL AND task_id IN (SELECT id FROM extra)
+)
+UPDATE tasks SET status = 'paused', duration = (
+    SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(i.end_time, NOW()) - i.start_time)), 0)::int / 60
+    FROM task_intervals i WHERE i.task_id = tasks.id AND i.deleted_at IS NULL
+)
+WHERE id IN (SELECT id FROM extra);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_one_running_per_user ON tasks (user_id) WHERE status = 'running' AND deleted_at IS NULL;
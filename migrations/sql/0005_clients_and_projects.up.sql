@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS clients (
+    id         bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name       text NOT NULL,
+    email      text
+);
+CREATE INDEX IF NOT EXISTS idx_clients_deleted_at ON clients (deleted_at);
+
+CREATE TABLE IF NOT EXISTS projects (
+    id          bigserial PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    client_id   bigint CONSTRAINT fk_clients_projects REFERENCES clients (id),
+    name        text NOT NULL,
+    description text
+);
+CREATE INDEX IF NOT EXISTS idx_projects_client_id ON projects (client_id);
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects (deleted_at);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id bigint CONSTRAINT fk_tasks_project REFERENCES projects (id);
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
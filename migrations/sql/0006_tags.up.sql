@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id         bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name       text NOT NULL,
+    color      text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags (name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tags_deleted_at ON tags (deleted_at);
+
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id bigint CONSTRAINT fk_task_tags_task REFERENCES tasks (id),
+    tag_id  bigint CONSTRAINT fk_task_tags_tag REFERENCES tags (id),
+    PRIMARY KEY (task_id, tag_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS rates;
+ALTER TABLE tasks DROP COLUMN IF EXISTS billable;
@@ -0,0 +1,14 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS billable boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS rates (
+    id             bigserial PRIMARY KEY,
+    created_at     timestamptz,
+    updated_at     timestamptz,
+    deleted_at     timestamptz,
+    user_id        bigint,
+    project_id     bigint,
+    hourly_rate    numeric(12,2) NOT NULL,
+    effective_from timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rates_scope ON rates (user_id, project_id, effective_from);
+CREATE INDEX IF NOT EXISTS idx_rates_deleted_at ON rates (deleted_at);
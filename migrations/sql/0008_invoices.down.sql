@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_intervals_invoice_id;
+ALTER TABLE task_intervals DROP COLUMN IF EXISTS invoice_id;
+DROP TABLE IF EXISTS invoice_sequences, invoice_lines, invoices;
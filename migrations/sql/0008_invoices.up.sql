@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS invoices (
+    id         bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    number     text,
+    client_id  bigint CONSTRAINT fk_invoices_client REFERENCES clients (id),
+    user_id    bigint CONSTRAINT fk_invoices_user REFERENCES users (id),
+    start_date timestamptz NOT NULL,
+    end_date   timestamptz NOT NULL,
+    status     varchar(16) NOT NULL DEFAULT 'draft',
+    currency   varchar(3) NOT NULL,
+    vat_rate   numeric(5,2) NOT NULL,
+    subtotal   numeric(12,2) NOT NULL,
+    vat_amount numeric(12,2) NOT NULL,
+    total      numeric(12,2) NOT NULL,
+    sent_at    timestamptz,
+    paid_at    timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_invoices_status ON invoices (status);
+CREATE INDEX IF NOT EXISTS idx_invoices_user_id ON invoices (user_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_client_id ON invoices (client_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_number ON invoices (number);
+CREATE INDEX IF NOT EXISTS idx_invoices_deleted_at ON invoices (deleted_at);
+
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id          bigserial PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    invoice_id  bigint NOT NULL CONSTRAINT fk_invoices_lines REFERENCES invoices (id),
+    task_id     bigint NOT NULL,
+    project_id  bigint,
+    description text,
+    hours       numeric(10,2) NOT NULL,
+    hourly_rate numeric(12,2) NOT NULL,
+    amount      numeric(12,2) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_task_id ON invoice_lines (task_id);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_invoice_id ON invoice_lines (invoice_id);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_deleted_at ON invoice_lines (deleted_at);
+
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    year        bigint PRIMARY KEY,
+    last_number bigint NOT NULL
+);
+
+ALTER TABLE task_intervals ADD COLUMN IF NOT EXISTS invoice_id bigint;
+CREATE INDEX IF NOT EXISTS idx_task_intervals_invoice_id ON task_intervals (invoice_id);
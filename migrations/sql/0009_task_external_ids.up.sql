@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS external_id text;
+CREATE INDEX IF NOT EXISTS idx_tasks_external_id ON tasks (external_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS timesheet_approvals;
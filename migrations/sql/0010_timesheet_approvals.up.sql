@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS timesheet_approvals (
+    id             bigserial PRIMARY KEY,
+    created_at     timestamptz,
+    updated_at     timestamptz,
+    deleted_at     timestamptz,
+    user_id        bigint NOT NULL CONSTRAINT fk_timesheet_approvals_user REFERENCES users (id),
+    week_start     date NOT NULL,
+    status         varchar(16) NOT NULL,
+    total_duration bigint,
+    submitted_at   timestamptz,
+    reviewer_id    bigint CONSTRAINT fk_timesheet_approvals_reviewer REFERENCES users (id),
+    reviewed_at    timestamptz,
+    comment        text
+);
+CREATE INDEX IF NOT EXISTS idx_timesheet_approvals_status ON timesheet_approvals (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_timesheet_approvals_week ON timesheet_approvals (user_id, week_start);
+CREATE INDEX IF NOT EXISTS idx_timesheet_approvals_deleted_at ON timesheet_approvals (deleted_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS budget_events;
+ALTER TABLE projects DROP COLUMN IF EXISTS budget_hours;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimated_duration;
@@ -0,0 +1,21 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimated_duration bigint;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS budget_hours numeric(10,2);
+
+CREATE TABLE IF NOT EXISTS budget_events (
+    id              bigserial PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    deleted_at      timestamptz,
+    kind            varchar(32) NOT NULL,
+    task_id         bigint NOT NULL CONSTRAINT fk_budget_events_task REFERENCES tasks (id),
+    user_id         bigint NOT NULL CONSTRAINT fk_budget_events_user REFERENCES users (id),
+    project_id      bigint CONSTRAINT fk_budget_events_project REFERENCES projects (id),
+    estimated       bigint,
+    actual          bigint,
+    acknowledged_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_budget_events_project_id ON budget_events (project_id);
+CREATE INDEX IF NOT EXISTS idx_budget_events_user_id ON budget_events (user_id);
+CREATE INDEX IF NOT EXISTS idx_budget_events_task_id ON budget_events (task_id);
+CREATE INDEX IF NOT EXISTS idx_budget_events_kind ON budget_events (kind);
+CREATE INDEX IF NOT EXISTS idx_budget_events_deleted_at ON budget_events (deleted_at);
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    id          bigserial PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    url         text NOT NULL,
+    description text,
+    events      jsonb NOT NULL,
+    secret      text NOT NULL,
+    active      boolean NOT NULL DEFAULT true
+);
+CREATE INDEX IF NOT EXISTS idx_webhooks_deleted_at ON webhooks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              bigserial PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    deleted_at      timestamptz,
+    webhook_id      bigint NOT NULL,
+    event_id        varchar(32) NOT NULL,
+    event           varchar(64) NOT NULL,
+    payload         text NOT NULL,
+    status          varchar(16) NOT NULL,
+    attempts        bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz,
+    last_attempt_at timestamptz,
+    response_status bigint,
+    response_body   text,
+    error           text,
+    redelivery_of   bigint
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_deleted_at ON webhook_deliveries (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries (event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
@@ -0,0 +1,7 @@
+-- Environment the database belongs to, recorded by the first run that migrates it.
+-- Seeding and reverting migrations are refused unless it is "dev".
+CREATE TABLE IF NOT EXISTS app_environment (
+    id          boolean PRIMARY KEY DEFAULT true CHECK (id),
+    name        text NOT NULL,
+    recorded_at timestamptz NOT NULL DEFAULT NOW()
+);
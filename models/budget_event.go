@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of budget events.
+const (
+	BudgetEventTaskEstimate  = "task_estimate_exceeded"
+	BudgetEventProjectBudget = "project_budget_exceeded"
+)
+
+// BudgetEvent records that ending a task pushed the task over its estimate or its project over budget,
+// so that managers can be notified.
+type BudgetEvent struct {
+	gorm.Model                // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	Kind           string     `gorm:"type:varchar(32);not null;index" json:"kind"` // Kind of the event: task_estimate_exceeded or project_budget_exceeded
+	TaskID         uint       `gorm:"not null;index" json:"taskID"`                // ID of the task whose end caused the event
+	UserID         uint       `gorm:"not null;index" json:"userID"`                // ID of the user who ended the task
+	ProjectID      *uint      `gorm:"index" json:"projectID"`                      // ID of the project of the task (nil if unassigned)
+	Estimated      int        `json:"estimated"`                                   // Estimate of the task or budget of the project in minutes when the event occurred
+	Actual         int        `json:"actual"`                                      // Minutes spent on the task or project when the event occurred
+	AcknowledgedAt *time.Time `json:"acknowledgedAt"`                              // Time a manager acknowledged the event (nil while it is new)
+	Task           *Task      `json:"task,omitempty"`                              // Task whose end caused the event
+	User           *User      `json:"user,omitempty"`                              // User who ended the task
+	Project        *Project   `json:"project,omitempty"`                           // Project of the task
+}
@@ -0,0 +1,11 @@
+package models
+
+import "gorm.io/gorm"
+
+// Client represents a customer that projects are carried out for.
+type Client struct {
+	gorm.Model           // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	Name       string    `gorm:"not null" json:"name"` // Name of the client
+	Email      string    `json:"email"`                // Contact email of the client
+	Projects   []Project `json:"projects,omitempty"`   // Projects of the client
+}
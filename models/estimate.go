@@ -0,0 +1,21 @@
+package models
+
+import "math"
+
+// Estimate compares the time spent on a task or project with its estimate or budget.
+type Estimate struct {
+	Estimated int     `json:"estimated"` // Estimated minutes
+	Actual    int     `json:"actual"`    // Minutes spent
+	Remaining int     `json:"remaining"` // Minutes left before the estimate is exceeded (negative once it is)
+	Usage     float64 `json:"usage"`     // Share of the estimate spent in percent, rounded to tenths
+	Exceeded  bool    `json:"exceeded"`  // Whether more time was spent than estimated
+}
+
+// NewEstimate compares the minutes spent with the estimated minutes.
+func NewEstimate(estimated, actual int) Estimate {
+	estimate := Estimate{Estimated: estimated, Actual: actual, Remaining: estimated - actual, Exceeded: actual > estimated}
+	if estimated > 0 {
+		estimate.Usage = math.Round(float64(actual)/float64(estimated)*1000) / 10
+	}
+	return estimate
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Invoice bills the tracked time of a client or user for a period.
+type Invoice struct {
+	gorm.Model               // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	Number     *string       `gorm:"uniqueIndex" json:"number"`                                   // Invoice number, assigned when the invoice is sent
+	ClientID   *uint         `gorm:"index" json:"clientID"`                                       // ID of the billed client (nil if not restricted to a client)
+	UserID     *uint         `gorm:"index" json:"userID"`                                         // ID of the user whose time is billed (nil for all users)
+	StartDate  time.Time     `gorm:"not null" json:"startDate"`                                   // Start of the billed period (inclusive)
+	EndDate    time.Time     `gorm:"not null" json:"endDate"`                                     // End of the billed period (exclusive)
+	Status     InvoiceStatus `gorm:"type:varchar(16);not null;default:draft;index" json:"status"` // Lifecycle state of the invoice
+	Currency   string        `gorm:"type:varchar(3);not null" json:"currency"`                    // ISO 4217 currency code
+	VATRate    float64       `gorm:"type:numeric(5,2);not null" json:"vatRate"`                   // VAT rate in percent
+	Subtotal   float64       `gorm:"type:numeric(12,2);not null" json:"subtotal"`                 // Sum of the line amounts
+	VATAmount  float64       `gorm:"type:numeric(12,2);not null" json:"vatAmount"`                // VAT charged on the subtotal
+	Total      float64       `gorm:"type:numeric(12,2);not null" json:"total"`                    // Subtotal plus VAT
+	SentAt     *time.Time    `json:"sentAt"`                                                      // Time the invoice was sent
+	PaidAt     *time.Time    `json:"paidAt"`                                                      // Time the invoice was paid
+	Client     *Client       `json:"client,omitempty"`                                            // Billed client
+	User       *User         `json:"user,omitempty"`                                              // User whose time is billed
+	Lines      []InvoiceLine `json:"lines"`                                                       // Line items, one per task and rate
+}
+
+// InvoiceLine is the time spent on a task at one hourly rate.
+type InvoiceLine struct {
+	gorm.Model          // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	InvoiceID   uint    `gorm:"index;not null" json:"invoiceID"`               // ID of the invoice the line belongs to
+	TaskID      uint    `gorm:"index;not null" json:"taskID"`                  // ID of the billed task
+	ProjectID   *uint   `json:"projectID"`                                     // ID of the project of the task (nil if unassigned)
+	Description string  `json:"description"`                                   // Project name and task description
+	Hours       float64 `gorm:"type:numeric(10,2);not null" json:"hours"`      // Billed hours, rounded to hundredths
+	HourlyRate  float64 `gorm:"type:numeric(12,2);not null" json:"hourlyRate"` // Rate the hours are billed at
+	Amount      float64 `gorm:"type:numeric(12,2);not null" json:"amount"`     // Amount charged for the line
+}
+
+// InvoiceSequence holds the last invoice number issued in a year.
+type InvoiceSequence struct {
+	Year       int `gorm:"primaryKey;autoIncrement:false"` // Calendar year the numbers belong to
+	LastNumber int `gorm:"not null"`                       // Last number issued in the year
+}
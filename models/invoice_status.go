@@ -0,0 +1,70 @@
+package models
+
+import "fmt"
+
+// InvoiceStatus represents a lifecycle state of an invoice.
+type InvoiceStatus string
+
+// Invoice lifecycle states.
+const (
+	InvoiceStatusDraft InvoiceStatus = "draft"
+	InvoiceStatusSent  InvoiceStatus = "sent"
+	InvoiceStatusPaid  InvoiceStatus = "paid"
+)
+
+// InvoiceAction represents an operation that moves an invoice between lifecycle states.
+type InvoiceAction string
+
+// Invoice lifecycle actions.
+const (
+	InvoiceActionSend    InvoiceAction = "send"
+	InvoiceActionPay     InvoiceAction = "pay"
+	InvoiceActionDiscard InvoiceAction = "discard" // Deletes a draft invoice
+)
+
+// Machine-readable reasons for rejected invoice operations.
+const (
+	ReasonInvoiceNotDraft = "invoice_not_draft"
+	ReasonInvoiceNotSent  = "invoice_not_sent"
+)
+
+// invoiceTransitions is the single source of truth for the invoice lifecycle.
+var invoiceTransitions = map[InvoiceAction]struct {
+	from   InvoiceStatus
+	to     InvoiceStatus
+	reason string
+}{
+	InvoiceActionSend: {from: InvoiceStatusDraft, to: InvoiceStatusSent, reason: ReasonInvoiceNotDraft},
+	InvoiceActionPay:  {from: InvoiceStatusSent, to: InvoiceStatusPaid, reason: ReasonInvoiceNotSent},
+}
+
+// InvoiceTransitionError is returned when an action is not allowed in the current invoice state.
+type InvoiceTransitionError struct {
+	Status InvoiceStatus // State the invoice was in
+	Action InvoiceAction // Action that was rejected
+	Reason string        // Machine-readable reason
+}
+
+func (e *InvoiceTransitionError) Error() string {
+	return fmt.Sprintf("cannot %s an invoice in status %q: %s", e.Action, e.Status, e.Reason)
+}
+
+// IsValid reports whether the status is one of the known invoice states.
+func (s InvoiceStatus) IsValid() bool {
+	return s == InvoiceStatusDraft || s == InvoiceStatusSent || s == InvoiceStatusPaid
+}
+
+// Transition validates the action against the current status and moves the invoice to the resulting state.
+func (i *Invoice) Transition(action InvoiceAction) error {
+	transition, ok := invoiceTransitions[action]
+	if !ok {
+		return fmt.Errorf("unknown invoice action %q", action)
+	}
+
+	if i.Status != transition.from {
+		return &InvoiceTransitionError{Status: i.Status, Action: action, Reason: transition.reason}
+	}
+
+	i.Status = transition.to
+	return nil
+}
@@ -0,0 +1,13 @@
+package models
+
+import "gorm.io/gorm"
+
+// Project represents a project that tasks are tracked against.
+type Project struct {
+	gorm.Model           // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	ClientID    *uint    `gorm:"index" json:"clientID"`                 // ID of the client the project belongs to (nil for internal projects)
+	Name        string   `gorm:"not null" json:"name"`                  // Name of the project
+	Description string   `json:"description"`                           // Description of the project
+	BudgetHours *float64 `gorm:"type:numeric(10,2)" json:"budgetHours"` // Budget of the project in hours (nil if not budgeted)
+	Client      *Client  `json:"client,omitempty"`                      // Client the project belongs to
+}
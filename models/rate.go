@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rate is an hourly rate that applies to time tracked from EffectiveFrom until the next rate with the same scope.
+// A rate may be scoped to a user, a project, both, or neither (the default rate).
+// When several rates apply, project rates win over user rates, and user-and-project rates win over project rates.
+type Rate struct {
+	gorm.Model              // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	UserID        *uint     `gorm:"index:idx_rates_scope" json:"userID"`                 // ID of the user the rate applies to (nil for all users)
+	ProjectID     *uint     `gorm:"index:idx_rates_scope" json:"projectID"`              // ID of the project the rate applies to (nil for all projects)
+	HourlyRate    float64   `gorm:"type:numeric(12,2);not null" json:"hourlyRate"`       // Amount charged per hour
+	EffectiveFrom time.Time `gorm:"not null;index:idx_rates_scope" json:"effectiveFrom"` // Time from which the rate applies
+}
@@ -0,0 +1,10 @@
+package models
+
+import "gorm.io/gorm"
+
+// Tag represents a label used to categorise tasks.
+type Tag struct {
+	gorm.Model        // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	Name       string `gorm:"not null;uniqueIndex:idx_tags_name,where:deleted_at IS NULL" json:"name"` // Name of the tag (unique, lower case)
+	Color      string `json:"color"`                                                                   // Display color of the tag, e.g. #ff8800
+}
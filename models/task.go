@@ -7,10 +7,66 @@ import (
 
 // Task represents a task assigned to a user.
 type Task struct {
-	gorm.Model            // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
-	UserID      uint      `json:"userID"`      // ID of the user associated with the task
-	Description string    `json:"description"` // Description of the task
-	StartTime   time.Time `json:"startTime"`   // Start time of the task
-	EndTime     time.Time `json:"endTime"`     // End time of the task
-	Duration    int       `json:"duration"`    // Duration of the task in minutes
+	gorm.Model                       // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	UserID            uint           `json:"userID"`                                                        // ID of the user associated with the task
+	ProjectID         *uint          `gorm:"index" json:"projectID"`                                        // ID of the project the task belongs to (nil if unassigned)
+	Description       string         `json:"description"`                                                   // Description of the task
+	ExternalID        *string        `gorm:"index" json:"externalID,omitempty"`                             // ID of the entry the task was imported from (nil if created here)
+	Billable          bool           `gorm:"not null;default:false" json:"billable"`                        // Whether time spent on the task is billed to the client
+	Status            TaskStatus     `gorm:"type:varchar(16);not null;default:created;index" json:"status"` // Lifecycle state of the task
+	StartTime         time.Time      `json:"startTime"`                                                     // Start time of the first interval of the task
+	EndTime           time.Time      `json:"endTime"`                                                       // End time of the last closed interval of the task
+	Duration          int            `json:"duration"`                                                      // Duration of the task in minutes (sum of all closed intervals)
+	EstimatedDuration *int           `json:"estimatedDuration"`                                             // Estimated duration of the task in minutes (nil if not estimated)
+	Estimate          *Estimate      `gorm:"-" json:"estimate,omitempty"`                                   // Estimated versus actual duration (nil if not estimated)
+	Project           *Project       `json:"project,omitempty"`                                             // Project the task belongs to
+	Tags              []Tag          `gorm:"many2many:task_tags;" json:"tags"`                              // Tags of the task
+	Intervals         []TaskInterval `json:"intervals"`                                                     // Work intervals of the task, ordered by start time
+}
+
+// OpenInterval returns the currently running interval of the task, or nil if there is none.
+func (t *Task) OpenInterval() *TaskInterval {
+	for i := range t.Intervals {
+		if t.Intervals[i].IsOpen() {
+			return &t.Intervals[i]
+		}
+	}
+	return nil
+}
+
+// RecalculateDuration updates StartTime, EndTime and Duration from the task intervals.
+// Only closed intervals contribute to Duration.
+func (t *Task) RecalculateDuration() {
+	var total time.Duration
+	t.StartTime = time.Time{}
+	t.EndTime = time.Time{}
+	for _, interval := range t.Intervals {
+		if t.StartTime.IsZero() || interval.StartTime.Before(t.StartTime) {
+			t.StartTime = interval.StartTime
+		}
+		if interval.IsOpen() {
+			continue
+		}
+		total += interval.Length(*interval.EndTime)
+		if interval.EndTime.After(t.EndTime) {
+			t.EndTime = *interval.EndTime
+		}
+	}
+	t.Duration = int(total.Minutes())
+	t.UpdateEstimate()
+}
+
+// UpdateEstimate compares Duration with the estimated duration of the task.
+func (t *Task) UpdateEstimate() {
+	t.Estimate = nil
+	if t.EstimatedDuration != nil {
+		estimate := NewEstimate(*t.EstimatedDuration, t.Duration)
+		t.Estimate = &estimate
+	}
+}
+
+// AfterFind fills in the estimate of tasks loaded from the database.
+func (t *Task) AfterFind(tx *gorm.DB) error {
+	t.UpdateEstimate()
+	return nil
 }
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaskInterval represents a single continuous period of work on a task.
+type TaskInterval struct {
+	gorm.Model            // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	TaskID     uint       `gorm:"index;not null" json:"taskID"` // ID of the task the interval belongs to
+	StartTime  time.Time  `gorm:"not null" json:"startTime"`    // Start time of the interval
+	EndTime    *time.Time `json:"endTime"`                      // End time of the interval (nil while the interval is open)
+	InvoiceID  *uint      `gorm:"index" json:"invoiceID"`       // ID of the invoice the interval was billed on (nil if not invoiced)
+}
+
+// IsOpen reports whether the interval is still running.
+func (i *TaskInterval) IsOpen() bool {
+	return i.EndTime == nil
+}
+
+// Length returns the length of the interval. Open intervals are measured up to now.
+func (i *TaskInterval) Length(now time.Time) time.Duration {
+	end := now
+	if i.EndTime != nil {
+		end = *i.EndTime
+	}
+	if end.Before(i.StartTime) {
+		return 0
+	}
+	return end.Sub(i.StartTime)
+}
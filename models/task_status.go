@@ -0,0 +1,121 @@
+package models
+
+import "fmt"
+
+// TaskStatus represents a lifecycle state of a task.
+type TaskStatus string
+
+// Task lifecycle states.
+const (
+	TaskStatusCreated   TaskStatus = "created"
+	TaskStatusRunning   TaskStatus = "running"
+	TaskStatusPaused    TaskStatus = "paused"
+	TaskStatusDone      TaskStatus = "done"
+	TaskStatusCancelled TaskStatus = "cancelled"
+)
+
+// TaskAction represents an operation that moves a task between lifecycle states.
+type TaskAction string
+
+// Task lifecycle actions.
+const (
+	TaskActionStart  TaskAction = "start"
+	TaskActionPause  TaskAction = "pause"
+	TaskActionResume TaskAction = "resume"
+	TaskActionEnd    TaskAction = "end"
+	TaskActionCancel TaskAction = "cancel"
+)
+
+// Machine-readable reasons for rejected transitions.
+const (
+	ReasonTaskClosed         = "task_closed"
+	ReasonTaskAlreadyStarted = "task_already_started"
+	ReasonTaskNotStarted     = "task_not_started"
+	ReasonTaskNotRunning     = "task_not_running"
+	ReasonTaskNotPaused      = "task_not_paused"
+	ReasonAnotherTaskRunning = "another_task_running"
+	ReasonTaskRunning        = "task_running"
+	ReasonTaskInvoiced       = "task_invoiced"
+)
+
+// taskTransition describes the states an action may be applied from and the state it leads to.
+type taskTransition struct {
+	from []TaskStatus
+	to   TaskStatus
+}
+
+// taskTransitions is the single source of truth for the task lifecycle.
+var taskTransitions = map[TaskAction]taskTransition{
+	TaskActionStart:  {from: []TaskStatus{TaskStatusCreated}, to: TaskStatusRunning},
+	TaskActionPause:  {from: []TaskStatus{TaskStatusRunning}, to: TaskStatusPaused},
+	TaskActionResume: {from: []TaskStatus{TaskStatusPaused}, to: TaskStatusRunning},
+	TaskActionEnd:    {from: []TaskStatus{TaskStatusRunning, TaskStatusPaused}, to: TaskStatusDone},
+	TaskActionCancel: {from: []TaskStatus{TaskStatusCreated, TaskStatusRunning, TaskStatusPaused}, to: TaskStatusCancelled},
+}
+
+// TransitionError is returned when an action is not allowed in the current task state.
+type TransitionError struct {
+	Status TaskStatus // State the task was in
+	Action TaskAction // Action that was rejected
+	Reason string     // Machine-readable reason
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot %s a task in status %q: %s", e.Action, e.Status, e.Reason)
+}
+
+// RunningTaskError is returned when a task cannot be started because the user already has a running task.
+type RunningTaskError struct {
+	TaskID uint // ID of the task that is already running
+}
+
+func (e *RunningTaskError) Error() string {
+	return fmt.Sprintf("task %d is already running", e.TaskID)
+}
+
+// IsValid reports whether the status is one of the known task states.
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case TaskStatusCreated, TaskStatusRunning, TaskStatusPaused, TaskStatusDone, TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsClosed reports whether the status is terminal.
+func (s TaskStatus) IsClosed() bool {
+	return s == TaskStatusDone || s == TaskStatusCancelled
+}
+
+// Transition validates the action against the current status and moves the task to the resulting state.
+func (t *Task) Transition(action TaskAction) error {
+	transition, ok := taskTransitions[action]
+	if !ok {
+		return fmt.Errorf("unknown task action %q", action)
+	}
+
+	for _, from := range transition.from {
+		if t.Status == from {
+			t.Status = transition.to
+			return nil
+		}
+	}
+
+	return &TransitionError{Status: t.Status, Action: action, Reason: transitionReason(t.Status, action)}
+}
+
+// transitionReason explains why the action cannot be applied in the given status.
+func transitionReason(status TaskStatus, action TaskAction) string {
+	switch {
+	case status.IsClosed():
+		return ReasonTaskClosed
+	case action == TaskActionStart:
+		return ReasonTaskAlreadyStarted
+	case action == TaskActionPause:
+		return ReasonTaskNotRunning
+	case action == TaskActionResume:
+		return ReasonTaskNotPaused
+	default:
+		return ReasonTaskNotStarted
+	}
+}
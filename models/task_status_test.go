@@ -0,0 +1,104 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTaskTransition(t *testing.T) {
+	// Every status × action pair: the resulting status, or the reason the action is rejected.
+	tests := []struct {
+		status TaskStatus
+		action TaskAction
+		want   TaskStatus
+		reason string
+	}{
+		{TaskStatusCreated, TaskActionStart, TaskStatusRunning, ""},
+		{TaskStatusCreated, TaskActionPause, "", ReasonTaskNotRunning},
+		{TaskStatusCreated, TaskActionResume, "", ReasonTaskNotPaused},
+		{TaskStatusCreated, TaskActionEnd, "", ReasonTaskNotStarted},
+		{TaskStatusCreated, TaskActionCancel, TaskStatusCancelled, ""},
+
+		{TaskStatusRunning, TaskActionStart, "", ReasonTaskAlreadyStarted},
+		{TaskStatusRunning, TaskActionPause, TaskStatusPaused, ""},
+		{TaskStatusRunning, TaskActionResume, "", ReasonTaskNotPaused},
+		{TaskStatusRunning, TaskActionEnd, TaskStatusDone, ""},
+		{TaskStatusRunning, TaskActionCancel, TaskStatusCancelled, ""},
+
+		{TaskStatusPaused, TaskActionStart, "", ReasonTaskAlreadyStarted},
+		{TaskStatusPaused, TaskActionPause, "", ReasonTaskNotRunning},
+		{TaskStatusPaused, TaskActionResume, TaskStatusRunning, ""},
+		{TaskStatusPaused, TaskActionEnd, TaskStatusDone, ""},
+		{TaskStatusPaused, TaskActionCancel, TaskStatusCancelled, ""},
+
+		{TaskStatusDone, TaskActionStart, "", ReasonTaskClosed},
+		{TaskStatusDone, TaskActionPause, "", ReasonTaskClosed},
+		{TaskStatusDone, TaskActionResume, "", ReasonTaskClosed},
+		{TaskStatusDone, TaskActionEnd, "", ReasonTaskClosed},
+		{TaskStatusDone, TaskActionCancel, "", ReasonTaskClosed},
+
+		{TaskStatusCancelled, TaskActionStart, "", ReasonTaskClosed},
+		{TaskStatusCancelled, TaskActionPause, "", ReasonTaskClosed},
+		{TaskStatusCancelled, TaskActionResume, "", ReasonTaskClosed},
+		{TaskStatusCancelled, TaskActionEnd, "", ReasonTaskClosed},
+		{TaskStatusCancelled, TaskActionCancel, "", ReasonTaskClosed},
+	}
+
+	covered := map[TaskStatus]map[TaskAction]bool{}
+	for _, tt := range tests {
+		if covered[tt.status] == nil {
+			covered[tt.status] = map[TaskAction]bool{}
+		}
+		covered[tt.status][tt.action] = true
+
+		t.Run(string(tt.status)+"/"+string(tt.action), func(t *testing.T) {
+			task := Task{Status: tt.status}
+			err := task.Transition(tt.action)
+
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("Transition(%s) = %v, want success", tt.action, err)
+				}
+				if task.Status != tt.want {
+					t.Errorf("status = %s, want %s", task.Status, tt.want)
+				}
+				return
+			}
+
+			var transitionErr *TransitionError
+			if !errors.As(err, &transitionErr) {
+				t.Fatalf("Transition(%s) = %v, want a *TransitionError", tt.action, err)
+			}
+			if transitionErr.Reason != tt.reason || transitionErr.Status != tt.status || transitionErr.Action != tt.action {
+				t.Errorf("error = %+v, want reason %s for %s/%s", *transitionErr, tt.reason, tt.status, tt.action)
+			}
+			if task.Status != tt.status {
+				t.Errorf("status changed to %s by a rejected transition", task.Status)
+			}
+		})
+	}
+
+	// The table must cover every known status and action, so new ones cannot be added without a test case.
+	for _, status := range []TaskStatus{TaskStatusCreated, TaskStatusRunning, TaskStatusPaused, TaskStatusDone, TaskStatusCancelled} {
+		for action := range taskTransitions {
+			if !covered[status][action] {
+				t.Errorf("no test case for %s/%s", status, action)
+			}
+		}
+	}
+}
+
+func TestTaskTransitionUnknownAction(t *testing.T) {
+	task := Task{Status: TaskStatusCreated}
+	err := task.Transition("finish")
+	if err == nil {
+		t.Fatal("Transition(finish) succeeded, want an error")
+	}
+	var transitionErr *TransitionError
+	if errors.As(err, &transitionErr) {
+		t.Errorf("unknown action returned a *TransitionError: %v", err)
+	}
+	if task.Status != TaskStatusCreated {
+		t.Errorf("status changed to %s", task.Status)
+	}
+}
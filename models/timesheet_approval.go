@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TimesheetApproval is a week of a user's tracked time submitted to a manager for approval.
+// Approved weeks are locked: their time can no longer be added, tracked or edited.
+type TimesheetApproval struct {
+	gorm.Model                    // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	UserID        uint            `gorm:"not null;uniqueIndex:idx_timesheet_approvals_week" json:"userID"`              // ID of the user whose time is submitted
+	WeekStart     time.Time       `gorm:"type:date;not null;uniqueIndex:idx_timesheet_approvals_week" json:"weekStart"` // Monday the week starts on
+	Status        TimesheetStatus `gorm:"type:varchar(16);not null;index" json:"status"`                                // Approval state of the week
+	TotalDuration int             `json:"totalDuration"`                                                                // Minutes tracked in the week when it was last submitted or approved
+	SubmittedAt   *time.Time      `json:"submittedAt"`                                                                  // Time the week was last submitted
+	ReviewerID    *uint           `json:"reviewerID"`                                                                   // ID of the manager who approved or rejected the week
+	ReviewedAt    *time.Time      `json:"reviewedAt"`                                                                   // Time the week was approved or rejected
+	Comment       string          `json:"comment"`                                                                      // Comment of the manager
+	User          *User           `json:"user,omitempty"`                                                               // User whose time is submitted
+	Reviewer      *User           `json:"reviewer,omitempty"`                                                           // Manager who approved or rejected the week
+}
@@ -0,0 +1,86 @@
+package models
+
+import "fmt"
+
+// TimesheetStatus represents an approval state of a weekly timesheet.
+type TimesheetStatus string
+
+// Timesheet approval states.
+const (
+	TimesheetStatusSubmitted TimesheetStatus = "submitted"
+	TimesheetStatusApproved  TimesheetStatus = "approved"
+	TimesheetStatusRejected  TimesheetStatus = "rejected"
+)
+
+// TimesheetAction represents an operation that moves a timesheet between approval states.
+type TimesheetAction string
+
+// Timesheet approval actions.
+const (
+	TimesheetActionSubmit  TimesheetAction = "submit"
+	TimesheetActionApprove TimesheetAction = "approve"
+	TimesheetActionReject  TimesheetAction = "reject"
+)
+
+// Machine-readable reasons for rejected timesheet operations.
+const (
+	ReasonTimesheetSubmitted    = "timesheet_submitted"
+	ReasonTimesheetApproved     = "timesheet_approved"
+	ReasonTimesheetNotSubmitted = "timesheet_not_submitted"
+	ReasonPeriodLocked          = "period_locked"
+)
+
+// timesheetTransitions is the single source of truth for the approval workflow.
+// A timesheet that has never been submitted has an empty status.
+var timesheetTransitions = map[TimesheetAction]struct {
+	from []TimesheetStatus
+	to   TimesheetStatus
+}{
+	TimesheetActionSubmit:  {from: []TimesheetStatus{"", TimesheetStatusRejected}, to: TimesheetStatusSubmitted},
+	TimesheetActionApprove: {from: []TimesheetStatus{TimesheetStatusSubmitted}, to: TimesheetStatusApproved},
+	TimesheetActionReject:  {from: []TimesheetStatus{TimesheetStatusSubmitted}, to: TimesheetStatusRejected},
+}
+
+// TimesheetTransitionError is returned when an action is not allowed in the current timesheet state.
+type TimesheetTransitionError struct {
+	Status TimesheetStatus // State the timesheet was in
+	Action TimesheetAction // Action that was rejected
+	Reason string          // Machine-readable reason
+}
+
+func (e *TimesheetTransitionError) Error() string {
+	return fmt.Sprintf("cannot %s a timesheet in status %q: %s", e.Action, e.Status, e.Reason)
+}
+
+// IsValid reports whether the status is one of the known timesheet states.
+func (s TimesheetStatus) IsValid() bool {
+	return s == TimesheetStatusSubmitted || s == TimesheetStatusApproved || s == TimesheetStatusRejected
+}
+
+// Transition validates the action against the current status and moves the timesheet to the resulting state.
+func (t *TimesheetApproval) Transition(action TimesheetAction) error {
+	transition, ok := timesheetTransitions[action]
+	if !ok {
+		return fmt.Errorf("unknown timesheet action %q", action)
+	}
+
+	for _, from := range transition.from {
+		if t.Status == from {
+			t.Status = transition.to
+			return nil
+		}
+	}
+	return &TimesheetTransitionError{Status: t.Status, Action: action, Reason: timesheetTransitionReason(t.Status)}
+}
+
+// timesheetTransitionReason explains why an action cannot be applied in the given state.
+func timesheetTransitionReason(status TimesheetStatus) string {
+	switch status {
+	case TimesheetStatusSubmitted:
+		return ReasonTimesheetSubmitted
+	case TimesheetStatusApproved:
+		return ReasonTimesheetApproved
+	default:
+		return ReasonTimesheetNotSubmitted
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook is a subscription of an external URL to events of the service.
+type Webhook struct {
+	gorm.Model           // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	URL         string   `gorm:"not null" json:"url"`                               // URL the events are posted to
+	Description string   `json:"description"`                                       // Description of the subscriber
+	Events      []string `gorm:"serializer:json;type:jsonb;not null" json:"events"` // Names of the events the webhook is subscribed to
+	Secret      string   `gorm:"not null" json:"-"`                                 // Key of the HMAC-SHA256 signature of deliveries, never listed
+	Active      bool     `gorm:"not null;default:true" json:"active"`               // Whether events are delivered to the webhook
+}
+
+// Subscribes reports whether the webhook is subscribed to the event.
+func (w *Webhook) Subscribes(event string) bool {
+	for _, name := range w.Events {
+		if name == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus represents a state of a webhook delivery.
+type WebhookDeliveryStatus string
+
+// Webhook delivery states.
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for its first attempt or a retry
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // Answered with a 2xx status
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // Given up after the last attempt
+)
+
+// IsValid reports whether the status is one of the known delivery states.
+func (s WebhookDeliveryStatus) IsValid() bool {
+	return s == WebhookDeliveryPending || s == WebhookDeliverySucceeded || s == WebhookDeliveryFailed
+}
+
+// WebhookDelivery is an event posted to a webhook, together with the outcome of its last attempt.
+type WebhookDelivery struct {
+	gorm.Model                           // Default GORM model fields (ID, CreatedAt, UpdatedAt, DeletedAt)
+	WebhookID      uint                  `gorm:"not null;index" json:"webhookID"`                // ID of the webhook
+	EventID        string                `gorm:"type:varchar(32);not null;index" json:"eventID"` // ID of the event, shared by its deliveries to all webhooks
+	Event          string                `gorm:"type:varchar(64);not null" json:"event"`         // Name of the event
+	Payload        string                `gorm:"type:text;not null" json:"payload"`              // JSON body posted to the webhook
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(16);not null;index" json:"status"`  // Delivery state
+	Attempts       int                   `gorm:"not null;default:0" json:"attempts"`             // Number of attempts made
+	NextAttemptAt  *time.Time            `gorm:"index" json:"nextAttemptAt"`                     // Time of the next attempt (nil once the delivery is finished)
+	LastAttemptAt  *time.Time            `json:"lastAttemptAt"`                                  // Time of the last attempt
+	ResponseStatus int                   `json:"responseStatus"`                                 // HTTP status of the last response (0 if there was none)
+	ResponseBody   string                `gorm:"type:text" json:"responseBody"`                  // Beginning of the body of the last response
+	Error          string                `gorm:"type:text" json:"error"`                         // Error of the last attempt
+	RedeliveryOf   *uint                 `json:"redeliveryOf,omitempty"`                         // ID of the delivery this one was manually redelivered from
+}
@@ -0,0 +1,88 @@
+package reports
+
+import (
+	"database/sql"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TaskFilter restricts reports to a subset of tasks. Empty fields do not restrict anything.
+type TaskFilter struct {
+	ProjectIDs []uint   // Only tasks of these projects
+	ClientIDs  []uint   // Only tasks of projects of these clients
+	TagsAny    []string // Only tasks with at least one of these tags
+	TagsAll    []string // Only tasks with all of these tags
+	TagsNone   []string // Only tasks with none of these tags
+	Billable   *bool    // Only billable (true) or non-billable (false) tasks
+}
+
+// taggedTasks selects IDs of tasks that have a tag from the named argument list.
+const taggedTasks = `SELECT tt.task_id FROM task_tags tt JOIN tags g ON g.id = tt.tag_id AND g.deleted_at IS NULL WHERE g.name IN `
+
+// IsEmpty reports whether the filter does not restrict anything.
+func (f TaskFilter) IsEmpty() bool {
+	return len(f.ProjectIDs) == 0 && len(f.ClientIDs) == 0 && len(f.TagsAny) == 0 && len(f.TagsAll) == 0 && len(f.TagsNone) == 0 && f.Billable == nil
+}
+
+// Scope returns a query scope restricting a query on the tasks table to tasks matching the filter.
+func (f TaskFilter) Scope(db *gorm.DB) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if f.IsEmpty() {
+			return query
+		}
+		filterSQL, filterArgs := f.where()
+		return query.Where("tasks.id IN (?)", db.Table("tasks t").Select("t.id").Where(filterSQL, filterArgs...))
+	}
+}
+
+// where returns the SQL condition selecting tasks "t" that match the filter, with its named arguments.
+func (f TaskFilter) where() (string, []interface{}) {
+	conditions := []string{"TRUE"}
+	var args []interface{}
+
+	if len(f.ProjectIDs) > 0 {
+		conditions = append(conditions, "t.project_id IN @projectIDs")
+		args = append(args, sql.Named("projectIDs", f.ProjectIDs))
+	}
+
+	if len(f.ClientIDs) > 0 {
+		conditions = append(conditions, "t.project_id IN (SELECT p.id FROM projects p WHERE p.client_id IN @clientIDs)")
+		args = append(args, sql.Named("clientIDs", f.ClientIDs))
+	}
+
+	if len(f.TagsAny) > 0 {
+		conditions = append(conditions, "t.id IN ("+taggedTasks+"@tagsAny)")
+		args = append(args, sql.Named("tagsAny", f.TagsAny))
+	}
+
+	if len(f.TagsAll) > 0 {
+		conditions = append(conditions, "t.id IN ("+taggedTasks+"@tagsAll GROUP BY tt.task_id HAVING COUNT(DISTINCT g.name) = @tagsAllCount)")
+		args = append(args, sql.Named("tagsAll", f.TagsAll), sql.Named("tagsAllCount", len(uniqueStrings(f.TagsAll))))
+	}
+
+	if len(f.TagsNone) > 0 {
+		conditions = append(conditions, "t.id NOT IN ("+taggedTasks+"@tagsNone)")
+		args = append(args, sql.Named("tagsNone", f.TagsNone))
+	}
+
+	if f.Billable != nil {
+		conditions = append(conditions, "t.billable = @billable")
+		args = append(args, sql.Named("billable", *f.Billable))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// uniqueStrings returns the distinct values of the list.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,176 @@
+package reports
+
+import (
+	"database/sql"
+	"math"
+	"sort"
+	"time"
+	"time-tracker-go/models"
+
+	"gorm.io/gorm"
+)
+
+// Overrun levels.
+const (
+	OverrunExceeded = "exceeded" // More time was spent than estimated
+	OverrunAtRisk   = "at_risk"  // The share of the estimate spent has reached the threshold
+)
+
+// spentSeconds is the SQL expression for the total length in seconds of task intervals "i", open intervals counted up to @now.
+const spentSeconds = `COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(i.end_time, @now) - i.start_time)), 0)`
+
+// TaskOverrun is a task that has exceeded its estimate or is about to.
+type TaskOverrun struct {
+	TaskID      uint              `json:"taskID"`      // ID of the task
+	UserID      uint              `json:"userID"`      // ID of the user of the task
+	ProjectID   *uint             `json:"projectID"`   // ID of the project of the task (nil if unassigned)
+	Description string            `json:"description"` // Description of the task
+	Status      models.TaskStatus `json:"status"`      // Lifecycle state of the task
+	Level       string            `json:"level"`       // exceeded or at_risk
+	models.Estimate
+}
+
+// ProjectOverrun is a project that has exceeded its budget or is about to.
+type ProjectOverrun struct {
+	ProjectID   uint    `json:"projectID"`   // ID of the project
+	ProjectName string  `json:"projectName"` // Name of the project
+	ClientID    *uint   `json:"clientID"`    // ID of the client (nil for internal projects)
+	ClientName  string  `json:"clientName"`  // Name of the client
+	BudgetHours float64 `json:"budgetHours"` // Budget of the project in hours
+	Hours       float64 `json:"hours"`       // Hours spent on the project, rounded to hundredths
+	Level       string  `json:"level"`       // exceeded or at_risk
+	models.Estimate
+}
+
+// OverrunReport lists the tasks and projects that have exceeded their estimates and budgets or are about to.
+type OverrunReport struct {
+	Threshold float64          `json:"threshold"` // Share of the estimate in percent from which tasks and projects are at risk
+	Tasks     []TaskOverrun    `json:"tasks"`     // Tasks over or near their estimates, highest usage first
+	Projects  []ProjectOverrun `json:"projects"`  // Projects over or near their budgets, highest usage first
+}
+
+// OverrunFilter restricts the overrun report. Nil fields do not restrict anything.
+type OverrunFilter struct {
+	UserID   *uint // Only tasks of this user (projects are not restricted)
+	ClientID *uint // Only projects of this client and their tasks
+}
+
+// Overruns reports the tasks and projects that have spent at least the threshold percentage of their
+// estimates or budgets. Running intervals are counted up to now. Cancelled tasks are not reported,
+// but their time counts towards their projects; done tasks are reported only once they exceed their estimates.
+func Overruns(db *gorm.DB, threshold float64, filter OverrunFilter, now time.Time) (OverrunReport, error) {
+	result := OverrunReport{Threshold: threshold, Tasks: []TaskOverrun{}, Projects: []ProjectOverrun{}}
+	args := []interface{}{sql.Named("now", now), sql.Named("threshold", threshold)}
+
+	var taskRows []struct {
+		TaskID            uint
+		UserID            uint
+		ProjectID         *uint
+		Description       string
+		Status            models.TaskStatus
+		EstimatedDuration int
+		Seconds           float64
+	}
+	query := db.Table("tasks t").
+		Select("t.id AS task_id, t.user_id, t.project_id, t.description, t.status, t.estimated_duration, "+spentSeconds+" AS seconds", args...).
+		Joins("LEFT JOIN task_intervals i ON i.task_id = t.id AND i.deleted_at IS NULL").
+		Where("t.deleted_at IS NULL AND t.estimated_duration > 0 AND t.status <> ?", models.TaskStatusCancelled).
+		Group("t.id").
+		Having(spentSeconds+" >= t.estimated_duration * 60 * @threshold / 100", args...)
+	if filter.UserID != nil {
+		query = query.Where("t.user_id = ?", *filter.UserID)
+	}
+	if filter.ClientID != nil {
+		query = query.Where("t.project_id IN (SELECT id FROM projects WHERE client_id = ?)", *filter.ClientID)
+	}
+	if err := query.Scan(&taskRows).Error; err != nil {
+		return result, err
+	}
+
+	for _, row := range taskRows {
+		estimate := models.NewEstimate(row.EstimatedDuration, minutes(row.Seconds))
+		level := overrunLevel(estimate)
+		if level == OverrunAtRisk && row.Status == models.TaskStatusDone {
+			continue
+		}
+		result.Tasks = append(result.Tasks, TaskOverrun{
+			TaskID:      row.TaskID,
+			UserID:      row.UserID,
+			ProjectID:   row.ProjectID,
+			Description: row.Description,
+			Status:      row.Status,
+			Level:       level,
+			Estimate:    estimate,
+		})
+	}
+
+	var projectRows []struct {
+		ProjectID   uint
+		ProjectName string
+		ClientID    *uint
+		ClientName  string
+		BudgetHours float64
+		Seconds     float64
+	}
+	query = db.Table("projects p").
+		Select("p.id AS project_id, p.name AS project_name, p.client_id, COALESCE(c.name, '') AS client_name, p.budget_hours, "+spentSeconds+" AS seconds", args...).
+		Joins("LEFT JOIN clients c ON c.id = p.client_id").
+		Joins("LEFT JOIN tasks t ON t.project_id = p.id AND t.deleted_at IS NULL").
+		Joins("LEFT JOIN task_intervals i ON i.task_id = t.id AND i.deleted_at IS NULL").
+		Where("p.deleted_at IS NULL AND p.budget_hours > 0").
+		Group("p.id, c.name").
+		Having(spentSeconds+" >= p.budget_hours * 3600 * @threshold / 100", args...)
+	if filter.ClientID != nil {
+		query = query.Where("p.client_id = ?", *filter.ClientID)
+	}
+	if err := query.Scan(&projectRows).Error; err != nil {
+		return result, err
+	}
+
+	for _, row := range projectRows {
+		estimate := models.NewEstimate(BudgetMinutes(row.BudgetHours), minutes(row.Seconds))
+		result.Projects = append(result.Projects, ProjectOverrun{
+			ProjectID:   row.ProjectID,
+			ProjectName: row.ProjectName,
+			ClientID:    row.ClientID,
+			ClientName:  row.ClientName,
+			BudgetHours: row.BudgetHours,
+			Hours:       hours(row.Seconds),
+			Level:       overrunLevel(estimate),
+			Estimate:    estimate,
+		})
+	}
+
+	sort.SliceStable(result.Tasks, func(i, j int) bool {
+		return result.Tasks[i].Usage > result.Tasks[j].Usage
+	})
+	sort.SliceStable(result.Projects, func(i, j int) bool {
+		return result.Projects[i].Usage > result.Projects[j].Usage
+	})
+	return result, nil
+}
+
+// ProjectDuration returns the minutes spent on all tasks of the project. Running intervals are counted up to now.
+func ProjectDuration(db *gorm.DB, projectID uint, now time.Time) (int, error) {
+	var seconds float64
+	err := db.Raw(`
+		SELECT `+spentSeconds+`
+		FROM task_intervals i
+		JOIN tasks t ON t.id = i.task_id AND t.deleted_at IS NULL
+		WHERE i.deleted_at IS NULL AND t.project_id = @projectID
+	`, sql.Named("now", now), sql.Named("projectID", projectID)).Scan(&seconds).Error
+	return minutes(seconds), err
+}
+
+// BudgetMinutes converts a budget in hours to minutes.
+func BudgetMinutes(budgetHours float64) int {
+	return int(math.Round(budgetHours * 60))
+}
+
+// overrunLevel classifies an estimate that has reached the threshold.
+func overrunLevel(estimate models.Estimate) string {
+	if estimate.Exceeded {
+		return OverrunExceeded
+	}
+	return OverrunAtRisk
+}
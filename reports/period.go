@@ -0,0 +1,33 @@
+package reports
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Period is a half-open time window [From, To).
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// clippedSeconds is the SQL expression for the length in seconds of task interval "i" clipped to the period.
+// Open intervals are counted up to @now. It expects the named arguments from periodArgs.
+const clippedSeconds = `EXTRACT(EPOCH FROM LEAST(COALESCE(i.end_time, @now), @to) - GREATEST(i.start_time, @from))`
+
+// overlapsPeriod is the SQL condition selecting task intervals "i" that overlap the period.
+const overlapsPeriod = `i.deleted_at IS NULL AND i.start_time < @to AND COALESCE(i.end_time, @now) > @from`
+
+// periodArgs returns the named arguments used by clippedSeconds and overlapsPeriod.
+func periodArgs(period Period, now time.Time) []interface{} {
+	return []interface{}{
+		sql.Named("from", period.From),
+		sql.Named("to", period.To),
+		sql.Named("now", now),
+	}
+}
+
+// minutes converts seconds to whole minutes, the unit used for task durations.
+func minutes(seconds float64) int {
+	return int(seconds / 60)
+}
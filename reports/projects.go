@@ -0,0 +1,113 @@
+package reports
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Groupings supported by project reports.
+const (
+	GroupByProject = "project"
+	GroupByClient  = "client"
+)
+
+// ProjectTotal is a row of the project report. Tasks without a project are reported with nil IDs.
+type ProjectTotal struct {
+	ClientID         *uint   `json:"clientID"`              // ID of the client (nil for internal projects and unassigned tasks)
+	ClientName       string  `json:"clientName"`            // Name of the client
+	ProjectID        *uint   `json:"projectID,omitempty"`   // ID of the project (omitted when grouped by client)
+	ProjectName      string  `json:"projectName,omitempty"` // Name of the project (omitted when grouped by client)
+	Duration         int     `json:"duration"`              // Minutes spent within the period
+	Hours            float64 `json:"hours"`                 // Hours spent within the period, rounded to hundredths
+	TaskCount        int     `json:"taskCount"`             // Number of tasks worked on within the period
+	UserCount        int     `json:"userCount"`             // Number of users who worked within the period
+	BillableDuration int     `json:"billableDuration"`      // Billable minutes spent within the period
+	BillableHours    float64 `json:"billableHours"`         // Billable hours spent within the period, rounded to hundredths
+	Amount           float64 `json:"amount"`                // Amount charged for the period, rounded to hundredths
+}
+
+// ProjectReport is the time spent on projects or clients within a period.
+type ProjectReport struct {
+	StartDate             time.Time      `json:"startDate"`             // Start of the period (inclusive)
+	EndDate               time.Time      `json:"endDate"`               // End of the period (exclusive)
+	GroupBy               string         `json:"groupBy"`               // Grouping: project or client
+	Rows                  []ProjectTotal `json:"rows"`                  // Totals per project or client, most time first
+	TotalDuration         int            `json:"totalDuration"`         // Total minutes spent within the period
+	TotalHours            float64        `json:"totalHours"`            // Total hours spent within the period, rounded to hundredths
+	TotalBillableDuration int            `json:"totalBillableDuration"` // Total billable minutes spent within the period
+	TotalBillableHours    float64        `json:"totalBillableHours"`    // Total billable hours spent within the period, rounded to hundredths
+	TotalAmount           float64        `json:"totalAmount"`           // Total amount charged for the period, rounded to hundredths
+}
+
+// Projects sums the time spent within the period by project or by client.
+// Only tasks of the users selected by the userFilters scope are counted.
+func Projects(db *gorm.DB, period Period, groupBy string, filter TaskFilter, now time.Time, userFilters func(*gorm.DB) *gorm.DB) (ProjectReport, error) {
+	result := ProjectReport{StartDate: period.From, EndDate: period.To, GroupBy: groupBy, Rows: []ProjectTotal{}}
+
+	users := db.Table("users").Select("users.id").Where("users.deleted_at IS NULL").Scopes(userFilters)
+	filterSQL, filterArgs := filter.where()
+
+	columns := "c.id AS client_id, COALESCE(c.name, '') AS client_name"
+	if groupBy == GroupByProject {
+		columns += ", p.id AS project_id, COALESCE(p.name, '') AS project_name"
+	}
+
+	var rows []struct {
+		ClientID        *uint
+		ClientName      string
+		ProjectID       *uint
+		ProjectName     string
+		Seconds         float64
+		TaskCount       int
+		UserCount       int
+		BillableSeconds float64
+		Amount          float64
+	}
+	err := db.Table("task_intervals i").
+		Select(columns+", SUM("+clippedSeconds+") AS seconds, COUNT(DISTINCT t.id) AS task_count, COUNT(DISTINCT t.user_id) AS user_count, "+
+			"SUM("+billableSeconds(clippedSeconds)+") AS billable_seconds, SUM("+billableAmount(clippedSeconds)+") AS amount", periodArgs(period, now)...).
+		Joins("JOIN tasks t ON t.id = i.task_id AND t.deleted_at IS NULL").
+		Joins(intervalRate).
+		Joins("LEFT JOIN projects p ON p.id = t.project_id").
+		Joins("LEFT JOIN clients c ON c.id = p.client_id").
+		Where(overlapsPeriod+" AND "+filterSQL, append(periodArgs(period, now), filterArgs...)...).
+		Where("t.user_id IN (?)", users).
+		Group(columnsGroup(groupBy)).
+		Order("seconds DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return result, err
+	}
+
+	var totals timeTotals
+	for _, row := range rows {
+		result.Rows = append(result.Rows, ProjectTotal{
+			ClientID:         row.ClientID,
+			ClientName:       row.ClientName,
+			ProjectID:        row.ProjectID,
+			ProjectName:      row.ProjectName,
+			Duration:         minutes(row.Seconds),
+			Hours:            hours(row.Seconds),
+			TaskCount:        row.TaskCount,
+			UserCount:        row.UserCount,
+			BillableDuration: minutes(row.BillableSeconds),
+			BillableHours:    hours(row.BillableSeconds),
+			Amount:           money(row.Amount),
+		})
+		totals = totals.add(timeTotals{Seconds: row.Seconds, BillableSeconds: row.BillableSeconds, Amount: row.Amount})
+	}
+	result.TotalDuration, result.TotalHours = minutes(totals.Seconds), hours(totals.Seconds)
+	result.TotalBillableDuration, result.TotalBillableHours = minutes(totals.BillableSeconds), hours(totals.BillableSeconds)
+	result.TotalAmount = money(totals.Amount)
+
+	return result, nil
+}
+
+// columnsGroup returns the GROUP BY columns for the grouping.
+func columnsGroup(groupBy string) string {
+	if groupBy == GroupByProject {
+		return "c.id, c.name, p.id, p.name"
+	}
+	return "c.id, c.name"
+}
@@ -0,0 +1,47 @@
+package reports
+
+import "math"
+
+// intervalRate is the SQL join selecting as "rate" the hourly rate in effect at the start of task interval "i" of task "t".
+// Rates scoped to the project of the task win over rates without a project, and rates scoped to the user of the task
+// win over rates without a user. Tasks without an applicable rate get a NULL rate.
+const intervalRate = `LEFT JOIN LATERAL (
+	SELECT r.hourly_rate FROM rates r
+	WHERE r.deleted_at IS NULL AND r.effective_from <= i.start_time
+		AND (r.user_id = t.user_id OR r.user_id IS NULL)
+		AND (r.project_id = t.project_id OR r.project_id IS NULL)
+	ORDER BY r.project_id IS NULL, r.user_id IS NULL, r.effective_from DESC
+	LIMIT 1
+) rate ON TRUE`
+
+// billableSeconds returns the SQL expression counting the given seconds expression only for billable tasks "t".
+func billableSeconds(seconds string) string {
+	return `CASE WHEN t.billable THEN ` + seconds + ` ELSE 0 END`
+}
+
+// billableAmount returns the SQL expression for the amount charged for the given seconds expression of task "t".
+// It expects the intervalRate join.
+func billableAmount(seconds string) string {
+	return `CASE WHEN t.billable THEN (` + seconds + `) * COALESCE(rate.hourly_rate, 0) / 3600 ELSE 0 END`
+}
+
+// timeTotals accumulates tracked time and the amount charged for it.
+type timeTotals struct {
+	Seconds         float64 // Seconds spent
+	BillableSeconds float64 // Seconds spent on billable tasks
+	Amount          float64 // Amount charged for billable seconds
+}
+
+// add returns the sum of both totals.
+func (t timeTotals) add(other timeTotals) timeTotals {
+	return timeTotals{
+		Seconds:         t.Seconds + other.Seconds,
+		BillableSeconds: t.BillableSeconds + other.BillableSeconds,
+		Amount:          t.Amount + other.Amount,
+	}
+}
+
+// money rounds an amount to hundredths.
+func money(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,105 @@
+package reports
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TeamMember is a row of the team report.
+type TeamMember struct {
+	Rank                int     `json:"rank"`                // Position of the user by time spent, starting from 1
+	UserID              uint    `json:"userID"`              // ID of the user
+	Surname             string  `json:"surname"`             // Surname of the user
+	Name                string  `json:"name"`                // Name of the user
+	Patronymic          string  `json:"patronymic"`          // Patronymic (middle name) of the user
+	Duration            int     `json:"duration"`            // Minutes spent within the period
+	Hours               float64 `json:"hours"`               // Hours spent within the period, rounded to hundredths
+	TaskCount           int     `json:"taskCount"`           // Number of tasks worked on within the period
+	AverageTaskDuration int     `json:"averageTaskDuration"` // Average minutes per task within the period
+	BillableDuration    int     `json:"billableDuration"`    // Billable minutes spent within the period
+	BillableHours       float64 `json:"billableHours"`       // Billable hours spent within the period, rounded to hundredths
+	Amount              float64 `json:"amount"`              // Amount charged for the period, rounded to hundredths
+}
+
+// TeamReport is the time spent by a group of users within a period.
+type TeamReport struct {
+	StartDate             time.Time    `json:"startDate"`             // Start of the period (inclusive)
+	EndDate               time.Time    `json:"endDate"`               // End of the period (exclusive)
+	Users                 []TeamMember `json:"users"`                 // Users ranked by time spent, most first
+	TotalDuration         int          `json:"totalDuration"`         // Total minutes spent by all users within the period
+	TotalHours            float64      `json:"totalHours"`            // Total hours spent by all users, rounded to hundredths
+	TaskCount             int          `json:"taskCount"`             // Total number of tasks worked on within the period
+	TotalBillableDuration int          `json:"totalBillableDuration"` // Total billable minutes spent by all users within the period
+	TotalBillableHours    float64      `json:"totalBillableHours"`    // Total billable hours spent by all users, rounded to hundredths
+	TotalAmount           float64      `json:"totalAmount"`           // Total amount charged for the period, rounded to hundredths
+}
+
+// Team ranks the users selected by the userFilters scope by the time they spent within the period.
+// Users without tracked time are included at the end of the ranking.
+func Team(db *gorm.DB, period Period, filter TaskFilter, now time.Time, userFilters func(*gorm.DB) *gorm.DB) (TeamReport, error) {
+	result := TeamReport{StartDate: period.From, EndDate: period.To, Users: []TeamMember{}}
+
+	filterSQL, filterArgs := filter.where()
+	taskTotals := db.Raw(`
+		SELECT t.user_id, i.task_id, SUM(`+clippedSeconds+`) AS seconds,
+			SUM(`+billableSeconds(clippedSeconds)+`) AS billable_seconds, SUM(`+billableAmount(clippedSeconds)+`) AS amount
+		FROM task_intervals i
+		JOIN tasks t ON t.id = i.task_id AND t.deleted_at IS NULL
+		`+intervalRate+`
+		WHERE `+overlapsPeriod+` AND `+filterSQL+`
+		GROUP BY t.user_id, i.task_id
+	`, append(periodArgs(period, now), filterArgs...)...)
+
+	var rows []struct {
+		UserID          uint
+		Surname         string
+		Name            string
+		Patronymic      string
+		Seconds         float64
+		TaskCount       int
+		BillableSeconds float64
+		Amount          float64
+	}
+	err := db.Table("users").
+		Select("users.id AS user_id, users.surname, users.name, users.patronymic, COALESCE(SUM(totals.seconds), 0) AS seconds, COUNT(totals.task_id) AS task_count, "+
+			"COALESCE(SUM(totals.billable_seconds), 0) AS billable_seconds, COALESCE(SUM(totals.amount), 0) AS amount").
+		Joins("LEFT JOIN (?) totals ON totals.user_id = users.id", taskTotals).
+		Where("users.deleted_at IS NULL").
+		Scopes(userFilters).
+		Group("users.id").
+		Order("seconds DESC, users.id").
+		Scan(&rows).Error
+	if err != nil {
+		return result, err
+	}
+
+	var totals timeTotals
+	for i, row := range rows {
+		member := TeamMember{
+			Rank:             i + 1,
+			UserID:           row.UserID,
+			Surname:          row.Surname,
+			Name:             row.Name,
+			Patronymic:       row.Patronymic,
+			Duration:         minutes(row.Seconds),
+			Hours:            hours(row.Seconds),
+			TaskCount:        row.TaskCount,
+			BillableDuration: minutes(row.BillableSeconds),
+			BillableHours:    hours(row.BillableSeconds),
+			Amount:           money(row.Amount),
+		}
+		if row.TaskCount > 0 {
+			member.AverageTaskDuration = minutes(row.Seconds / float64(row.TaskCount))
+		}
+
+		totals = totals.add(timeTotals{Seconds: row.Seconds, BillableSeconds: row.BillableSeconds, Amount: row.Amount})
+		result.TaskCount += row.TaskCount
+		result.Users = append(result.Users, member)
+	}
+	result.TotalDuration, result.TotalHours = minutes(totals.Seconds), hours(totals.Seconds)
+	result.TotalBillableDuration, result.TotalBillableHours = minutes(totals.BillableSeconds), hours(totals.BillableSeconds)
+	result.TotalAmount = money(totals.Amount)
+
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package reports
+
+import (
+	"database/sql"
+	"time"
+	"time-tracker-go/models"
+
+	"gorm.io/gorm"
+)
+
+// TimeEntry is a task together with the time spent on it within a period.
+type TimeEntry struct {
+	models.Task
+	PeriodDuration int     `json:"periodDuration"` // Minutes spent on the task within the period
+	PeriodAmount   float64 `json:"periodAmount"`   // Amount charged for the task within the period (0 if not billable)
+}
+
+// TimeEntries is the time a user spent on tasks within a period.
+type TimeEntries struct {
+	UserID                uint        `json:"userID"`                // ID of the user
+	StartDate             time.Time   `json:"startDate"`             // Start of the period (inclusive)
+	EndDate               time.Time   `json:"endDate"`               // End of the period (exclusive)
+	Entries               []TimeEntry `json:"entries"`               // Tasks worked on within the period, longest first
+	TotalDuration         int         `json:"totalDuration"`         // Total minutes spent within the period
+	TotalBillableDuration int         `json:"totalBillableDuration"` // Total billable minutes spent within the period
+	TotalAmount           float64     `json:"totalAmount"`           // Total amount charged for the period, rounded to hundredths
+}
+
+// UserTimeEntries returns the tasks of the user whose intervals overlap the period.
+// Intervals are clipped to the period and running intervals are counted up to now.
+// Billable time is charged at the rate in effect at the start of each interval.
+func UserTimeEntries(db *gorm.DB, userID uint, period Period, filter TaskFilter, now time.Time) (TimeEntries, error) {
+	result := TimeEntries{UserID: userID, StartDate: period.From, EndDate: period.To, Entries: []TimeEntry{}}
+
+	var rows []struct {
+		TaskID          uint
+		Seconds         float64
+		BillableSeconds float64
+		Amount          float64
+	}
+	filterSQL, filterArgs := filter.where()
+	args := append(append(periodArgs(period, now), filterArgs...), sql.Named("userID", userID))
+	err := db.Raw(`
+		SELECT i.task_id, SUM(`+clippedSeconds+`) AS seconds,
+			SUM(`+billableSeconds(clippedSeconds)+`) AS billable_seconds, SUM(`+billableAmount(clippedSeconds)+`) AS amount
+		FROM task_intervals i
+		JOIN tasks t ON t.id = i.task_id AND t.deleted_at IS NULL
+		`+intervalRate+`
+		WHERE t.user_id = @userID AND `+overlapsPeriod+` AND `+filterSQL+`
+		GROUP BY i.task_id
+		ORDER BY seconds DESC, i.task_id
+	`, args...).Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return result, err
+	}
+
+	taskIDs := make([]uint, len(rows))
+	for i, row := range rows {
+		taskIDs[i] = row.TaskID
+	}
+
+	var tasks []models.Task
+	err = db.Preload("Intervals", func(db *gorm.DB) *gorm.DB {
+		return db.Order("start_time")
+	}).Preload("Project.Client").Preload("Tags").Where("id IN ?", taskIDs).Find(&tasks).Error
+	if err != nil {
+		return result, err
+	}
+
+	tasksByID := make(map[uint]models.Task, len(tasks))
+	for _, task := range tasks {
+		tasksByID[task.ID] = task
+	}
+
+	var totalSeconds, totalBillableSeconds, totalAmount float64
+	for _, row := range rows {
+		totalSeconds += row.Seconds
+		totalBillableSeconds += row.BillableSeconds
+		totalAmount += row.Amount
+		result.Entries = append(result.Entries, TimeEntry{Task: tasksByID[row.TaskID], PeriodDuration: minutes(row.Seconds), PeriodAmount: money(row.Amount)})
+	}
+	result.TotalDuration, result.TotalBillableDuration, result.TotalAmount = minutes(totalSeconds), minutes(totalBillableSeconds), money(totalAmount)
+
+	return result, nil
+}
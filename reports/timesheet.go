@@ -0,0 +1,211 @@
+package reports
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Groupings supported by timesheet reports. Weeks are ISO weeks starting on Monday.
+const (
+	GroupByDay   = "day"
+	GroupByWeek  = "week"
+	GroupByMonth = "month"
+)
+
+// IsValidGroupBy reports whether the grouping is supported by timesheet reports.
+func IsValidGroupBy(groupBy string) bool {
+	return groupBy == GroupByDay || groupBy == GroupByWeek || groupBy == GroupByMonth
+}
+
+// TaskDuration is the time spent on a single task.
+type TaskDuration struct {
+	TaskID      uint    `json:"taskID"`      // ID of the task
+	ProjectID   *uint   `json:"projectID"`   // ID of the project of the task (nil if unassigned)
+	Description string  `json:"description"` // Description of the task
+	Billable    bool    `json:"billable"`    // Whether time spent on the task is billed
+	Duration    int     `json:"duration"`    // Minutes spent on the task
+	Hours       float64 `json:"hours"`       // Hours spent on the task, rounded to hundredths
+	Amount      float64 `json:"amount"`      // Amount charged for the task, rounded to hundredths
+}
+
+// TimesheetBucket is the time spent within one day, week or month.
+type TimesheetBucket struct {
+	Start            time.Time      `json:"start"`            // Start of the bucket
+	End              time.Time      `json:"end"`              // End of the bucket (exclusive)
+	Duration         int            `json:"duration"`         // Minutes spent within the bucket
+	Hours            float64        `json:"hours"`            // Hours spent within the bucket, rounded to hundredths
+	BillableDuration int            `json:"billableDuration"` // Billable minutes spent within the bucket
+	BillableHours    float64        `json:"billableHours"`    // Billable hours spent within the bucket, rounded to hundredths
+	Amount           float64        `json:"amount"`           // Amount charged for the bucket, rounded to hundredths
+	Tasks            []TaskDuration `json:"tasks"`            // Per-task breakdown, longest first
+}
+
+// Timesheet is the time a user spent on tasks within a period, grouped by day, week or month.
+type Timesheet struct {
+	UserID                uint              `json:"userID"`                // ID of the user
+	StartDate             time.Time         `json:"startDate"`             // Start of the period (inclusive)
+	EndDate               time.Time         `json:"endDate"`               // End of the period (exclusive)
+	GroupBy               string            `json:"groupBy"`               // Bucket size: day, week or month
+	Buckets               []TimesheetBucket `json:"buckets"`               // Buckets covering the period, in chronological order
+	Tasks                 []TaskDuration    `json:"tasks"`                 // Per-task totals for the whole period, longest first
+	TotalDuration         int               `json:"totalDuration"`         // Total minutes spent within the period
+	TotalHours            float64           `json:"totalHours"`            // Total hours spent within the period, rounded to hundredths
+	TotalBillableDuration int               `json:"totalBillableDuration"` // Total billable minutes spent within the period
+	TotalBillableHours    float64           `json:"totalBillableHours"`    // Total billable hours spent within the period, rounded to hundredths
+	TotalAmount           float64           `json:"totalAmount"`           // Total amount charged for the period, rounded to hundredths
+}
+
+// UserTimesheet aggregates the time the user spent within the period into buckets of the given size.
+// Intervals are split between the buckets they overlap and running intervals are counted up to now.
+// Bucket boundaries follow the time zone of the database session.
+// Billable time is charged at the rate in effect at the start of each interval.
+func UserTimesheet(db *gorm.DB, userID uint, period Period, groupBy string, filter TaskFilter, now time.Time) (Timesheet, error) {
+	timesheets, err := UserTimesheets(db, []uint{userID}, period, groupBy, filter, now)
+	if err != nil {
+		return newTimesheet(userID, period, groupBy), err
+	}
+	return timesheets[0], nil
+}
+
+// UserTimesheets builds the timesheets of several users like UserTimesheet, with a single query.
+// The timesheets are returned in the order of the user IDs.
+func UserTimesheets(db *gorm.DB, userIDs []uint, period Period, groupBy string, filter TaskFilter, now time.Time) ([]Timesheet, error) {
+	if !IsValidGroupBy(groupBy) {
+		return nil, fmt.Errorf("unsupported grouping %q", groupBy)
+	}
+	result := make([]Timesheet, len(userIDs))
+	index := make(map[uint]int, len(userIDs))
+	for i, userID := range userIDs {
+		result[i] = newTimesheet(userID, period, groupBy)
+		index[userID] = i
+	}
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		UserID          *uint
+		BucketStart     time.Time
+		BucketEnd       time.Time
+		TaskID          *uint
+		ProjectID       *uint
+		Description     *string
+		Billable        *bool
+		Seconds         float64
+		BillableSeconds float64
+		Amount          float64
+	}
+	filterSQL, filterArgs := filter.where()
+	args := append(append(periodArgs(period, now), filterArgs...), sql.Named("userIDs", userIDs))
+	bucketSeconds := `EXTRACT(EPOCH FROM
+		LEAST(COALESCE(i.end_time, @now), @to, b.bucket_end) - GREATEST(i.start_time, @from, b.bucket_start)
+	)`
+	// groupBy is validated above, so it is safe to inline it into the query.
+	// Buckets in which none of the users spent time are returned once, without a user.
+	err := db.Raw(`
+		WITH buckets AS (
+			SELECT b AS bucket_start, b + INTERVAL '1 `+groupBy+`' AS bucket_end
+			FROM generate_series(date_trunc('`+groupBy+`', CAST(@from AS timestamptz)), CAST(@to AS timestamptz), INTERVAL '1 `+groupBy+`') AS b
+			WHERE b < @to
+		)
+		SELECT t.user_id, b.bucket_start, b.bucket_end, t.id AS task_id, t.project_id, t.description, t.billable,
+			COALESCE(SUM(`+bucketSeconds+`), 0) AS seconds,
+			COALESCE(SUM(`+billableSeconds(bucketSeconds)+`), 0) AS billable_seconds,
+			COALESCE(SUM(`+billableAmount(bucketSeconds)+`), 0) AS amount
+		FROM buckets b
+		LEFT JOIN (
+			task_intervals i JOIN tasks t ON t.id = i.task_id AND t.deleted_at IS NULL AND t.user_id IN @userIDs AND `+filterSQL+`
+		) ON `+overlapsPeriod+` AND i.start_time < b.bucket_end AND COALESCE(i.end_time, @now) > b.bucket_start
+		`+intervalRate+`
+		GROUP BY t.user_id, b.bucket_start, b.bucket_end, t.id, t.project_id, t.description, t.billable
+		ORDER BY b.bucket_start, seconds DESC, t.id
+	`, args...).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]timesheetTotals, len(result))
+	for _, row := range rows {
+		if buckets := result[0].Buckets; len(buckets) == 0 || !buckets[len(buckets)-1].Start.Equal(row.BucketStart) {
+			for i := range result {
+				totals[i].bucket = timeTotals{}
+				result[i].Buckets = append(result[i].Buckets, TimesheetBucket{Start: row.BucketStart, End: row.BucketEnd, Tasks: []TaskDuration{}})
+			}
+		}
+		if row.TaskID == nil {
+			continue
+		}
+
+		i := index[*row.UserID]
+		task := TaskDuration{TaskID: *row.TaskID, ProjectID: row.ProjectID, Description: *row.Description, Billable: *row.Billable}
+		totals[i].add(&result[i], task, timeTotals{Seconds: row.Seconds, BillableSeconds: row.BillableSeconds, Amount: row.Amount})
+	}
+
+	for i := range result {
+		totals[i].finish(&result[i])
+	}
+	return result, nil
+}
+
+// newTimesheet returns an empty timesheet of the user.
+func newTimesheet(userID uint, period Period, groupBy string) Timesheet {
+	return Timesheet{UserID: userID, StartDate: period.From, EndDate: period.To, GroupBy: groupBy, Buckets: []TimesheetBucket{}, Tasks: []TaskDuration{}}
+}
+
+// timesheetTotals accumulates the time of a timesheet while its rows are read.
+type timesheetTotals struct {
+	bucket, period timeTotals
+	tasks          map[uint]timeTotals
+	details        map[uint]TaskDuration
+}
+
+// add adds the time spent on a task to the last bucket of the timesheet.
+func (t *timesheetTotals) add(timesheet *Timesheet, task TaskDuration, rowTotals timeTotals) {
+	if t.tasks == nil {
+		t.tasks, t.details = make(map[uint]timeTotals), make(map[uint]TaskDuration)
+	}
+	t.bucket = t.bucket.add(rowTotals)
+	t.period = t.period.add(rowTotals)
+
+	bucket := &timesheet.Buckets[len(timesheet.Buckets)-1]
+	bucket.Duration, bucket.Hours = minutes(t.bucket.Seconds), hours(t.bucket.Seconds)
+	bucket.BillableDuration, bucket.BillableHours = minutes(t.bucket.BillableSeconds), hours(t.bucket.BillableSeconds)
+	bucket.Amount = money(t.bucket.Amount)
+	bucket.Tasks = append(bucket.Tasks, task.withTotals(rowTotals))
+
+	t.tasks[task.TaskID] = t.tasks[task.TaskID].add(rowTotals)
+	t.details[task.TaskID] = task
+}
+
+// finish sets the per-task and period totals of the timesheet.
+func (t *timesheetTotals) finish(timesheet *Timesheet) {
+	for taskID, totals := range t.tasks {
+		timesheet.Tasks = append(timesheet.Tasks, t.details[taskID].withTotals(totals))
+	}
+	sort.Slice(timesheet.Tasks, func(i, j int) bool {
+		if timesheet.Tasks[i].Duration != timesheet.Tasks[j].Duration {
+			return timesheet.Tasks[i].Duration > timesheet.Tasks[j].Duration
+		}
+		return timesheet.Tasks[i].TaskID < timesheet.Tasks[j].TaskID
+	})
+
+	timesheet.TotalDuration, timesheet.TotalHours = minutes(t.period.Seconds), hours(t.period.Seconds)
+	timesheet.TotalBillableDuration, timesheet.TotalBillableHours = minutes(t.period.BillableSeconds), hours(t.period.BillableSeconds)
+	timesheet.TotalAmount = money(t.period.Amount)
+}
+
+// withTotals returns a copy of the task duration set to the given totals.
+func (d TaskDuration) withTotals(totals timeTotals) TaskDuration {
+	d.Duration, d.Hours, d.Amount = minutes(totals.Seconds), hours(totals.Seconds), money(totals.Amount)
+	return d
+}
+
+// hours converts seconds to hours rounded to hundredths.
+func hours(seconds float64) float64 {
+	return math.Round(seconds/36) / 100
+}
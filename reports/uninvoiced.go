@@ -0,0 +1,53 @@
+package reports
+
+import (
+	"database/sql"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// BillableInterval is a closed interval of a billable task together with the rate it is charged at.
+type BillableInterval struct {
+	IntervalID  uint    // ID of the interval
+	TaskID      uint    // ID of the task
+	ProjectID   *uint   // ID of the project of the task (nil if unassigned)
+	ProjectName string  // Name of the project of the task
+	Description string  // Description of the task
+	HourlyRate  float64 // Rate in effect at the start of the interval (0 if none applies)
+	Seconds     float64 // Length of the interval
+}
+
+// UninvoicedIntervals returns the closed intervals of billable tasks that start within the period
+// and have not been invoiced yet, in chronological order.
+// When set, clientID restricts them to tasks of projects of the client and userID to tasks of the user.
+func UninvoicedIntervals(db *gorm.DB, period Period, clientID, userID *uint) ([]BillableInterval, error) {
+	conditions := []string{
+		"i.deleted_at IS NULL", "i.end_time IS NOT NULL", "i.invoice_id IS NULL",
+		"i.start_time >= @from", "i.start_time < @to", "t.billable",
+	}
+	args := []interface{}{sql.Named("from", period.From), sql.Named("to", period.To)}
+
+	if clientID != nil {
+		conditions = append(conditions, "p.client_id = @clientID")
+		args = append(args, sql.Named("clientID", *clientID))
+	}
+	if userID != nil {
+		conditions = append(conditions, "t.user_id = @userID")
+		args = append(args, sql.Named("userID", *userID))
+	}
+
+	var intervals []BillableInterval
+	err := db.Raw(`
+		SELECT i.id AS interval_id, t.id AS task_id, t.project_id, COALESCE(p.name, '') AS project_name, t.description,
+			COALESCE(rate.hourly_rate, 0) AS hourly_rate, EXTRACT(EPOCH FROM i.end_time - i.start_time) AS seconds
+		FROM task_intervals i
+		JOIN tasks t ON t.id = i.task_id AND t.deleted_at IS NULL
+		LEFT JOIN projects p ON p.id = t.project_id
+		`+intervalRate+`
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY i.start_time, i.id
+	`, args...).Scan(&intervals).Error
+
+	return intervals, err
+}
@@ -6,6 +6,10 @@ import (
 	"time-tracker-go/api"
 	"time-tracker-go/config"
 	"time-tracker-go/controllers"
+	"time-tracker-go/graphqlapi"
+	"time-tracker-go/live"
+	"time-tracker-go/services"
+	"time-tracker-go/webhooks"
 
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -45,6 +49,45 @@ import (
 // Responses:
 //   200: timeEntriesResponse
 
+// Swagger:Route GET /users/{id}/tasks getTasksForUser
+// Get tasks of a user.
+// Parameters:
+//   id path int true "User ID"
+// Responses:
+//   200: tasksResponse
+
+// Swagger:Route GET /users/{id}/tasks/{taskID} getTaskForUser
+// Get a task of a user.
+// Parameters:
+//   id path int true "User ID"
+//   taskID path int true "Task ID"
+// Responses:
+//   200: taskResponse
+
+// Swagger:Route PATCH /users/{id}/tasks/{taskID} updateTaskForUser
+// Update a task of a user.
+// Parameters:
+//   id path int true "User ID"
+//   taskID path int true "Task ID"
+// Responses:
+//   200: taskResponse
+
+// Swagger:Route DELETE /users/{id}/tasks/{taskID} deleteTaskForUser
+// Delete a task of a user.
+// Parameters:
+//   id path int true "User ID"
+//   taskID path int true "Task ID"
+// Responses:
+//   200: messageResponse
+
+// Swagger:Route PUT /users/{id}/tasks/{taskID}/restore restoreTaskForUser
+// Restore a deleted task of a user.
+// Parameters:
+//   id path int true "User ID"
+//   taskID path int true "Task ID"
+// Responses:
+//   200: taskResponse
+
 // Swagger:Route POST /users/{id}/tasks addTaskForUser
 // Add a task for a user.
 // Parameters:
@@ -60,6 +103,22 @@ import (
 // Responses:
 //   200: taskResponse
 
+// Swagger:Route PUT /users/{id}/tasks/{taskID}/pause pauseTaskForUser
+// Pause a running task for a user.
+// Parameters:
+//   id path int true "User ID"
+//   taskID path int true "Task ID"
+// Responses:
+//   200: taskResponse
+
+// Swagger:Route PUT /users/{id}/tasks/{taskID}/resume resumeTaskForUser
+// Resume a paused task for a user.
+// Parameters:
+//   id path int true "User ID"
+//   taskID path int true "Task ID"
+// Responses:
+//   200: taskResponse
+
 // Swagger:Route PUT /users/{id}/tasks/{taskID}/end endTaskForUser
 // End a task for a user.
 // Parameters:
@@ -68,11 +127,348 @@ import (
 // Responses:
 //   200: taskResponse
 
-func SetupRoutes(db *gorm.DB, cfg config.Config) *mux.Router {
+// Swagger:Route PUT /users/{id}/tasks/{taskID}/cancel cancelTaskForUser
+// Cancel a task for a user.
+// Parameters:
+//   id path int true "User ID"
+//   taskID path int true "Task ID"
+// Responses:
+//   200: taskResponse
+
+// Swagger:Route GET /users/{id}/reports/timesheet getUserTimesheet
+// Get a timesheet of a user grouped by day, week or month.
+// Parameters:
+//   id path int true "User ID"
+// Responses:
+//   200: timesheetResponse
+
+// Swagger:Route GET /reports/team getTeamReport
+// Get a report ranking users by time spent.
+// Responses:
+//   200: teamReportResponse
+
+// Swagger:Route GET /reports/projects getProjectReport
+// Get a report of time spent by project or client.
+// Responses:
+//   200: projectReportResponse
+
+// Swagger:Route GET /clients getClients
+// Get a list of clients.
+// Responses:
+//   200: clientsResponse
+
+// Swagger:Route POST /clients addClient
+// Add a new client.
+// Responses:
+//   201: clientResponse
+
+// Swagger:Route GET /clients/{clientID} getClient
+// Get a client by ID.
+// Parameters:
+//   clientID path int true "Client ID"
+// Responses:
+//   200: clientResponse
+
+// Swagger:Route PUT /clients/{clientID} updateClient
+// Update a client by ID.
+// Parameters:
+//   clientID path int true "Client ID"
+// Responses:
+//   200: clientResponse
+
+// Swagger:Route DELETE /clients/{clientID} deleteClient
+// Delete a client by ID.
+// Parameters:
+//   clientID path int true "Client ID"
+// Responses:
+//   200: messageResponse
+
+// Swagger:Route GET /projects getProjects
+// Get a list of projects.
+// Responses:
+//   200: projectsResponse
+
+// Swagger:Route POST /projects addProject
+// Add a new project.
+// Responses:
+//   201: projectResponse
+
+// Swagger:Route GET /projects/{projectID} getProject
+// Get a project by ID.
+// Parameters:
+//   projectID path int true "Project ID"
+// Responses:
+//   200: projectResponse
+
+// Swagger:Route PUT /projects/{projectID} updateProject
+// Update a project by ID.
+// Parameters:
+//   projectID path int true "Project ID"
+// Responses:
+//   200: projectResponse
+
+// Swagger:Route DELETE /projects/{projectID} deleteProject
+// Delete a project by ID.
+// Parameters:
+//   projectID path int true "Project ID"
+// Responses:
+//   200: messageResponse
+
+// Swagger:Route GET /tags getTags
+// Get the tag catalogue.
+// Responses:
+//   200: tagsResponse
+
+// Swagger:Route POST /tags addTag
+// Add a new tag.
+// Responses:
+//   201: tagResponse
+
+// Swagger:Route PUT /tags/{tagID} updateTag
+// Update a tag by ID.
+// Parameters:
+//   tagID path int true "Tag ID"
+// Responses:
+//   200: tagResponse
+
+// Swagger:Route DELETE /tags/{tagID} deleteTag
+// Delete a tag by ID.
+// Parameters:
+//   tagID path int true "Tag ID"
+// Responses:
+//   200: messageResponse
+
+// Swagger:Route GET /rates getRates
+// Get the hourly rate history.
+// Responses:
+//   200: ratesResponse
+
+// Swagger:Route POST /rates addRate
+// Add an hourly rate.
+// Responses:
+//   201: rateResponse
+
+// Swagger:Route DELETE /rates/{rateID} deleteRate
+// Delete an hourly rate by ID.
+// Parameters:
+//   rateID path int true "Rate ID"
+// Responses:
+//   200: messageResponse
+
+// Swagger:Route GET /invoices getInvoices
+// Get a list of invoices.
+// Responses:
+//   200: invoicesResponse
+
+// Swagger:Route POST /invoices addInvoice
+// Generate a draft invoice from billable time.
+// Responses:
+//   201: invoiceResponse
+
+// Swagger:Route GET /invoices/{invoiceID} getInvoice
+// Get an invoice as JSON, HTML or PDF.
+// Parameters:
+//   invoiceID path int true "Invoice ID"
+// Responses:
+//   200: invoiceResponse
+
+// Swagger:Route PUT /invoices/{invoiceID}/send sendInvoice
+// Mark an invoice as sent.
+// Parameters:
+//   invoiceID path int true "Invoice ID"
+// Responses:
+//   200: invoiceResponse
+
+// Swagger:Route PUT /invoices/{invoiceID}/pay payInvoice
+// Mark an invoice as paid.
+// Parameters:
+//   invoiceID path int true "Invoice ID"
+// Responses:
+//   200: invoiceResponse
+
+// Swagger:Route DELETE /invoices/{invoiceID} deleteInvoice
+// Delete a draft invoice.
+// Parameters:
+//   invoiceID path int true "Invoice ID"
+// Responses:
+//   200: messageResponse
+
+// Swagger:Route GET /users/{id}/calendar.ics getUserCalendar
+// Get the work intervals of a user as an iCalendar feed.
+// Parameters:
+//   id path int true "User ID"
+// Responses:
+//   200: calendarResponse
+
+// Swagger:Route POST /users/{id}/calendar/import importUserCalendar
+// Import events of an iCalendar file as tasks of a user.
+// Parameters:
+//   id path int true "User ID"
+// Responses:
+//   200: calendarImportResponse
+//   201: calendarImportResponse
+
+// Swagger:Route POST /imports/timesheets importTimesheet
+// Import a CSV timesheet in one transaction.
+// Responses:
+//   200: timesheetImportResponse
+//   201: timesheetImportResponse
+//   422: timesheetImportResponse
+
+// Swagger:Route POST /imports/toggl importToggl
+// Import a Toggl Track export.
+// Responses:
+//   200: trackerImportResponse
+//   201: trackerImportResponse
+
+// Swagger:Route POST /imports/clockify importClockify
+// Import a Clockify export.
+// Responses:
+//   200: trackerImportResponse
+//   201: trackerImportResponse
+
+// Swagger:Route GET /timesheets getTimesheets
+// Get submitted, approved and rejected weekly timesheets.
+// Responses:
+//   200: timesheetsResponse
+
+// Swagger:Route GET /users/{id}/timesheets/{week} getTimesheet
+// Get the timesheet of a user for a week.
+// Parameters:
+//   id path int true "User ID"
+//   week path string true "Monday the week starts on"
+// Responses:
+//   200: timesheetResponse
+
+// Swagger:Route PUT /users/{id}/timesheets/{week}/submit submitTimesheet
+// Submit a week of a user for approval.
+// Parameters:
+//   id path int true "User ID"
+//   week path string true "Monday the week starts on"
+// Responses:
+//   200: timesheetApprovalResponse
+
+// Swagger:Route PUT /users/{id}/timesheets/{week}/approve approveTimesheet
+// Approve a submitted week and lock its time.
+// Parameters:
+//   id path int true "User ID"
+//   week path string true "Monday the week starts on"
+// Responses:
+//   200: timesheetApprovalResponse
+
+// Swagger:Route PUT /users/{id}/timesheets/{week}/reject rejectTimesheet
+// Reject a submitted week with a comment.
+// Parameters:
+//   id path int true "User ID"
+//   week path string true "Monday the week starts on"
+// Responses:
+//   200: timesheetApprovalResponse
+
+// Swagger:Route GET /reports/overruns getOverrunReport
+// Get tasks and projects over or near their estimates and budgets.
+// Responses:
+//   200: overrunReportResponse
+
+// Swagger:Route GET /budget-events getBudgetEvents
+// Get events recorded when tasks exceeded their estimates or projects their budgets.
+// Responses:
+//   200: budgetEventsResponse
+
+// Swagger:Route PUT /budget-events/{eventID}/acknowledge acknowledgeBudgetEvent
+// Acknowledge a budget event.
+// Parameters:
+//   eventID path int true "Budget event ID"
+// Responses:
+//   200: budgetEventResponse
+
+// Swagger:Route GET /webhooks getWebhooks
+// Get webhook subscriptions.
+// Responses:
+//   200: webhooksResponse
+
+// Swagger:Route POST /webhooks addWebhook
+// Subscribe a URL to events.
+// Responses:
+//   201: webhookResponse
+
+// Swagger:Route GET /webhooks/{webhookID} getWebhook
+// Get a webhook by ID.
+// Parameters:
+//   webhookID path int true "Webhook ID"
+// Responses:
+//   200: webhookResponse
+
+// Swagger:Route PUT /webhooks/{webhookID} updateWebhook
+// Update a webhook by ID.
+// Parameters:
+//   webhookID path int true "Webhook ID"
+// Responses:
+//   200: webhookResponse
+
+// Swagger:Route POST /webhooks/{webhookID}/secret rotateWebhookSecret
+// Replace the signing secret of a webhook.
+// Parameters:
+//   webhookID path int true "Webhook ID"
+// Responses:
+//   200: webhookSecretResponse
+
+// Swagger:Route DELETE /webhooks/{webhookID} deleteWebhook
+// Delete a webhook by ID.
+// Parameters:
+//   webhookID path int true "Webhook ID"
+// Responses:
+//   200: messageResponse
+
+// Swagger:Route GET /webhooks/{webhookID}/deliveries getWebhookDeliveries
+// Get the delivery log of a webhook.
+// Parameters:
+//   webhookID path int true "Webhook ID"
+// Responses:
+//   200: webhookDeliveriesResponse
+
+// Swagger:Route POST /webhooks/{webhookID}/deliveries/{deliveryID}/redeliver redeliverWebhookDelivery
+// Queue a delivery of a webhook again.
+// Parameters:
+//   webhookID path int true "Webhook ID"
+//   deliveryID path int true "Delivery ID"
+// Responses:
+//   202: webhookDeliveryResponse
+
+// Swagger:Route GET /stream streamTeam
+// Stream timer changes of all users as Server-Sent Events.
+// Responses:
+//   200: eventStreamResponse
+
+// Swagger:Route GET /users/{id}/stream streamUser
+// Stream timer changes of a user as Server-Sent Events.
+// Parameters:
+//   id path int true "User ID"
+// Responses:
+//   200: eventStreamResponse
+
+// Swagger:Route POST /graphql graphQLQuery
+// Execute a GraphQL query or mutation.
+// Responses:
+//   200: graphQLResponse
+
+func SetupRoutes(db *gorm.DB, cfg config.Config, users *services.Users, tasks *services.Tasks, dispatcher *webhooks.Dispatcher, broker *live.Broker) *mux.Router {
 	router := mux.NewRouter()
 
-	userController := controllers.NewUserController(db, cfg)
-	taskController := controllers.NewTaskController(db)
+	userController := controllers.NewUserController(db, cfg, users)
+	taskController := controllers.NewTaskController(db, tasks)
+	reportController := controllers.NewReportController(db, cfg)
+	clientController := controllers.NewClientController(db)
+	projectController := controllers.NewProjectController(db)
+	tagController := controllers.NewTagController(db)
+	rateController := controllers.NewRateController(db)
+	invoiceController := controllers.NewInvoiceController(db, cfg)
+	calendarController := controllers.NewCalendarController(db)
+	importController := controllers.NewImportController(db)
+	timesheetController := controllers.NewTimesheetController(db)
+	budgetEventController := controllers.NewBudgetEventController(db)
+	webhookController := controllers.NewWebhookController(db, dispatcher)
+	streamController := controllers.NewStreamController(db, tasks, broker)
+	graphQLController := controllers.NewGraphQLController(graphqlapi.NewSchema(db, users, tasks))
 
 	// Routes for user management
 	router.HandleFunc("/users", logRequest(userController.GetUsers)).Methods("GET")
@@ -82,9 +478,91 @@ func SetupRoutes(db *gorm.DB, cfg config.Config) *mux.Router {
 
 	// Routes for user task management
 	router.HandleFunc("/users/{id}/time-entries", logRequest(taskController.GetTimeEntriesByUserAndPeriod)).Methods("GET")
+	router.HandleFunc("/users/{id}/tasks", logRequest(taskController.GetTasksForUser)).Methods("GET")
 	router.HandleFunc("/users/{id}/tasks", logRequest(taskController.AddTaskForUser)).Methods("POST")
+	router.HandleFunc("/users/{id}/tasks/{taskID}", logRequest(taskController.GetTaskForUser)).Methods("GET")
+	router.HandleFunc("/users/{id}/tasks/{taskID}", logRequest(taskController.UpdateTaskForUser)).Methods("PATCH")
+	router.HandleFunc("/users/{id}/tasks/{taskID}", logRequest(taskController.DeleteTaskForUser)).Methods("DELETE")
+	router.HandleFunc("/users/{id}/tasks/{taskID}/restore", logRequest(taskController.RestoreTaskForUser)).Methods("PUT")
 	router.HandleFunc("/users/{id}/tasks/{taskID}/start", logRequest(taskController.StartTaskForUser)).Methods("PUT")
+	router.HandleFunc("/users/{id}/tasks/{taskID}/pause", logRequest(taskController.PauseTaskForUser)).Methods("PUT")
+	router.HandleFunc("/users/{id}/tasks/{taskID}/resume", logRequest(taskController.ResumeTaskForUser)).Methods("PUT")
 	router.HandleFunc("/users/{id}/tasks/{taskID}/end", logRequest(taskController.EndTaskForUser)).Methods("PUT")
+	router.HandleFunc("/users/{id}/tasks/{taskID}/cancel", logRequest(taskController.CancelTaskForUser)).Methods("PUT")
+
+	// Routes for reports
+	router.HandleFunc("/users/{id}/reports/timesheet", logRequest(reportController.GetUserTimesheet)).Methods("GET")
+	router.HandleFunc("/reports/team", logRequest(reportController.GetTeamReport)).Methods("GET")
+	router.HandleFunc("/reports/projects", logRequest(reportController.GetProjectReport)).Methods("GET")
+	router.HandleFunc("/reports/overruns", logRequest(reportController.GetOverrunReport)).Methods("GET")
+
+	// Routes for client and project management
+	router.HandleFunc("/clients", logRequest(clientController.GetClients)).Methods("GET")
+	router.HandleFunc("/clients", logRequest(clientController.AddClient)).Methods("POST")
+	router.HandleFunc("/clients/{clientID}", logRequest(clientController.GetClient)).Methods("GET")
+	router.HandleFunc("/clients/{clientID}", logRequest(clientController.UpdateClient)).Methods("PUT")
+	router.HandleFunc("/clients/{clientID}", logRequest(clientController.DeleteClient)).Methods("DELETE")
+	router.HandleFunc("/projects", logRequest(projectController.GetProjects)).Methods("GET")
+	router.HandleFunc("/projects", logRequest(projectController.AddProject)).Methods("POST")
+	router.HandleFunc("/projects/{projectID}", logRequest(projectController.GetProject)).Methods("GET")
+	router.HandleFunc("/projects/{projectID}", logRequest(projectController.UpdateProject)).Methods("PUT")
+	router.HandleFunc("/projects/{projectID}", logRequest(projectController.DeleteProject)).Methods("DELETE")
+
+	// Routes for tag management
+	router.HandleFunc("/tags", logRequest(tagController.GetTags)).Methods("GET")
+	router.HandleFunc("/tags", logRequest(tagController.AddTag)).Methods("POST")
+	router.HandleFunc("/tags/{tagID}", logRequest(tagController.UpdateTag)).Methods("PUT")
+	router.HandleFunc("/tags/{tagID}", logRequest(tagController.DeleteTag)).Methods("DELETE")
+
+	// Routes for hourly rates
+	router.HandleFunc("/rates", logRequest(rateController.GetRates)).Methods("GET")
+	router.HandleFunc("/rates", logRequest(rateController.AddRate)).Methods("POST")
+	router.HandleFunc("/rates/{rateID}", logRequest(rateController.DeleteRate)).Methods("DELETE")
+
+	// Routes for invoices
+	router.HandleFunc("/invoices", logRequest(invoiceController.GetInvoices)).Methods("GET")
+	router.HandleFunc("/invoices", logRequest(invoiceController.AddInvoice)).Methods("POST")
+	router.HandleFunc("/invoices/{invoiceID}", logRequest(invoiceController.GetInvoice)).Methods("GET")
+	router.HandleFunc("/invoices/{invoiceID}", logRequest(invoiceController.DeleteInvoice)).Methods("DELETE")
+	router.HandleFunc("/invoices/{invoiceID}/send", logRequest(invoiceController.SendInvoice)).Methods("PUT")
+	router.HandleFunc("/invoices/{invoiceID}/pay", logRequest(invoiceController.PayInvoice)).Methods("PUT")
+
+	// Routes for calendar feeds and imports
+	router.HandleFunc("/users/{id}/calendar.ics", logRequest(calendarController.GetUserCalendar)).Methods("GET")
+	router.HandleFunc("/users/{id}/calendar/import", logRequest(calendarController.ImportUserCalendar)).Methods("POST")
+
+	// Routes for timesheet approval
+	router.HandleFunc("/timesheets", logRequest(timesheetController.GetTimesheets)).Methods("GET")
+	router.HandleFunc("/users/{id}/timesheets/{week}", logRequest(timesheetController.GetTimesheet)).Methods("GET")
+	router.HandleFunc("/users/{id}/timesheets/{week}/submit", logRequest(timesheetController.SubmitTimesheet)).Methods("PUT")
+	router.HandleFunc("/users/{id}/timesheets/{week}/approve", logRequest(timesheetController.ApproveTimesheet)).Methods("PUT")
+	router.HandleFunc("/users/{id}/timesheets/{week}/reject", logRequest(timesheetController.RejectTimesheet)).Methods("PUT")
+
+	// Routes for budget events
+	router.HandleFunc("/budget-events", logRequest(budgetEventController.GetBudgetEvents)).Methods("GET")
+	router.HandleFunc("/budget-events/{eventID}/acknowledge", logRequest(budgetEventController.AcknowledgeBudgetEvent)).Methods("PUT")
+
+	// Routes for webhooks
+	router.HandleFunc("/webhooks", logRequest(webhookController.GetWebhooks)).Methods("GET")
+	router.HandleFunc("/webhooks", logRequest(webhookController.AddWebhook)).Methods("POST")
+	router.HandleFunc("/webhooks/{webhookID}", logRequest(webhookController.GetWebhook)).Methods("GET")
+	router.HandleFunc("/webhooks/{webhookID}", logRequest(webhookController.UpdateWebhook)).Methods("PUT")
+	router.HandleFunc("/webhooks/{webhookID}", logRequest(webhookController.DeleteWebhook)).Methods("DELETE")
+	router.HandleFunc("/webhooks/{webhookID}/secret", logRequest(webhookController.RotateSecret)).Methods("POST")
+	router.HandleFunc("/webhooks/{webhookID}/deliveries", logRequest(webhookController.GetDeliveries)).Methods("GET")
+	router.HandleFunc("/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver", logRequest(webhookController.RedeliverDelivery)).Methods("POST")
+
+	// Routes for live timer streams
+	router.HandleFunc("/stream", logRequest(streamController.StreamTeam)).Methods("GET")
+	router.HandleFunc("/users/{id}/stream", logRequest(streamController.StreamUser)).Methods("GET")
+
+	// Routes for GraphQL
+	router.HandleFunc("/graphql", logRequest(graphQLController.Query)).Methods("POST")
+
+	// Routes for imports
+	router.HandleFunc("/imports/timesheets", logRequest(importController.ImportTimesheet)).Methods("POST")
+	router.HandleFunc("/imports/toggl", logRequest(importController.ImportToggl)).Methods("POST")
+	router.HandleFunc("/imports/clockify", logRequest(importController.ImportClockify)).Methods("POST")
 
 	// Setting up sub-routes for API
 	apiRouter := router.PathPrefix("/api").Subrouter()
@@ -0,0 +1,52 @@
+// Package services implements the user and task operations shared by the REST and gRPC APIs,
+// so that both transports validate, persist and notify in the same way.
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Errors returned when a user or task referenced by a request does not exist or cannot be changed.
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrTaskNotFound        = errors.New("task not found")
+	ErrDeletedTaskNotFound = errors.New("deleted task not found")
+	ErrDeleteRunning       = errors.New("a running task cannot be deleted")
+	ErrTaskInvoiced        = errors.New("the task has invoiced time; delete the invoice first")
+)
+
+// ErrExternalAPI is wrapped by the errors returned when the user information service fails.
+var ErrExternalAPI = errors.New("failed to fetch user info from external API")
+
+// InvalidError is returned when a request is invalid, for instance when it references a missing project or tag.
+type InvalidError struct {
+	Message string // Human-readable description
+}
+
+func (e *InvalidError) Error() string {
+	return e.Message
+}
+
+// invalid returns an *InvalidError with the message.
+func invalid(message string) error {
+	return &InvalidError{Message: message}
+}
+
+// Page selects a page of a list. Pages are numbered from 1; values below 1 select the first page of 10 items.
+type Page struct {
+	Number int // Page number
+	Size   int // Page size
+}
+
+// scope limits a query to the page.
+func (p Page) scope(query *gorm.DB) *gorm.DB {
+	if p.Number < 1 {
+		p.Number = 1
+	}
+	if p.Size < 1 {
+		p.Size = 10
+	}
+	return query.Limit(p.Size).Offset((p.Number - 1) * p.Size)
+}
@@ -0,0 +1,533 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+	"time-tracker-go/budgets"
+	"time-tracker-go/live"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+	"time-tracker-go/timesheets"
+	"time-tracker-go/webhooks"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaskQuery selects tasks of a user. Empty fields do not restrict the selection.
+type TaskQuery struct {
+	Statuses    []models.TaskStatus // Any of these statuses
+	Filter      reports.TaskFilter  // Project, client, tag and billable filters
+	CreatedFrom *time.Time          // Created at or after
+	CreatedTo   *time.Time          // Created at or before
+	Deleted     bool                // Soft-deleted tasks instead of active ones
+}
+
+// TaskChanges describes the editable fields of a task. Nil fields are left unchanged.
+type TaskChanges struct {
+	Description       *string
+	ProjectID         *uint   // 0 removes the task from its project
+	TagIDs            *[]uint // Replaces the tags of the task
+	Billable          *bool
+	EstimatedDuration *int // Estimated duration in minutes; 0 removes the estimate
+}
+
+// liveTaskEvents maps lifecycle actions to the live events they publish.
+var liveTaskEvents = map[models.TaskAction]string{
+	models.TaskActionStart:  live.EventTaskStarted,
+	models.TaskActionPause:  live.EventTaskPaused,
+	models.TaskActionResume: live.EventTaskStarted,
+	models.TaskActionEnd:    live.EventTaskEnded,
+	models.TaskActionCancel: live.EventTaskCancelled,
+}
+
+// Tasks manages the tasks of users and their lifecycle. Webhooks and live streams are notified
+// of added, started and ended tasks once the changes have been committed.
+type Tasks struct {
+	DB       *gorm.DB
+	Webhooks *webhooks.Dispatcher
+	Live     *live.Broker
+}
+
+// NewTasks creates the task service with the given DB connection, webhook dispatcher and live broker.
+func NewTasks(db *gorm.DB, dispatcher *webhooks.Dispatcher, broker *live.Broker) *Tasks {
+	return &Tasks{DB: db, Webhooks: dispatcher, Live: broker}
+}
+
+// List returns a page of the tasks of the user matching the query, newest first.
+func (s *Tasks) List(userID uint, query TaskQuery, page Page) ([]models.Task, error) {
+	db := s.where(preloadIntervals(s.DB), query).Where("user_id = ?", userID)
+
+	var tasks []models.Task
+	err := db.Order("created_at DESC").Scopes(page.scope).Find(&tasks).Error
+	return tasks, err
+}
+
+// ListForUsers returns the same page of tasks as List for each of the users, in a single query.
+func (s *Tasks) ListForUsers(userIDs []uint, query TaskQuery, page Page) (map[uint][]models.Task, error) {
+	result := make(map[uint][]models.Task, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	if page.Number < 1 {
+		page.Number = 1
+	}
+	if page.Size < 1 {
+		page.Size = 10
+	}
+
+	// Tasks are numbered per user, newest first, to cut the page out of each user's list.
+	ranked := s.where(s.DB.Model(&models.Task{}), query).
+		Select("tasks.id, ROW_NUMBER() OVER (PARTITION BY tasks.user_id ORDER BY tasks.created_at DESC) AS position").
+		Where("tasks.user_id IN ?", userIDs)
+	pageIDs := s.DB.Table("(?) AS ranked", ranked).
+		Select("ranked.id").
+		Where("ranked.position > ? AND ranked.position <= ?", (page.Number-1)*page.Size, page.Number*page.Size)
+
+	var tasks []models.Task
+	db := preloadIntervals(s.DB)
+	if query.Deleted {
+		db = db.Unscoped()
+	}
+	if err := db.Where("id IN (?)", pageIDs).Order("created_at DESC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		result[task.UserID] = append(result[task.UserID], task)
+	}
+	return result, nil
+}
+
+// where restricts a query of tasks to the ones matching the query.
+func (s *Tasks) where(db *gorm.DB, query TaskQuery) *gorm.DB {
+	if query.Deleted {
+		db = db.Unscoped().Where("tasks.deleted_at IS NOT NULL")
+	}
+	if len(query.Statuses) > 0 {
+		db = db.Where("tasks.status IN ?", query.Statuses)
+	}
+	db = db.Scopes(query.Filter.Scope(s.DB))
+	if query.CreatedFrom != nil {
+		db = db.Where("tasks.created_at >= ?", *query.CreatedFrom)
+	}
+	if query.CreatedTo != nil {
+		db = db.Where("tasks.created_at <= ?", *query.CreatedTo)
+	}
+	return db
+}
+
+// Get returns a task of the user together with its intervals, project and tags.
+func (s *Tasks) Get(userID, taskID uint) (models.Task, error) {
+	var task models.Task
+	err := preloadIntervals(s.DB).Where("user_id=? AND id=?", userID, taskID).First(&task).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return task, ErrTaskNotFound
+	}
+	return task, err
+}
+
+// Running returns the running tasks of the user, or of all users if userID is 0.
+func (s *Tasks) Running(userID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	query := preloadIntervals(s.DB).Where("status = ?", models.TaskStatusRunning)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	err := query.Order("id").Find(&tasks).Error
+	return tasks, err
+}
+
+// TimeEntries returns the time the user spent on tasks in the period. Running tasks are counted up to now.
+func (s *Tasks) TimeEntries(userID uint, period reports.Period, filter reports.TaskFilter) (reports.TimeEntries, error) {
+	return reports.UserTimeEntries(s.DB, userID, period, filter, time.Now())
+}
+
+// Add creates a task for the user. Tags are referenced by ID and must already exist in the catalogue.
+// A task with both a start and an end time is a manual entry and gets a single closed interval;
+// its end must be after its start.
+func (s *Tasks) Add(userID uint, task models.Task) (models.Task, error) {
+	task.UserID = userID
+	task.Intervals = nil
+	task.Project = nil
+
+	if task.EstimatedDuration != nil && *task.EstimatedDuration <= 0 {
+		return task, invalid("Estimated duration must be positive")
+	}
+
+	manual := !task.StartTime.IsZero() && !task.EndTime.IsZero()
+	if manual && !task.EndTime.After(task.StartTime) {
+		return task, invalid("End time must be after start time")
+	}
+
+	if task.ProjectID != nil {
+		if err := s.checkProject(*task.ProjectID); err != nil {
+			return task, err
+		}
+	}
+
+	tagIDs := make([]uint, len(task.Tags))
+	for i, tag := range task.Tags {
+		tagIDs[i] = tag.ID
+	}
+	tags, err := s.findTags(tagIDs)
+	if err != nil {
+		return task, err
+	}
+	task.Tags = tags
+
+	task.Status = models.TaskStatusCreated
+	if manual {
+		endTime := task.EndTime
+		task.Intervals = []models.TaskInterval{{StartTime: task.StartTime, EndTime: &endTime}}
+		task.Status = models.TaskStatusDone
+	}
+	task.RecalculateDuration()
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, userID); err != nil {
+			return err
+		}
+		if err := timesheets.CheckUnlocked(tx, userID, timesheets.IntervalPeriods(task.Intervals, time.Now())...); err != nil {
+			return err
+		}
+		return tx.Create(&task).Error
+	})
+	if err != nil {
+		return task, err
+	}
+
+	s.Webhooks.Emit(webhooks.EventTaskCreated, task)
+	s.Live.Publish(live.EventTaskCreated, task.UserID, task)
+	return task, nil
+}
+
+// Update changes the editable fields of a task. Tasks with time in an approved week cannot be edited,
+// and the billable flag and project of a task with invoiced time cannot be changed.
+func (s *Tasks) Update(userID, taskID uint, changes TaskChanges) (models.Task, error) {
+	task, err := s.Get(userID, taskID)
+	if err != nil {
+		return task, err
+	}
+	billable, projectID := task.Billable, task.ProjectID
+
+	if changes.Description != nil {
+		task.Description = *changes.Description
+	}
+
+	if changes.Billable != nil {
+		task.Billable = *changes.Billable
+	}
+
+	if changes.EstimatedDuration != nil {
+		if *changes.EstimatedDuration < 0 {
+			return task, invalid("Estimated duration must not be negative")
+		}
+		task.EstimatedDuration = nil
+		if *changes.EstimatedDuration != 0 {
+			task.EstimatedDuration = changes.EstimatedDuration
+		}
+	}
+
+	if changes.ProjectID != nil {
+		task.ProjectID = nil
+		if *changes.ProjectID != 0 {
+			if err := s.checkProject(*changes.ProjectID); err != nil {
+				return task, err
+			}
+			task.ProjectID = changes.ProjectID
+		}
+	}
+
+	var tags []models.Tag
+	if changes.TagIDs != nil {
+		if tags, err = s.findTags(*changes.TagIDs); err != nil {
+			return task, err
+		}
+	}
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkTaskUnlocked(tx, task, time.Now(), false); err != nil {
+			return err
+		}
+		// Invoice lines keep the project and rate of the task, so billing fields are frozen once it is invoiced.
+		if task.Billable != billable || !sameProject(task.ProjectID, projectID) {
+			if err := checkTaskNotInvoiced(tx, task.ID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit(clause.Associations).Save(&task).Error; err != nil {
+			return err
+		}
+		if changes.TagIDs != nil {
+			return tx.Model(&task).Association("Tags").Replace(tags)
+		}
+		return nil
+	})
+	if err != nil {
+		return task, err
+	}
+
+	return s.Get(userID, taskID)
+}
+
+// Delete soft-deletes a task. Running tasks must be paused or ended first;
+// tasks with time in an approved week or on an invoice cannot be deleted.
+func (s *Tasks) Delete(userID, taskID uint) error {
+	task, err := s.Get(userID, taskID)
+	if err != nil {
+		return err
+	}
+
+	if task.Status == models.TaskStatusRunning {
+		return ErrDeleteRunning
+	}
+
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkTaskUnlocked(tx, task, time.Now(), false); err != nil {
+			return err
+		}
+		if err := checkTaskNotInvoiced(tx, task.ID); err != nil {
+			return err
+		}
+		return tx.Delete(&task).Error
+	})
+}
+
+// Restore restores a soft-deleted task, unless it has time in an approved week.
+func (s *Tasks) Restore(userID, taskID uint) (models.Task, error) {
+	var task models.Task
+	err := s.DB.Unscoped().Where("user_id=? AND id=? AND deleted_at IS NOT NULL", userID, taskID).First(&task).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return task, ErrDeletedTaskNotFound
+	}
+	if err != nil {
+		return task, err
+	}
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		// The intervals of a deleted task are kept, and restoring it brings its time back.
+		if err := tx.Where("task_id = ?", task.ID).Order("start_time").Find(&task.Intervals).Error; err != nil {
+			return err
+		}
+		if err := checkTaskUnlocked(tx, task, time.Now(), false); err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&task).Update("deleted_at", nil).Error
+	})
+	if err != nil {
+		return task, err
+	}
+
+	return s.Get(userID, taskID)
+}
+
+// Apply moves a task through the given lifecycle action, opening or closing its work intervals accordingly.
+// It returns a *models.TransitionError for illegal transitions and a *timesheets.LockedError if the interval
+// it opens or closes lies in an approved week.
+//
+// A user may have at most one running task. Starting or resuming a task while another one is running
+// fails with a *models.RunningTaskError unless switchRunning is true, in which case the running task
+// is ended first. Ending a task records budget events if it pushed the task over its estimate or its
+// project over budget.
+func (s *Tasks) Apply(userID, taskID uint, action models.TaskAction, switchRunning bool) (models.Task, error) {
+	var task models.Task
+	var switched *models.Task // Running task ended to switch to this one
+	var events []models.BudgetEvent
+	now := time.Now()
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockUser(tx, userID); err != nil {
+			return err
+		}
+
+		if err := preloadIntervals(tx).Where("user_id=? AND id=?", userID, taskID).First(&task).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskNotFound
+			}
+			return err
+		}
+
+		if err := checkTaskUnlocked(tx, task, now, true); err != nil {
+			return err
+		}
+
+		if err := task.Transition(action); err != nil {
+			return err
+		}
+
+		if task.Status == models.TaskStatusRunning {
+			var running models.Task
+			err := preloadIntervals(tx).Where("user_id = ? AND status = ? AND id <> ?", userID, models.TaskStatusRunning, task.ID).First(&running).Error
+			switch {
+			case err == nil && !switchRunning:
+				return &models.RunningTaskError{TaskID: running.ID}
+			case err == nil:
+				if err := checkTaskUnlocked(tx, running, now, true); err != nil {
+					return err
+				}
+				if err := running.Transition(models.TaskActionEnd); err != nil {
+					return err
+				}
+				if err := saveTaskState(tx, &running, now); err != nil {
+					return err
+				}
+				if events, err = budgets.RecordOverruns(tx, running, now); err != nil {
+					return err
+				}
+				switched = &running
+				log.Printf("Task %d ended for user %d to switch to task %d", running.ID, userID, task.ID)
+			case !errors.Is(err, gorm.ErrRecordNotFound):
+				return err
+			}
+		}
+
+		if err := saveTaskState(tx, &task, now); err != nil {
+			return err
+		}
+		if action != models.TaskActionEnd {
+			return nil
+		}
+		overruns, err := budgets.RecordOverruns(tx, task, now)
+		events = append(events, overruns...)
+		return err
+	})
+	if err != nil {
+		return task, err
+	}
+
+	for _, event := range events {
+		log.Printf("Budget event %d: %s by task %d (%d of %d minutes)", event.ID, event.Kind, event.TaskID, event.Actual, event.Estimated)
+	}
+
+	if switched != nil {
+		s.Webhooks.Emit(webhooks.EventTaskEnded, *switched)
+		s.Live.Publish(live.EventTaskEnded, switched.UserID, *switched)
+	}
+	s.Live.Publish(liveTaskEvents[action], task.UserID, task)
+	switch {
+	case task.Status == models.TaskStatusRunning:
+		s.Webhooks.Emit(webhooks.EventTaskStarted, task)
+	case action == models.TaskActionEnd:
+		s.Webhooks.Emit(webhooks.EventTaskEnded, task)
+	}
+	return task, nil
+}
+
+// preloadIntervals loads task intervals ordered by start time and the task project.
+func preloadIntervals(db *gorm.DB) *gorm.DB {
+	return db.Preload("Intervals", func(db *gorm.DB) *gorm.DB {
+		return db.Order("start_time")
+	}).Preload("Project.Client").Preload("Tags")
+}
+
+// findTags loads the tags with the given IDs. It returns an *InvalidError if any of them does not exist.
+func (s *Tasks) findTags(tagIDs []uint) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	if len(tagIDs) == 0 {
+		return tags, nil
+	}
+
+	if err := s.DB.Where("id IN ?", tagIDs).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint]bool, len(tags))
+	for _, tag := range tags {
+		found[tag.ID] = true
+	}
+	for _, tagID := range tagIDs {
+		if !found[tagID] {
+			return nil, invalid("Tag not found")
+		}
+	}
+
+	return tags, nil
+}
+
+// checkProject returns an *InvalidError if the project a task is assigned to does not exist.
+func (s *Tasks) checkProject(projectID uint) error {
+	err := s.DB.Select("id").First(&models.Project{}, projectID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return invalid("Project not found")
+	}
+	return err
+}
+
+// lockUser locks the row of the user until the end of the transaction. Locking the user row serializes
+// state changes of all tasks of the user, so two concurrent requests cannot both start a task,
+// and keeps the weeks of the user from being approved while their time changes.
+func lockUser(tx *gorm.DB, userID uint) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.User{}, userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// checkTaskUnlocked locks the user of the task and returns a *timesheets.LockedError if the change would
+// alter time in an approved week. Edits touch all of the task's time, so all its intervals are checked.
+// State changes only close the open interval and open a new one now, so past intervals are not checked:
+// a paused task with time in an approved week can still be resumed, ended or cancelled.
+func checkTaskUnlocked(tx *gorm.DB, task models.Task, now time.Time, changesState bool) error {
+	if err := lockUser(tx, task.UserID); err != nil {
+		return err
+	}
+	if !changesState {
+		return timesheets.CheckUnlocked(tx, task.UserID, timesheets.IntervalPeriods(task.Intervals, now)...)
+	}
+
+	periods := []reports.Period{{From: now, To: now}}
+	if interval := task.OpenInterval(); interval != nil {
+		periods = append(periods, reports.Period{From: interval.StartTime, To: interval.StartTime})
+	}
+	return timesheets.CheckUnlocked(tx, task.UserID, periods...)
+}
+
+// checkTaskNotInvoiced returns ErrTaskInvoiced if any interval of the task is billed by an invoice
+// that has not been deleted.
+func checkTaskNotInvoiced(tx *gorm.DB, taskID uint) error {
+	var invoiced int64
+	err := tx.Model(&models.TaskInterval{}).
+		Joins("JOIN invoices ON invoices.id = task_intervals.invoice_id AND invoices.deleted_at IS NULL").
+		Where("task_intervals.task_id = ?", taskID).
+		Count(&invoiced).Error
+	if err != nil {
+		return err
+	}
+	if invoiced > 0 {
+		return ErrTaskInvoiced
+	}
+	return nil
+}
+
+// sameProject reports whether two optional project IDs refer to the same project.
+func sameProject(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// saveTaskState persists the intervals and fields of a task after a status change:
+// the open interval is closed, and a new one is opened if the task is running.
+func saveTaskState(tx *gorm.DB, task *models.Task, now time.Time) error {
+	if interval := task.OpenInterval(); interval != nil {
+		if err := tx.Model(interval).Update("end_time", now).Error; err != nil {
+			return err
+		}
+		interval.EndTime = &now
+	}
+
+	if task.Status == models.TaskStatusRunning {
+		interval := models.TaskInterval{TaskID: task.ID, StartTime: now}
+		if err := tx.Create(&interval).Error; err != nil {
+			return err
+		}
+		task.Intervals = append(task.Intervals, interval)
+	}
+
+	task.RecalculateDuration()
+	return tx.Omit(clause.Associations).Save(task).Error
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"time-tracker-go/models"
+)
+
+func TestAddRejectsEndNotAfterStart(t *testing.T) {
+	start := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		end  time.Time
+	}{
+		{"end before start", start.Add(-time.Hour)},
+		{"end at start", start},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The times are checked before the database is used.
+			_, err := (&Tasks{}).Add(1, models.Task{Description: "Report", StartTime: start, EndTime: tt.end})
+			var invalidErr *InvalidError
+			if !errors.As(err, &invalidErr) || invalidErr.Message != "End time must be after start time" {
+				t.Errorf("got error %v, want an InvalidError about the end time", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time-tracker-go/config"
+	"time-tracker-go/models"
+	"time-tracker-go/webhooks"
+
+	"gorm.io/gorm"
+)
+
+// UserFilter selects users. Empty fields do not restrict the selection.
+type UserFilter struct {
+	IDs            []uint // Any of these users
+	PassportNumber string // Exact passport number
+	Surname        string // Exact surname
+	Name           string // Exact name
+	Patronymic     string // Exact patronymic
+	Address        string // Exact address
+	City           string // Substring of the address, case-insensitive
+}
+
+// Scope restricts a query of users to the ones matching the filter.
+func (f UserFilter) Scope(query *gorm.DB) *gorm.DB {
+	if len(f.IDs) > 0 {
+		query = query.Where("users.id IN ?", f.IDs)
+	}
+
+	if f.PassportNumber != "" {
+		query = query.Where("users.passport_number = ?", f.PassportNumber)
+	}
+
+	if f.Surname != "" {
+		query = query.Where("users.surname = ?", f.Surname)
+	}
+
+	if f.Name != "" {
+		query = query.Where("users.name = ?", f.Name)
+	}
+
+	if f.Patronymic != "" {
+		query = query.Where("users.patronymic = ?", f.Patronymic)
+	}
+
+	if f.Address != "" {
+		query = query.Where("users.address = ?", f.Address)
+	}
+
+	if f.City != "" {
+		query = query.Where(`users.address ILIKE ? ESCAPE '\'`, "%"+escapeLike(f.City)+"%")
+	}
+
+	return query
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns and the escape character itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes a value for use in a LIKE pattern with ESCAPE '\', so that it matches literally.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// Users manages users. Webhooks are notified of created and deleted users.
+type Users struct {
+	DB       *gorm.DB
+	Config   config.Config
+	Webhooks *webhooks.Dispatcher
+}
+
+// NewUsers creates the user service with the given DB connection, configuration and webhook dispatcher.
+func NewUsers(db *gorm.DB, config config.Config, dispatcher *webhooks.Dispatcher) *Users {
+	return &Users{DB: db, Config: config, Webhooks: dispatcher}
+}
+
+// List returns a page of the users matching the filter.
+func (s *Users) List(filter UserFilter, page Page) ([]models.User, error) {
+	var users []models.User
+	err := s.DB.Scopes(filter.Scope, page.scope).Find(&users).Error
+	return users, err
+}
+
+// Get returns the user with the ID.
+func (s *Users) Get(id uint) (models.User, error) {
+	var user models.User
+	err := s.DB.First(&user, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return user, ErrUserNotFound
+	}
+	return user, err
+}
+
+// Add creates a user from the passport number ("1234 567890"), fetching the personal details
+// from the user information service.
+func (s *Users) Add(passport string) (models.User, error) {
+	var user models.User
+
+	parts := strings.Split(passport, " ")
+	if len(parts) != 2 {
+		return user, invalid("Invalid passport number format")
+	}
+
+	passportSeries, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return user, invalid("Invalid passport series")
+	}
+
+	passportNumber, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return user, invalid("Invalid passport number")
+	}
+
+	apiURL := fmt.Sprintf(s.Config.ExternalAPIURL+"/info?passportSeries=%d&passportNumber=%d", passportSeries, passportNumber)
+	log.Printf("Calling external API: %s", apiURL)
+
+	resp, err := http.Get(apiURL)
+	if err != nil {
+		return user, fmt.Errorf("%w: %v", ErrExternalAPI, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return user, fmt.Errorf("%w: status code %d", ErrExternalAPI, resp.StatusCode)
+	}
+
+	var people models.People
+	if err := json.NewDecoder(resp.Body).Decode(&people); err != nil {
+		return user, fmt.Errorf("%w: decoding response: %v", ErrExternalAPI, err)
+	}
+
+	user = models.User{
+		PassportNumber: passport,
+		Surname:        people.Surname,
+		Name:           people.Name,
+		Patronymic:     people.Patronymic,
+		Address:        people.Address,
+	}
+	if err := s.DB.Create(&user).Error; err != nil {
+		return user, err
+	}
+
+	s.Webhooks.Emit(webhooks.EventUserCreated, user)
+	return user, nil
+}
+
+// Update replaces the personal details of the user with the ones of changes.
+func (s *Users) Update(id uint, changes models.User) (models.User, error) {
+	user, err := s.Get(id)
+	if err != nil {
+		return user, err
+	}
+
+	user.PassportNumber = changes.PassportNumber
+	user.Surname = changes.Surname
+	user.Name = changes.Name
+	user.Patronymic = changes.Patronymic
+	user.Address = changes.Address
+
+	return user, s.DB.Save(&user).Error
+}
+
+// Delete deletes the user.
+func (s *Users) Delete(id uint) (models.User, error) {
+	user, err := s.Get(id)
+	if err != nil {
+		return user, err
+	}
+
+	if err := s.DB.Delete(&user).Error; err != nil {
+		return user, err
+	}
+
+	s.Webhooks.Emit(webhooks.EventUserDeleted, user)
+	return user, nil
+}
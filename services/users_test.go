@@ -0,0 +1,46 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time-tracker-go/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Moscow", "Moscow"},
+		{"100%", `100\%`},
+		{"St_Petersburg", `St\_Petersburg`},
+		{`C:\Temp`, `C:\\Temp`},
+		{`\%_`, `\\\%\_`},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := escapeLike(tt.value); got != tt.want {
+			t.Errorf("escapeLike(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestUserFilterCity(t *testing.T) {
+	// A dry-run session builds statements without connecting to the database.
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stmt := db.Scopes(UserFilter{City: `50%_off\`}.Scope).Find(&[]models.User{}).Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, `users.address ILIKE $1 ESCAPE '\'`) {
+		t.Errorf("query %q does not escape the city pattern", sql)
+	}
+	if want := []interface{}{`%50\%\_off\\%`}; !reflect.DeepEqual(stmt.Vars, want) {
+		t.Errorf("query arguments are %q, want %q", stmt.Vars, want)
+	}
+}
@@ -0,0 +1,281 @@
+// Package timesheets implements the weekly timesheet approval workflow and the locking of approved weeks.
+package timesheets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"time-tracker-go/models"
+	"time-tracker-go/reports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// weekLayout is the format of week start dates.
+const weekLayout = "2006-01-02"
+
+// Errors returned when a timesheet cannot be submitted or reviewed.
+var (
+	ErrInvalidWeek     = errors.New("week must be the date of a Monday (format: 2006-01-02)")
+	ErrFutureWeek      = errors.New("a week that has not started cannot be submitted")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrTaskRunning     = errors.New("a task of the user is still running in the week")
+	ErrOwnTimesheet    = errors.New("users cannot review their own timesheets")
+	ErrCommentRequired = errors.New("a comment is required to reject a timesheet")
+)
+
+// LockedError is returned when a change would alter time in an approved week.
+type LockedError struct {
+	UserID    uint      // User whose week is locked
+	WeekStart time.Time // Monday the locked week starts on
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("the week of %s is approved and locked", e.WeekStart.Format(weekLayout))
+}
+
+// Timesheet is a week of a user's tracked time together with its approval state.
+type Timesheet struct {
+	models.TimesheetApproval
+	Entries reports.TimeEntries `json:"entries"` // Time tracked in the week
+}
+
+// WeekStart returns the beginning of the week containing t: midnight UTC of its Monday.
+// Weeks are in UTC like the periods of reports, so they do not depend on the time zone of the server.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// ParseWeek parses the date of the Monday a week starts on as midnight UTC.
+func ParseWeek(value string) (time.Time, error) {
+	week, err := time.ParseInLocation(weekLayout, value, time.UTC)
+	if err != nil || week.Weekday() != time.Monday {
+		return time.Time{}, ErrInvalidWeek
+	}
+	return week, nil
+}
+
+// WeekPeriod returns the period covered by the week starting at the given Monday.
+func WeekPeriod(week time.Time) reports.Period {
+	return reports.Period{From: week, To: week.AddDate(0, 0, 7)}
+}
+
+// Find returns the timesheet of the user for the week with the time tracked in it.
+// A week that has never been submitted has an empty status.
+func Find(db *gorm.DB, userID uint, week time.Time, now time.Time) (Timesheet, error) {
+	timesheet := Timesheet{TimesheetApproval: models.TimesheetApproval{UserID: userID, WeekStart: weekDate(week)}}
+
+	err := db.Preload("Reviewer").Where("user_id = ? AND week_start = ?", userID, week.Format(weekLayout)).First(&timesheet.TimesheetApproval).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return timesheet, err
+	}
+
+	timesheet.Entries, err = reports.UserTimeEntries(db, userID, WeekPeriod(week), reports.TaskFilter{}, now)
+	return timesheet, err
+}
+
+// Submit submits the week of the user for approval, recording the time tracked in it.
+// A rejected week can be submitted again. It must run inside a transaction.
+func Submit(tx *gorm.DB, userID uint, week time.Time, now time.Time) (models.TimesheetApproval, error) {
+	if week.After(now) {
+		return models.TimesheetApproval{}, ErrFutureWeek
+	}
+
+	approval, err := lockApproval(tx, userID, week)
+	if err != nil {
+		return approval, err
+	}
+	if err := approval.Transition(models.TimesheetActionSubmit); err != nil {
+		return approval, err
+	}
+	if approval.TotalDuration, err = weekDuration(tx, userID, week, now); err != nil {
+		return approval, err
+	}
+
+	approval.SubmittedAt = &now
+	approval.ReviewerID = nil
+	approval.ReviewedAt = nil
+	approval.Comment = ""
+	return approval, tx.Omit(clause.Associations).Save(&approval).Error
+}
+
+// Approve approves a submitted week, locking the time tracked in it. The total recorded on submission
+// is refreshed, so it matches the locked time. It must run inside a transaction.
+func Approve(tx *gorm.DB, userID uint, week time.Time, reviewerID uint, comment string, now time.Time) (models.TimesheetApproval, error) {
+	if reviewerID == userID {
+		return models.TimesheetApproval{}, ErrOwnTimesheet
+	}
+
+	approval, err := lockApproval(tx, userID, week)
+	if err != nil {
+		return approval, err
+	}
+	if err := approval.Transition(models.TimesheetActionApprove); err != nil {
+		return approval, err
+	}
+	if approval.TotalDuration, err = weekDuration(tx, userID, week, now); err != nil {
+		return approval, err
+	}
+
+	approval.ReviewerID = &reviewerID
+	approval.ReviewedAt = &now
+	approval.Comment = comment
+	return approval, tx.Omit(clause.Associations).Save(&approval).Error
+}
+
+// Reject returns a submitted week to the user with a comment explaining what to correct.
+// It must run inside a transaction.
+func Reject(tx *gorm.DB, userID uint, week time.Time, reviewerID uint, comment string, now time.Time) (models.TimesheetApproval, error) {
+	if reviewerID == userID {
+		return models.TimesheetApproval{}, ErrOwnTimesheet
+	}
+	if strings.TrimSpace(comment) == "" {
+		return models.TimesheetApproval{}, ErrCommentRequired
+	}
+
+	approval, err := lockApproval(tx, userID, week)
+	if err != nil {
+		return approval, err
+	}
+	if err := approval.Transition(models.TimesheetActionReject); err != nil {
+		return approval, err
+	}
+
+	approval.ReviewerID = &reviewerID
+	approval.ReviewedAt = &now
+	approval.Comment = comment
+	return approval, tx.Omit(clause.Associations).Save(&approval).Error
+}
+
+// CheckUnlocked returns a *LockedError if any of the periods of the user's time overlaps an approved week.
+// Empty periods are checked as instants. To keep a week from being approved concurrently, the caller
+// should hold the lock on the user row.
+func CheckUnlocked(db *gorm.DB, userID uint, periods ...reports.Period) error {
+	if len(periods) == 0 {
+		return nil
+	}
+
+	from, to := periods[0].From, periods[0].To
+	for _, period := range periods {
+		if period.From.Before(from) {
+			from = period.From
+		}
+		if period.To.After(to) {
+			to = period.To
+		}
+	}
+
+	locks, err := LoadLocks(db, []uint{userID}, from, to)
+	if err != nil {
+		return err
+	}
+	for _, period := range periods {
+		if week, locked := locks.Locked(userID, period); locked {
+			return &LockedError{UserID: userID, WeekStart: week}
+		}
+	}
+	return nil
+}
+
+// IntervalPeriods returns the periods covered by the intervals. Running intervals last until now.
+func IntervalPeriods(intervals []models.TaskInterval, now time.Time) []reports.Period {
+	periods := make([]reports.Period, len(intervals))
+	for i, interval := range intervals {
+		periods[i] = reports.Period{From: interval.StartTime, To: now}
+		if interval.EndTime != nil {
+			periods[i].To = *interval.EndTime
+		}
+	}
+	return periods
+}
+
+// Locks are the approved weeks of users, keyed by user ID and week start date.
+type Locks map[uint]map[string]bool
+
+// LoadLocks loads the approved weeks of the users that overlap [from, to].
+func LoadLocks(db *gorm.DB, userIDs []uint, from, to time.Time) (Locks, error) {
+	locks := Locks{}
+	if len(userIDs) == 0 {
+		return locks, nil
+	}
+
+	var approvals []models.TimesheetApproval
+	err := db.Select("user_id", "week_start").
+		Where("user_id IN ? AND status = ? AND week_start BETWEEN ? AND ?", userIDs, models.TimesheetStatusApproved, WeekStart(from).Format(weekLayout), WeekStart(to).Format(weekLayout)).
+		Find(&approvals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, approval := range approvals {
+		if locks[approval.UserID] == nil {
+			locks[approval.UserID] = map[string]bool{}
+		}
+		locks[approval.UserID][approval.WeekStart.Format(weekLayout)] = true
+	}
+	return locks, nil
+}
+
+// Locked returns the first approved week of the user that the period overlaps. Empty periods are checked as instants.
+func (l Locks) Locked(userID uint, period reports.Period) (time.Time, bool) {
+	weeks := l[userID]
+	if len(weeks) == 0 {
+		return time.Time{}, false
+	}
+
+	for week := WeekStart(period.From); week.Before(period.To) || week.Equal(WeekStart(period.From)); week = week.AddDate(0, 0, 7) {
+		if weeks[week.Format(weekLayout)] {
+			return week, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// lockApproval loads the approval of the user's week, or a new one if the week has never been submitted,
+// locking the user row so the time of the week cannot change until the end of the transaction.
+func lockApproval(tx *gorm.DB, userID uint, week time.Time) (models.TimesheetApproval, error) {
+	approval := models.TimesheetApproval{UserID: userID, WeekStart: weekDate(week)}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.User{}, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return approval, ErrUserNotFound
+		}
+		return approval, err
+	}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ? AND week_start = ?", userID, week.Format(weekLayout)).First(&approval).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return approval, err
+	}
+	return approval, nil
+}
+
+// weekDuration returns the minutes the user tracked in the week. Time that is still running cannot
+// be signed off, so it fails with ErrTaskRunning if an open interval started before the end of the week.
+func weekDuration(tx *gorm.DB, userID uint, week time.Time, now time.Time) (int, error) {
+	period := WeekPeriod(week)
+
+	var running int64
+	err := tx.Model(&models.TaskInterval{}).
+		Joins("JOIN tasks ON tasks.id = task_intervals.task_id AND tasks.deleted_at IS NULL").
+		Where("tasks.user_id = ? AND task_intervals.end_time IS NULL AND task_intervals.start_time < ?", userID, period.To).
+		Count(&running).Error
+	if err != nil {
+		return 0, err
+	}
+	if running > 0 {
+		return 0, ErrTaskRunning
+	}
+
+	entries, err := reports.UserTimeEntries(tx, userID, period, reports.TaskFilter{}, now)
+	return entries.TotalDuration, err
+}
+
+// weekDate returns the date a week starts on as stored in the database: midnight UTC.
+func weekDate(week time.Time) time.Time {
+	return time.Date(week.Year(), week.Month(), week.Day(), 0, 0, 0, 0, time.UTC)
+}
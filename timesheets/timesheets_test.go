@@ -0,0 +1,104 @@
+package timesheets
+
+import (
+	"testing"
+	"time"
+	"time-tracker-go/reports"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	previous := monday.AddDate(0, 0, -7)
+	moscow := time.FixedZone("MSK", 3*60*60)
+	newYork := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"monday midnight", monday, monday},
+		{"monday noon", monday.Add(12 * time.Hour), monday},
+		{"wednesday", time.Date(2024, 3, 6, 15, 30, 0, 0, time.UTC), monday},
+		{"sunday last second", time.Date(2024, 3, 10, 23, 59, 59, 0, time.UTC), monday},
+		{"next monday midnight", time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), monday.AddDate(0, 0, 7)},
+		{"sunday before", time.Date(2024, 3, 3, 23, 59, 59, 0, time.UTC), previous},
+		// Monday 01:00 in Moscow is still Sunday in UTC.
+		{"monday east of utc", time.Date(2024, 3, 4, 1, 0, 0, 0, moscow), previous},
+		// Sunday 21:00 in New York is already Monday in UTC.
+		{"sunday west of utc", time.Date(2024, 3, 3, 21, 0, 0, 0, newYork), monday},
+		{"across a month", time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC), time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC)},
+		{"across a year", time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC), time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got := WeekStart(tt.t)
+		if !got.Equal(tt.want) || got.Location() != time.UTC {
+			t.Errorf("%s: WeekStart(%s) = %s, want %s", tt.name, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestParseWeek(t *testing.T) {
+	week, err := ParseWeek("2024-03-04")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC); !week.Equal(want) || week.Location() != time.UTC {
+		t.Errorf("ParseWeek(2024-03-04) = %s, want %s", week, want)
+	}
+	if !WeekStart(week).Equal(week) {
+		t.Errorf("WeekStart(%s) = %s, want the week itself", week, WeekStart(week))
+	}
+
+	for _, value := range []string{"2024-03-05", "2024-03-10", "04.03.2024", "2024-3-4", ""} {
+		if _, err := ParseWeek(value); err != ErrInvalidWeek {
+			t.Errorf("ParseWeek(%q) = %v, want ErrInvalidWeek", value, err)
+		}
+	}
+}
+
+func TestLocked(t *testing.T) {
+	approved := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	next := approved.AddDate(0, 0, 7)
+	locks := Locks{1: {"2024-03-04": true}}
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC) }
+	moscow := time.FixedZone("MSK", 3*60*60)
+	sundayMoscow := time.Date(2024, 3, 10, 23, 30, 0, 0, moscow)
+	mondayMoscow := time.Date(2024, 3, 4, 1, 0, 0, 0, moscow)
+
+	tests := []struct {
+		name   string
+		userID uint
+		period reports.Period
+		locked bool
+	}{
+		{"inside the week", 1, reports.Period{From: at(5, 9), To: at(5, 17)}, true},
+		{"instant at monday midnight", 1, reports.Period{From: approved, To: approved}, true},
+		{"instant on sunday night", 1, reports.Period{From: at(10, 23), To: at(10, 23)}, true},
+		{"instant at the next monday", 1, reports.Period{From: next, To: next}, false},
+		{"instant the sunday before", 1, reports.Period{From: at(3, 23), To: at(3, 23)}, false},
+		{"ending at monday midnight", 1, reports.Period{From: at(3, 22), To: approved}, false},
+		{"starting at the next monday", 1, reports.Period{From: next, To: next.Add(time.Hour)}, false},
+		{"across the start of the week", 1, reports.Period{From: at(3, 22), To: at(4, 1)}, true},
+		{"across the end of the week", 1, reports.Period{From: at(10, 22), To: at(11, 1)}, true},
+		{"spanning the week", 1, reports.Period{From: at(1, 0), To: at(15, 0)}, true},
+		{"the week after", 1, reports.Period{From: at(12, 9), To: at(12, 17)}, false},
+		{"other user", 2, reports.Period{From: at(5, 9), To: at(5, 17)}, false},
+		// Sunday 23:30 in Moscow is 20:30 UTC, inside the approved week; Monday 01:00 is still Sunday in UTC.
+		{"sunday east of utc", 1, reports.Period{From: sundayMoscow, To: sundayMoscow}, true},
+		{"monday east of utc", 1, reports.Period{From: mondayMoscow, To: mondayMoscow}, false},
+	}
+	for _, tt := range tests {
+		week, locked := locks.Locked(tt.userID, tt.period)
+		if locked != tt.locked {
+			t.Errorf("%s: Locked = %v, want %v", tt.name, locked, tt.locked)
+		}
+		if locked && !week.Equal(approved) {
+			t.Errorf("%s: locked week is %s, want %s", tt.name, week, approved)
+		}
+	}
+
+	if _, locked := (Locks{}).Locked(1, reports.Period{From: at(5, 9), To: at(5, 17)}); locked {
+		t.Error("a period is locked without approved weeks")
+	}
+}
@@ -0,0 +1,351 @@
+// Package webhooks delivers events of the service to subscribed URLs with signed, retried HTTP requests.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"time-tracker-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Events that webhooks can subscribe to.
+const (
+	EventUserCreated = "user.created"
+	EventUserDeleted = "user.deleted"
+	EventTaskCreated = "task.created"
+	EventTaskStarted = "task.started"
+	EventTaskEnded   = "task.ended"
+)
+
+// Events lists the names of all events that webhooks can subscribe to.
+var Events = []string{EventUserCreated, EventUserDeleted, EventTaskCreated, EventTaskStarted, EventTaskEnded}
+
+// Headers of delivery requests.
+const (
+	HeaderEvent     = "X-Webhook-Event"     // Name of the event
+	HeaderDelivery  = "X-Webhook-Delivery"  // ID of the delivery
+	HeaderTimestamp = "X-Webhook-Timestamp" // Unix time the request was signed at
+	HeaderSignature = "X-Webhook-Signature" // "sha256=" and the hex HMAC-SHA256 of the timestamp, a dot and the body
+)
+
+const (
+	claimSize       = 20               // Number of deliveries claimed at a time
+	claimLease      = 5 * time.Minute  // Time after which a claimed delivery that was not finished is attempted again
+	maxDelay        = time.Hour        // Longest wait between two attempts
+	maxResponseBody = 1024             // Number of bytes of response bodies kept in the delivery log
+	requestTimeout  = 10 * time.Second // Timeout of delivery requests
+)
+
+// Payload is the JSON body posted to webhooks.
+type Payload struct {
+	ID        string      `json:"id"`        // ID of the event; redeliveries keep it, so receivers can drop duplicates
+	Event     string      `json:"event"`     // Name of the event
+	CreatedAt time.Time   `json:"createdAt"` // Time the event occurred
+	Data      interface{} `json:"data"`      // The user or task the event is about
+}
+
+// IsEvent reports whether the name is one of the events webhooks can subscribe to.
+func IsEvent(name string) bool {
+	for _, event := range Events {
+		if event == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the value of the signature header of a request body signed at the timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret generates a random signing secret.
+func NewSecret() (string, error) {
+	return randomHex(32)
+}
+
+// Dispatcher records events as deliveries in the database and posts them to webhooks in the background.
+// Deliveries that fail are retried with exponential backoff. Several dispatchers may share a database.
+type Dispatcher struct {
+	DB           *gorm.DB
+	Client       *http.Client
+	MaxAttempts  int           // Number of attempts before a delivery fails
+	BaseDelay    time.Duration // Wait before the first retry; every further retry waits twice as long
+	PollInterval time.Duration // Interval of checks for deliveries due for a retry
+	wake         chan struct{}
+}
+
+// NewDispatcher creates a dispatcher with the default retry policy: 8 attempts over about an hour.
+func NewDispatcher(db *gorm.DB) *Dispatcher {
+	return &Dispatcher{
+		DB:           db,
+		Client:       &http.Client{Timeout: requestTimeout},
+		MaxAttempts:  8,
+		BaseDelay:    30 * time.Second,
+		PollInterval: 5 * time.Second,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Emit records a delivery of the event to every active webhook subscribed to it. It must be called only
+// after the change the event reports has been committed. Errors are logged, since the change cannot be
+// undone by then. Emitting on a nil dispatcher does nothing.
+func (d *Dispatcher) Emit(event string, data interface{}) {
+	if d == nil {
+		return
+	}
+
+	var webhooks []models.Webhook
+	if err := d.DB.Where("active").Find(&webhooks).Error; err != nil {
+		log.Printf("Error fetching webhooks for %s: %v", event, err)
+		return
+	}
+
+	var deliveries []models.WebhookDelivery
+	now := time.Now()
+	eventID, err := randomHex(16)
+	if err != nil {
+		log.Printf("Error generating ID of %s event: %v", event, err)
+		return
+	}
+	payload, err := json.Marshal(Payload{ID: eventID, Event: event, CreatedAt: now, Data: data})
+	if err != nil {
+		log.Printf("Error encoding %s event: %v", event, err)
+		return
+	}
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event) {
+			deliveries = append(deliveries, models.WebhookDelivery{
+				WebhookID:     webhook.ID,
+				EventID:       eventID,
+				Event:         event,
+				Payload:       string(payload),
+				Status:        models.WebhookDeliveryPending,
+				NextAttemptAt: &now,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+
+	if err := d.DB.Create(&deliveries).Error; err != nil {
+		log.Printf("Error recording deliveries of %s event %s: %v", event, eventID, err)
+		return
+	}
+	d.notify()
+	log.Printf("Queued %s event %s for %d webhooks", event, eventID, len(deliveries))
+}
+
+// Redeliver queues the payload of a delivery again as a new delivery to the same webhook.
+func (d *Dispatcher) Redeliver(delivery models.WebhookDelivery) (models.WebhookDelivery, error) {
+	now := time.Now()
+	redelivery := models.WebhookDelivery{
+		WebhookID:     delivery.WebhookID,
+		EventID:       delivery.EventID,
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &delivery.ID,
+	}
+	if err := d.DB.Create(&redelivery).Error; err != nil {
+		return redelivery, err
+	}
+	d.notify()
+	return redelivery, nil
+}
+
+// Run attempts the deliveries that are due until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.deliverDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// notify wakes the dispatcher up to attempt new deliveries without waiting for the next poll.
+func (d *Dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliverDue attempts all deliveries that are due, a batch at a time.
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := d.claim(time.Now())
+		if err != nil {
+			log.Printf("Error claiming webhook deliveries: %v", err)
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		// Deliveries of a batch are attempted concurrently, so a slow webhook does not hold up the others.
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(delivery *models.WebhookDelivery) {
+				defer wg.Done()
+				d.attempt(ctx, delivery)
+			}(&deliveries[i])
+		}
+		wg.Wait()
+	}
+}
+
+// claim selects deliveries that are due and postpones their next attempt by the lease, so that other
+// dispatchers skip them while they are being attempted.
+func (d *Dispatcher) claim(now time.Time) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := d.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at").Limit(claimSize).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(claimLease)).Error
+	})
+	return deliveries, err
+}
+
+// attempt posts the delivery to its webhook and records the outcome. Failed attempts are retried
+// with exponential backoff until the maximum number of attempts is reached.
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+
+	var webhook models.Webhook
+	err := d.DB.First(&webhook, delivery.WebhookID).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		d.finish(delivery, models.WebhookDeliveryFailed, "webhook was deleted")
+		return
+	case err != nil:
+		d.retry(delivery, now, err.Error())
+		return
+	case !webhook.Active:
+		d.finish(delivery, models.WebhookDeliveryFailed, "webhook is inactive")
+		return
+	}
+
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		d.finish(delivery, models.WebhookDeliveryFailed, err.Error())
+		return
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "time-tracker-go-webhooks")
+	request.Header.Set(HeaderEvent, delivery.Event)
+	request.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	request.Header.Set(HeaderSignature, Sign(webhook.Secret, now.Unix(), body))
+
+	response, err := d.Client.Do(request)
+	if err != nil {
+		d.retry(delivery, now, err.Error())
+		return
+	}
+	defer response.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBody))
+	delivery.ResponseStatus = response.StatusCode
+	delivery.ResponseBody = strings.ToValidUTF8(string(responseBody), "")
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		d.finish(delivery, models.WebhookDeliverySucceeded, "")
+		return
+	}
+	d.retry(delivery, now, fmt.Sprintf("webhook responded with status %d", response.StatusCode))
+}
+
+// retry schedules the next attempt of a failed delivery, or fails it after the last attempt.
+func (d *Dispatcher) retry(delivery *models.WebhookDelivery, now time.Time, reason string) {
+	if delivery.Attempts >= d.MaxAttempts {
+		d.finish(delivery, models.WebhookDeliveryFailed, reason)
+		return
+	}
+
+	next := now.Add(d.retryDelay(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+	delivery.Error = reason
+	d.save(delivery)
+}
+
+// retryDelay returns the wait after the given number of failed attempts: BaseDelay after the first,
+// twice as long after every further one, and at most maxDelay.
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.BaseDelay << (attempts - 1)
+	if delay > maxDelay || delay <= 0 {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// finish records the final state of a delivery.
+func (d *Dispatcher) finish(delivery *models.WebhookDelivery, status models.WebhookDeliveryStatus, reason string) {
+	delivery.Status = status
+	delivery.NextAttemptAt = nil
+	delivery.Error = reason
+	d.save(delivery)
+}
+
+// save records the outcome of an attempt.
+func (d *Dispatcher) save(delivery *models.WebhookDelivery) {
+	err := d.DB.Model(delivery).
+		Select("Status", "Attempts", "NextAttemptAt", "LastAttemptAt", "ResponseStatus", "ResponseBody", "Error").
+		Updates(delivery).Error
+	if err != nil {
+		log.Printf("Error saving webhook delivery %d: %v", delivery.ID, err)
+		return
+	}
+	log.Printf("Webhook delivery %d of %s to webhook %d: attempt %d, %s", delivery.ID, delivery.Event, delivery.WebhookID, delivery.Attempts, delivery.Status)
+}
+
+// randomHex returns n random bytes encoded as hex.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,56 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	// Expected values computed independently with:
+	//   printf '%s' '<timestamp>.<body>' | openssl dgst -sha256 -hmac '<secret>'
+	tests := []struct {
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{"whsec_test", 1700000000, `{"id":"evt_1","event":"task.started"}`, "sha256=d2c634226dac58a8e24bea6dc5c6efce2109ea66bba593c99a84b26bdd848afa"},
+		{"key", 0, "", "sha256=85841b4efc3cd7776c3c8f9b7cca9e281c550e5d19889d78e9e669c6337f000d"},
+	}
+	for _, tt := range tests {
+		if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+			t.Errorf("Sign(%q, %d, %q) = %s, want %s", tt.secret, tt.timestamp, tt.body, got, tt.want)
+		}
+	}
+
+	body := []byte(`{"id":"evt_1"}`)
+	if Sign("a", 1, body) == Sign("b", 1, body) || Sign("a", 1, body) == Sign("a", 2, body) {
+		t.Error("signature does not depend on the secret and the timestamp")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	d := NewDispatcher(nil)
+	want := []time.Duration{
+		30 * time.Second,
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		8 * time.Minute,
+		16 * time.Minute,
+		32 * time.Minute,
+		time.Hour, // 64 minutes, capped
+	}
+	for i, delay := range want {
+		if got := d.retryDelay(i + 1); got != delay {
+			t.Errorf("retryDelay(%d) = %s, want %s", i+1, got, delay)
+		}
+	}
+
+	// Large attempt counts must not overflow into short or negative delays.
+	for _, attempts := range []int{20, 40, 64, 100} {
+		if got := d.retryDelay(attempts); got != maxDelay {
+			t.Errorf("retryDelay(%d) = %s, want %s", attempts, got, maxDelay)
+		}
+	}
+}